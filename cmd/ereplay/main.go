@@ -0,0 +1,44 @@
+// Command ereplay plays back the recordings made with the ereplay package.
+//
+//	ereplay [-speed 2] [-idle 1s] demo.cast
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ravvio/easycli-ui/ereplay"
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+func main() {
+	speed := flag.Float64("speed", 1, "playback speed, 2 plays twice as fast")
+	idle := flag.Duration("idle", 0, "longest pause between two frames, 0 for no limit")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: ereplay [flags] recording.cast\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	width, height, err := ereplay.Size(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ereplay:", err)
+		os.Exit(1)
+	}
+	if w, h := eterm.Size(); w < width || h < height {
+		fmt.Fprintf(os.Stderr, "ereplay: recorded in %dx%d, the terminal is %dx%d\n", width, height, w, h)
+	}
+
+	err = ereplay.NewPlayer(path).WithSpeed(*speed).WithMaxIdle(*idle).Play()
+	if err != nil && !errors.Is(err, ereplay.ErrInterrupted) {
+		fmt.Fprintln(os.Stderr, "ereplay:", err)
+		os.Exit(1)
+	}
+}
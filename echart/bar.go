@@ -0,0 +1,178 @@
+package echart
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Order of the bars of a bar chart.
+type BarSort int
+
+const (
+	// Bars in the order of the values given
+	BarSortNone BarSort = iota
+	BarSortAscending
+	BarSortDescending
+)
+
+// Bar chart style definition.
+type BarChartStyle struct {
+	LabelStyle lipgloss.Style
+	BarStyle   lipgloss.Style
+	ValueStyle lipgloss.Style
+	// Width of the chart in cells, the terminal width when 0
+	Width int
+}
+
+// Default BarChartStyle used by BarChart.
+var BarChartStyleDefault = barChartStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		BarChartStyleDefault = barChartStyle(t)
+	})
+}
+
+// Returns BarChartStyleDefault for the theme t.
+func barChartStyle(t etheme.Theme) BarChartStyle {
+	return BarChartStyle{
+		LabelStyle: lipgloss.NewStyle(),
+		BarStyle:   lipgloss.NewStyle().Foreground(t.Primary),
+		ValueStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+	}
+}
+
+// Bars is a horizontal bar chart, with a bar per label scaled to the largest
+// value.
+type Bars struct {
+	labels []string
+	values []float64
+	sort   BarSort
+	limit  int
+	format func(float64) string
+	style  BarChartStyle
+}
+
+// Create a horizontal bar chart of values, a bar per label. Negative values
+// are drawn as empty bars. When there are more labels than values the extra
+// labels get an empty bar and no value, when there are more values than
+// labels the extra bars get an empty label.
+//
+//	fmt.Println(echart.BarChart(names, sizes).WithSort(echart.BarSortDescending).Render())
+func BarChart(labels []string, values []float64) Bars {
+	return Bars{
+		labels: labels,
+		values: values,
+		format: formatValue,
+		style:  BarChartStyleDefault,
+	}
+}
+
+// Specify the order of the bars.
+//
+//	b := echart.BarChart(names, sizes).WithSort(echart.BarSortDescending)
+func (b Bars) WithSort(s BarSort) Bars {
+	b.sort = s
+	return b
+}
+
+// Keep only the first n bars, after sorting, for top N charts. All the bars
+// are kept when n is 0.
+//
+//	b := echart.BarChart(names, sizes).WithSort(echart.BarSortDescending).WithLimit(10)
+func (b Bars) WithLimit(n int) Bars {
+	b.limit = max(n, 0)
+	return b
+}
+
+// Specify the function formatting the values written after the bars.
+//
+//	b := echart.BarChart(names, sizes).WithValueFormat(func(v float64) string {
+//		return units.Bytes(int64(v))
+//	})
+func (b Bars) WithValueFormat(format func(float64) string) Bars {
+	b.format = format
+	return b
+}
+
+// Specify the style of the chart.
+//
+//	b := echart.BarChart(names, sizes).WithStyle(echart.BarChartStyleDefault)
+func (b Bars) WithStyle(s BarChartStyle) Bars {
+	b.style = s
+	return b
+}
+
+// Specify the width of the chart in cells, overriding the one of the style.
+//
+//	b := echart.BarChart(names, sizes).WithWidth(60)
+func (b Bars) WithWidth(w int) Bars {
+	b.style.Width = w
+	return b
+}
+
+// Render the chart, a line per bar: the label, the bar and its value.
+//
+//	fmt.Println(echart.BarChart(names, sizes).Render())
+func (b Bars) Render() string {
+	n := max(len(b.labels), len(b.values))
+	labels := make([]string, n)
+	copy(labels, b.labels)
+	// The labels from valued on have no value and are sorted last
+	valued := len(b.values)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	switch b.sort {
+	case BarSortAscending:
+		sort.SliceStable(order[:valued], func(i, j int) bool { return b.values[order[i]] < b.values[order[j]] })
+	case BarSortDescending:
+		sort.SliceStable(order[:valued], func(i, j int) bool { return b.values[order[i]] > b.values[order[j]] })
+	}
+	if b.limit > 0 && b.limit < n {
+		order = order[:b.limit]
+	}
+	if len(order) == 0 {
+		return ""
+	}
+
+	width := b.style.Width
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	labelWidth, valueWidth, largest := 0, 0, 0.0
+	values := make([]string, n)
+	for _, i := range order {
+		labelWidth = max(labelWidth, ansi.StringWidth(labels[i]))
+		if i >= valued {
+			continue
+		}
+		values[i] = b.format(b.values[i])
+		valueWidth = max(valueWidth, ansi.StringWidth(values[i]))
+		if !math.IsNaN(b.values[i]) && !math.IsInf(b.values[i], 0) {
+			largest = max(largest, b.values[i])
+		}
+	}
+	// Labels take at most a third of the width
+	labelWidth = min(labelWidth, max(width/3, 1))
+	barWidth := max(width-labelWidth-valueWidth-2, 1)
+
+	lines := make([]string, len(order))
+	for l, i := range order {
+		label := ansi.Truncate(labels[i], labelWidth, "…")
+		label += strings.Repeat(" ", labelWidth-ansi.StringWidth(label))
+		bar := ""
+		if largest > 0 && i < valued {
+			bar = horizontalBar(min(b.values[i]/largest, 1) * float64(barWidth))
+		}
+		lines[l] = b.style.LabelStyle.Render(label) + " " + b.style.BarStyle.Render(bar) + " " + b.style.ValueStyle.Render(values[i])
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,242 @@
+package echart
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Breakdown style definition.
+type BreakdownStyle struct {
+	// Colors of the parts, from the largest, repeated when there are more
+	// parts
+	Colors      []lipgloss.TerminalColor
+	LegendStyle lipgloss.Style
+	ValueStyle  lipgloss.Style
+	Segment     string
+	LegendGlyph string
+	// Width of the bar in cells, the terminal width when 0
+	Width int
+}
+
+// Default BreakdownStyle used by Breakdown.
+var BreakdownStyleDefault = breakdownStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		BreakdownStyleDefault = breakdownStyle(t)
+	})
+}
+
+// Returns BreakdownStyleDefault for the theme t.
+func breakdownStyle(t etheme.Theme) BreakdownStyle {
+	return BreakdownStyle{
+		Colors:      lineChartStyle(t).Colors,
+		LegendStyle: lipgloss.NewStyle(),
+		ValueStyle:  lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		Segment:     "█",
+		LegendGlyph: "■",
+	}
+}
+
+// Parts is a breakdown of a total into parts, drawn as a single stacked bar
+// or as a donut, with a legend of the share of each part.
+type Parts struct {
+	parts  map[string]float64
+	donut  bool
+	format func(float64) string
+	style  BreakdownStyle
+}
+
+// Create a breakdown of the parts of a total, from the largest. Negative and
+// NaN parts are ignored.
+//
+//	fmt.Println(echart.Breakdown(map[string]float64{"images": 42e9, "videos": 18e9}).Render())
+func Breakdown(parts map[string]float64) Parts {
+	return Parts{
+		parts:  parts,
+		format: formatValue,
+		style:  BreakdownStyleDefault,
+	}
+}
+
+// Draw a donut instead of a stacked bar, with the legend on its right.
+//
+//	b := echart.Breakdown(usage).WithDonut(true)
+func (p Parts) WithDonut(donut bool) Parts {
+	p.donut = donut
+	return p
+}
+
+// Specify the function formatting the values of the parts in the legend.
+//
+//	b := echart.Breakdown(usage).WithValueFormat(func(v float64) string {
+//		return units.Bytes(int64(v))
+//	})
+func (p Parts) WithValueFormat(format func(float64) string) Parts {
+	p.format = format
+	return p
+}
+
+// Specify the style of the breakdown.
+//
+//	b := echart.Breakdown(usage).WithStyle(echart.BreakdownStyleDefault)
+func (p Parts) WithStyle(s BreakdownStyle) Parts {
+	p.style = s
+	return p
+}
+
+// Specify the width of the bar in cells, overriding the one of the style.
+//
+//	b := echart.Breakdown(usage).WithWidth(40)
+func (p Parts) WithWidth(w int) Parts {
+	p.style.Width = w
+	return p
+}
+
+// A part of a breakdown.
+type part struct {
+	name  string
+	value float64
+}
+
+// Returns the parts from the largest, and their total.
+func (p Parts) sorted() ([]part, float64) {
+	parts, total := []part{}, 0.0
+	for name, value := range p.parts {
+		if value > 0 && !math.IsInf(value, 0) {
+			parts = append(parts, part{name, value})
+			total += value
+		}
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		if parts[i].value != parts[j].value {
+			return parts[i].value > parts[j].value
+		}
+		return parts[i].name < parts[j].name
+	})
+	return parts, total
+}
+
+// Returns the style of the i-th part.
+func (p Parts) color(i int) lipgloss.Style {
+	if len(p.style.Colors) == 0 {
+		return lipgloss.NewStyle()
+	}
+	return lipgloss.NewStyle().Foreground(p.style.Colors[i%len(p.style.Colors)])
+}
+
+// Render the breakdown: the bar, or the donut, and the legend.
+//
+//	fmt.Println(echart.Breakdown(usage).Render())
+func (p Parts) Render() string {
+	parts, total := p.sorted()
+	if len(parts) == 0 {
+		return ""
+	}
+
+	names, values, shares := make([]string, len(parts)), make([]string, len(parts)), make([]string, len(parts))
+	nameWidth, shareWidth := 0, 0
+	for i, part := range parts {
+		names[i] = part.name
+		values[i] = p.format(part.value)
+		shares[i] = fmt.Sprintf("%.1f%%", part.value/total*100)
+		nameWidth = max(nameWidth, ansi.StringWidth(part.name))
+		shareWidth = max(shareWidth, len(shares[i]))
+	}
+	legend := make([]string, len(parts))
+	for i := range parts {
+		legend[i] = p.color(i).Render(p.style.LegendGlyph) + " " +
+			p.style.LegendStyle.Render(names[i]+strings.Repeat(" ", nameWidth-ansi.StringWidth(names[i]))) + " " +
+			strings.Repeat(" ", shareWidth-len(shares[i])) + shares[i] + " " +
+			p.style.ValueStyle.Render(values[i])
+	}
+
+	if p.donut {
+		lines := strings.Split(lipgloss.JoinHorizontal(lipgloss.Center, p.renderDonut(parts, total), "  ", strings.Join(legend, "\n")), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " ")
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	width := p.style.Width
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	var bar strings.Builder
+	for i, cells := range apportion(parts, total, width) {
+		bar.WriteString(p.color(i).Render(strings.Repeat(p.style.Segment, cells)))
+	}
+	return bar.String() + "\n" + strings.Join(legend, "\n")
+}
+
+// Split width cells among the parts proportionally, giving the cells left
+// by rounding down to the parts with the largest remainders.
+func apportion(parts []part, total float64, width int) []int {
+	cells := make([]int, len(parts))
+	remainders := make([]int, len(parts))
+	used := 0
+	for i, part := range parts {
+		exact := part.value / total * float64(width)
+		cells[i] = int(exact)
+		used += cells[i]
+		remainders[i] = i
+	}
+	sort.SliceStable(remainders, func(a, b int) bool {
+		ra := parts[remainders[a]].value/total*float64(width) - float64(cells[remainders[a]])
+		rb := parts[remainders[b]].value/total*float64(width) - float64(cells[remainders[b]])
+		return ra > rb
+	})
+	for _, i := range remainders[:width-used] {
+		cells[i]++
+	}
+	return cells
+}
+
+// Radii of the donut, in rows.
+const (
+	donutOuter = 4.5
+	donutInner = 2.2
+)
+
+// Render the parts as a donut, clockwise from the top. Cells are twice as
+// high as wide, so the donut is twice as wide in cells as high.
+func (p Parts) renderDonut(parts []part, total float64) string {
+	// Angles at which each part ends, as fractions of the turn
+	ends := make([]float64, len(parts))
+	sum := 0.0
+	for i, part := range parts {
+		sum += part.value
+		ends[i] = sum / total
+	}
+
+	size := int(math.Ceil(donutOuter * 2))
+	lines := make([]string, size)
+	for row := range size {
+		var b strings.Builder
+		for col := range size * 2 {
+			dx := (float64(col)+0.5)/2 - donutOuter
+			dy := float64(row) + 0.5 - donutOuter
+			dist := math.Hypot(dx, dy)
+			if dist > donutOuter || dist < donutInner {
+				b.WriteString(" ")
+				continue
+			}
+			turn := math.Atan2(dx, -dy) / (2 * math.Pi)
+			if turn < 0 {
+				turn++
+			}
+			i := sort.SearchFloat64s(ends, turn)
+			b.WriteString(p.color(min(i, len(parts)-1)).Render(p.style.Segment))
+		}
+		lines[row] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
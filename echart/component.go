@@ -0,0 +1,359 @@
+package echart
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// Identifiers of the ChartModels, to route their messages.
+var lastChartID atomic.Int64
+
+// Kind of chart drawn by a ChartModel.
+type ChartKind int
+
+const (
+	// A braille line chart of all the series, see LineChart
+	ChartKindLine ChartKind = iota
+	// A sparkline per series, followed by its last value, see Sparkline
+	ChartKindSparkline
+)
+
+// PointMsg adds a value to a series of the ChartModel with the same ID.
+type PointMsg struct {
+	ID     int64
+	Series string
+	Value  float64
+}
+
+// RedrawMsg redraws the ChartModel with the same ID, if it received new
+// values.
+type RedrawMsg struct {
+	ID int64
+}
+
+// ChartModel is a chart of series of values received over time, to embed in
+// an existing bubbletea program: send it the values with Point, forward it
+// the messages of the program and render its View. Only the last values of
+// each series are kept, so the chart scrolls as new ones arrive, and it is
+// redrawn at most once per rate to keep fast streams cheap.
+//
+//	type model struct {
+//		chart echart.ChartModel
+//	}
+//
+//	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+//		var cmd tea.Cmd
+//		m.chart, cmd = m.chart.Update(msg)
+//		return m, cmd
+//	}
+type ChartModel struct {
+	id     int64
+	kind   ChartKind
+	width  int
+	height int
+	window int
+	rate   time.Duration
+	lo, hi float64
+	fixed  bool
+	style  LineChartStyle
+	series map[string][]float64
+	view   string
+	dirty  bool
+}
+
+// Create a new ChartModel of width by height cells, drawing a line chart
+// redrawn every 250ms and keeping as many values per series as fit its
+// width.
+//
+//	chart := echart.NewChart(80, 12)
+func NewChart(width, height int) ChartModel {
+	return ChartModel{
+		id:     lastChartID.Add(1),
+		width:  width,
+		height: height,
+		rate:   250 * time.Millisecond,
+		style:  LineChartStyleDefault,
+		series: map[string][]float64{},
+	}
+}
+
+// Specify the kind of chart drawn.
+//
+//	chart := echart.NewChart(60, 3).WithKind(echart.ChartKindSparkline)
+func (m ChartModel) WithKind(k ChartKind) ChartModel {
+	m.kind = k
+	m.view = ""
+	return m
+}
+
+// Specify the size of the chart in cells, for example after a
+// tea.WindowSizeMsg.
+//
+//	m.chart = m.chart.WithSize(msg.Width, msg.Height-1)
+func (m ChartModel) WithSize(width, height int) ChartModel {
+	m.width, m.height = width, height
+	m.view = ""
+	return m
+}
+
+// Specify the number of values kept per series, the oldest ones are
+// discarded. When 0, as many as fit the width of the chart are kept.
+//
+//	chart := echart.NewChart(80, 12).WithWindow(300)
+func (m ChartModel) WithWindow(n int) ChartModel {
+	m.window = max(n, 0)
+	return m
+}
+
+// Specify the interval between two redraws of the chart.
+//
+//	chart := echart.NewChart(80, 12).WithRate(time.Second)
+func (m ChartModel) WithRate(d time.Duration) ChartModel {
+	m.rate = max(d, time.Millisecond)
+	return m
+}
+
+// Scale the line chart between lo and hi instead of between the smallest and
+// the largest value kept.
+//
+//	chart := echart.NewChart(80, 12).WithRange(0, 100)
+func (m ChartModel) WithRange(lo, hi float64) ChartModel {
+	m.lo, m.hi, m.fixed = lo, hi, hi > lo
+	m.view = ""
+	return m
+}
+
+// Specify the style of the chart.
+//
+//	chart := echart.NewChart(80, 12).WithStyle(echart.LineChartStyleDefault)
+func (m ChartModel) WithStyle(s LineChartStyle) ChartModel {
+	m.style = s
+	m.view = ""
+	return m
+}
+
+// Identifier of the ChartModel, set in its messages.
+func (m ChartModel) ID() int64 {
+	return m.id
+}
+
+// Returns the message adding value to the series, to send to the program
+// from any goroutine.
+//
+//	program.Send(m.chart.Point("cpu", usage))
+func (m ChartModel) Point(series string, value float64) PointMsg {
+	return PointMsg{ID: m.id, Series: series, Value: value}
+}
+
+// Returns the values kept of a series, oldest first.
+func (m ChartModel) Values(series string) []float64 {
+	return m.series[series]
+}
+
+// Start redrawing the ChartModel.
+func (m ChartModel) Init() tea.Cmd {
+	return m.nextRedraw()
+}
+
+func (m ChartModel) nextRedraw() tea.Cmd {
+	id := m.id
+	return tea.Tick(m.rate, func(time.Time) tea.Msg {
+		return RedrawMsg{ID: id}
+	})
+}
+
+func (m ChartModel) Update(msg tea.Msg) (ChartModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case PointMsg:
+		if msg.ID != m.id {
+			return m, nil
+		}
+		values := append(m.series[msg.Series], msg.Value)
+		if window := m.capacity(); len(values) > window {
+			values = append([]float64(nil), values[len(values)-window:]...)
+		}
+		m.series[msg.Series] = values
+		m.dirty = true
+	case RedrawMsg:
+		if msg.ID != m.id {
+			return m, nil
+		}
+		if m.dirty || m.view == "" {
+			m.view, m.dirty = m.render(), false
+		}
+		return m, m.nextRedraw()
+	}
+	return m, nil
+}
+
+// Number of values kept per series.
+func (m ChartModel) capacity() int {
+	if m.window > 0 {
+		return m.window
+	}
+	if m.kind == ChartKindSparkline {
+		return max(m.width, 1)
+	}
+	// Two values per braille cell
+	return max(m.width*2, 1)
+}
+
+// Returns the names of the series, sorted.
+func (m ChartModel) names() []string {
+	names := make([]string, 0, len(m.series))
+	for name := range m.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Draw the chart with the values kept.
+func (m ChartModel) render() string {
+	if m.kind == ChartKindSparkline {
+		names := m.names()
+		nameWidth := 0
+		for _, name := range names {
+			nameWidth = max(nameWidth, ansi.StringWidth(name))
+		}
+		lines := make([]string, 0, len(names))
+		for i, name := range names {
+			values := m.series[name]
+			last := formatValue(values[len(values)-1])
+			width := max(m.width-nameWidth-ansi.StringWidth(last)-2, 1)
+			style := lipgloss.NewStyle()
+			if len(m.style.Colors) > 0 {
+				style = style.Foreground(m.style.Colors[i%len(m.style.Colors)])
+			}
+			label := m.style.LegendStyle.Render(name + strings.Repeat(" ", nameWidth-ansi.StringWidth(name)))
+			lines = append(lines, label+" "+style.Render(Sparkline(values, width))+" "+m.style.LabelStyle.Render(last))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	chart := LineChart(m.series, m.width, m.height).WithStyle(m.style)
+	if m.fixed {
+		chart = chart.WithRange(m.lo, m.hi)
+	}
+	return chart.Render()
+}
+
+func (m ChartModel) View() string {
+	if m.view == "" {
+		return m.render()
+	}
+	return m.view
+}
+
+// The bubbletea.Msg carrying a value sampled by Watch
+type watchMsgSample struct {
+	value float64
+	err   error
+}
+
+// Sample a value every interval and show it as a scrolling line chart
+// filling the terminal, with title and the last value in the status line,
+// until the user quits with q, Esc or Ctrl+C, like watch. Returns the error
+// of sample, which stops the chart.
+// When stdout is not a terminal the values are printed one per line
+// instead.
+//
+//	err := echart.Watch("queue depth", time.Second, func() (float64, error) {
+//		return queue.Depth(ctx)
+//	})
+func Watch(title string, interval time.Duration, sample func() (float64, error)) error {
+	if !eterm.IsTerminal(os.Stdout) {
+		for {
+			v, err := sample()
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Println(formatValue(v)); err != nil {
+				return err
+			}
+			time.Sleep(interval)
+		}
+	}
+
+	m := watchModel{
+		title:    title,
+		interval: interval,
+		sample:   sample,
+		chart:    NewChart(eterm.Width(), 10),
+	}
+	final, err := live.Run(tea.NewProgram(m, tea.WithAltScreen()))
+	if err != nil {
+		return err
+	}
+	return final.(watchModel).err
+}
+
+// Bubbletea model of a running Watch.
+type watchModel struct {
+	title    string
+	interval time.Duration
+	sample   func() (float64, error)
+	chart    ChartModel
+	last     string
+	width    int
+	err      error
+}
+
+// Returns the command sampling the next value, after delay.
+func (m watchModel) next(delay time.Duration) tea.Cmd {
+	sample := m.sample
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		v, err := sample()
+		return watchMsgSample{value: v, err: err}
+	})
+}
+
+func (m watchModel) Init() tea.Cmd {
+	return tea.Batch(m.chart.Init(), m.next(0))
+}
+
+func (m watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.chart = m.chart.WithSize(msg.Width, max(msg.Height-1, 3))
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	case watchMsgSample:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		m.last = formatValue(msg.value)
+		m.chart, _ = m.chart.Update(m.chart.Point(m.title, msg.value))
+		return m, m.next(m.interval)
+	}
+	var cmd tea.Cmd
+	m.chart, cmd = m.chart.Update(msg)
+	return m, cmd
+}
+
+func (m watchModel) View() string {
+	left := " " + m.title
+	if m.last != "" {
+		left += ": " + m.last
+	}
+	right := fmt.Sprintf(" every %s · %s ", m.interval, ekeys.Plain(ekeys.Quit))
+	left = ansi.Truncate(left, max(m.width-ansi.StringWidth(right), 0), "…")
+	fill := strings.Repeat(" ", max(m.width-ansi.StringWidth(left)-ansi.StringWidth(right), 0))
+	return m.chart.View() + "\n" + lipgloss.NewStyle().Reverse(true).Render(left+fill+right)
+}
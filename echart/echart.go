@@ -0,0 +1,35 @@
+// Package echart renders charts of numeric data, like bar charts and
+// sparklines, sized to the terminal.
+package echart
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Format a value with at most two decimals, without trailing zeros.
+func formatValue(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	v = math.Round(v*100) / 100
+	if v == 0 {
+		// Avoid -0
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Eighths of a cell, from the empty to the full block, to draw horizontal
+// bars with sub-cell precision.
+var eighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉", "█"}
+
+// Returns a horizontal bar of length cells, which may be fractional.
+func horizontalBar(length float64) string {
+	if length <= 0 || math.IsNaN(length) {
+		return ""
+	}
+	full := int(length)
+	return strings.Repeat(eighths[8], full) + eighths[int(math.Round((length-float64(full))*8))]
+}
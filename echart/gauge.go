@@ -0,0 +1,153 @@
+package echart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Gauge style definition.
+type GaugeStyle struct {
+	LabelStyle lipgloss.Style
+	// Styles of the filled part of the meter in each zone
+	OKStyle       lipgloss.Style
+	WarningStyle  lipgloss.Style
+	CriticalStyle lipgloss.Style
+	TrackStyle    lipgloss.Style
+	ValueStyle    lipgloss.Style
+	Filled        string
+	Empty         string
+	// Width of the meter in cells
+	Width int
+}
+
+// Default GaugeStyle used by Gauge.
+var GaugeStyleDefault = gaugeStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		GaugeStyleDefault = gaugeStyle(t)
+		GaugeStyleASCII = gaugeStyleASCII(t)
+	})
+}
+
+// Returns GaugeStyleDefault for the theme t, drawing the meter with ASCII
+// characters when the terminal cannot display unicode.
+func gaugeStyle(t etheme.Theme) GaugeStyle {
+	s := GaugeStyle{
+		LabelStyle:    lipgloss.NewStyle().Bold(true),
+		OKStyle:       lipgloss.NewStyle().Foreground(t.Success),
+		WarningStyle:  lipgloss.NewStyle().Foreground(t.Warning),
+		CriticalStyle: lipgloss.NewStyle().Foreground(t.Error),
+		TrackStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		ValueStyle:    lipgloss.NewStyle(),
+		Filled:        "█",
+		Empty:         "░",
+		Width:         20,
+	}
+	if !eterm.Unicode() {
+		s.Filled, s.Empty = "#", "-"
+	}
+	return s
+}
+
+// GaugeStyle drawing the meter with ASCII characters.
+var GaugeStyleASCII = gaugeStyleASCII(etheme.Current())
+
+// Returns GaugeStyleASCII for the theme t.
+func gaugeStyleASCII(t etheme.Theme) GaugeStyle {
+	s := gaugeStyle(t)
+	s.Filled = "#"
+	s.Empty = "-"
+	return s
+}
+
+// Meter is a single horizontal meter of a value, colored by the zone the
+// value falls in.
+type Meter struct {
+	value    float64
+	max      float64
+	label    string
+	warning  float64
+	critical float64
+	style    GaugeStyle
+}
+
+// Create a meter of value out of max, written after label. The meter is
+// green, yellow from 70% and red from 90%, see WithThresholds.
+//
+//	fmt.Println(echart.Gauge(used, total, "disk").Render())
+func Gauge(value, max float64, label string) Meter {
+	return Meter{
+		value:    value,
+		max:      max,
+		label:    label,
+		warning:  0.7,
+		critical: 0.9,
+		style:    GaugeStyleDefault,
+	}
+}
+
+// Specify the fractions of max from which the meter is yellow and red.
+// When warning is greater than critical the zones are reversed, for meters
+// where low values are bad, like the charge of a battery.
+//
+//	g := echart.Gauge(charge, 100, "battery").WithThresholds(0.3, 0.1)
+func (g Meter) WithThresholds(warning, critical float64) Meter {
+	g.warning, g.critical = warning, critical
+	return g
+}
+
+// Specify the style of the meter.
+//
+//	g := echart.Gauge(used, total, "disk").WithStyle(echart.GaugeStyleASCII)
+func (g Meter) WithStyle(s GaugeStyle) Meter {
+	g.style = s
+	return g
+}
+
+// Specify the width of the meter in cells, overriding the one of the style.
+//
+//	g := echart.Gauge(used, total, "disk").WithWidth(40)
+func (g Meter) WithWidth(w int) Meter {
+	g.style.Width = w
+	return g
+}
+
+// Returns the style of the filled part of the meter at fraction.
+func (g Meter) zone(fraction float64) lipgloss.Style {
+	if g.warning > g.critical {
+		fraction, g.warning, g.critical = -fraction, -g.warning, -g.critical
+	}
+	switch {
+	case fraction >= g.critical:
+		return g.style.CriticalStyle
+	case fraction >= g.warning:
+		return g.style.WarningStyle
+	}
+	return g.style.OKStyle
+}
+
+// Render the meter: the label, the meter and the percentage.
+//
+//	disk ████████████████░░░░  82%
+func (g Meter) Render() string {
+	fraction := 0.0
+	if g.max > 0 && !math.IsNaN(g.value) {
+		fraction = min(max(g.value/g.max, 0), 1)
+	}
+	width := max(g.style.Width, 1)
+	filled := int(math.Round(fraction * float64(width)))
+
+	meter := g.zone(fraction).Render(strings.Repeat(g.style.Filled, filled)) +
+		g.style.TrackStyle.Render(strings.Repeat(g.style.Empty, width-filled))
+	value := g.style.ValueStyle.Render(fmt.Sprintf("%3.0f%%", fraction*100))
+	if g.label == "" {
+		return meter + " " + value
+	}
+	return g.style.LabelStyle.Render(g.label) + " " + meter + " " + value
+}
@@ -0,0 +1,237 @@
+package echart
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/ecolor"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Heatmap style definition. The cells are colored from From, for the
+// smallest value, to To, for the largest.
+type HeatmapStyle struct {
+	From       lipgloss.Color
+	To         lipgloss.Color
+	LabelStyle lipgloss.Style
+	// Content of each cell, its background is colored
+	Cell string
+	// Characters filling the cells instead of the colors when the terminal
+	// has none, from the smallest value to the largest
+	Shades string
+}
+
+// Default HeatmapStyle used by Heatmap, along the gradient of the
+// etheme.Theme.
+var HeatmapStyleDefault = heatmapStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		HeatmapStyleDefault = heatmapStyle(t)
+		HeatmapStyleASCII = heatmapStyleASCII(t)
+	})
+}
+
+// Returns HeatmapStyleDefault for the theme t, shading the cells with ASCII
+// characters when the terminal cannot display unicode.
+func heatmapStyle(t etheme.Theme) HeatmapStyle {
+	s := HeatmapStyle{
+		From:       t.GradientFrom,
+		To:         t.GradientTo,
+		LabelStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		Cell:       "  ",
+		Shades:     " ░▒▓█",
+	}
+	if !eterm.Unicode() {
+		s.Shades = " .:*#"
+	}
+	return s
+}
+
+// HeatmapStyle shading the cells with ASCII characters.
+var HeatmapStyleASCII = heatmapStyleASCII(etheme.Current())
+
+// Returns HeatmapStyleASCII for the theme t.
+func heatmapStyleASCII(t etheme.Theme) HeatmapStyle {
+	s := heatmapStyle(t)
+	s.Shades = " .:*#"
+	return s
+}
+
+// Returns the cell of value v between lo and hi, colored or shaded when the
+// terminal has no colors.
+func (s HeatmapStyle) render(v, lo, hi float64) string {
+	if shades := []rune(s.Shades); len(shades) > 0 && eterm.Colors() == eterm.NoColor {
+		shade := string(shades[level(v, lo, hi, len(shades))])
+		return strings.Repeat(shade, ansi.StringWidth(s.Cell))
+	}
+	fraction := 1.0
+	if hi > lo {
+		fraction = (v - lo) / (hi - lo)
+	}
+	return lipgloss.NewStyle().Background(ecolor.Blend(s.From, s.To, fraction)).Render(s.Cell)
+}
+
+// Heat is a grid of values drawn as cells colored by intensity, with
+// optional labels for the rows and the columns.
+type Heat struct {
+	matrix       [][]float64
+	rowLabels    []string
+	columnLabels []string
+	legend       bool
+	style        HeatmapStyle
+}
+
+// Create a heatmap of matrix, a row of cells per slice. NaN values are drawn
+// as empty cells. When the terminal has no colors the cells are shaded with
+// the characters of the style instead.
+//
+//	fmt.Println(echart.Heatmap(load).WithRowLabels(hosts).Render())
+func Heatmap(matrix [][]float64) Heat {
+	return Heat{
+		matrix: matrix,
+		style:  HeatmapStyleDefault,
+	}
+}
+
+// Create a calendar heatmap of a value per day from start: a column per
+// week and a row per weekday, labelled with the months and the days of the
+// week, like a contribution graph.
+//
+//	fmt.Println(echart.CalendarHeatmap(time.Now().AddDate(0, 0, -len(commits)+1), commits).Render())
+func CalendarHeatmap(start time.Time, values []float64) Heat {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	// Weeks start on Monday
+	lead := (int(start.Weekday()) + 6) % 7
+	weeks := (lead + len(values) + 6) / 7
+
+	matrix := make([][]float64, 7)
+	for day := range matrix {
+		matrix[day] = make([]float64, weeks)
+		for week := range matrix[day] {
+			matrix[day][week] = math.NaN()
+		}
+	}
+	columns := make([]string, weeks)
+	month := time.Month(0)
+	for i, v := range values {
+		cell := lead + i
+		matrix[cell%7][cell/7] = v
+		if date := start.AddDate(0, 0, i); date.Month() != month {
+			month = date.Month()
+			if cell%7 == 0 || i == 0 {
+				columns[cell/7] = month.String()[:3]
+			} else if cell/7+1 < weeks {
+				// The month is labelled on its first full week
+				columns[cell/7+1] = month.String()[:3]
+			}
+		}
+	}
+	return Heatmap(matrix).
+		WithRowLabels([]string{"Mon", "", "Wed", "", "Fri", "", ""}).
+		WithColumnLabels(columns).
+		WithLegend(true)
+}
+
+// Specify the labels written before the rows.
+//
+//	h := echart.Heatmap(load).WithRowLabels([]string{"web-1", "web-2"})
+func (h Heat) WithRowLabels(labels []string) Heat {
+	h.rowLabels = labels
+	return h
+}
+
+// Specify the labels written above the columns, starting at their column.
+// Labels overlapping the previous one are skipped.
+//
+//	h := echart.Heatmap(load).WithColumnLabels([]string{"00", "", "", "03"})
+func (h Heat) WithColumnLabels(labels []string) Heat {
+	h.columnLabels = labels
+	return h
+}
+
+// Write a legend of the colors from the smallest to the largest value below
+// the grid.
+//
+//	h := echart.Heatmap(load).WithLegend(true)
+func (h Heat) WithLegend(legend bool) Heat {
+	h.legend = legend
+	return h
+}
+
+// Specify the style of the heatmap.
+//
+//	h := echart.Heatmap(load).WithStyle(echart.HeatmapStyleDefault)
+func (h Heat) WithStyle(s HeatmapStyle) Heat {
+	h.style = s
+	return h
+}
+
+// Render the heatmap: the column labels, a line per row and the legend.
+//
+//	fmt.Println(echart.Heatmap(load).Render())
+func (h Heat) Render() string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	columns := 0
+	for _, row := range h.matrix {
+		rlo, rhi := bounds(row)
+		lo, hi = min(lo, rlo), max(hi, rhi)
+		columns = max(columns, len(row))
+	}
+
+	labelWidth := 0
+	for _, label := range h.rowLabels {
+		labelWidth = max(labelWidth, ansi.StringWidth(label))
+	}
+	indent := ""
+	if labelWidth > 0 {
+		indent = strings.Repeat(" ", labelWidth+1)
+	}
+	cellWidth := ansi.StringWidth(h.style.Cell)
+
+	lines := []string{}
+	if len(h.columnLabels) > 0 {
+		header, end := "", 0
+		for i, label := range h.columnLabels {
+			if label == "" || i*cellWidth < end {
+				continue
+			}
+			header += strings.Repeat(" ", i*cellWidth-end) + label
+			end = i*cellWidth + ansi.StringWidth(label)
+		}
+		lines = append(lines, indent+h.style.LabelStyle.Render(header))
+	}
+
+	blank := strings.Repeat(" ", cellWidth)
+	for r, row := range h.matrix {
+		var b strings.Builder
+		if labelWidth > 0 {
+			label := ""
+			if r < len(h.rowLabels) {
+				label = h.rowLabels[r]
+			}
+			b.WriteString(h.style.LabelStyle.Render(label+strings.Repeat(" ", labelWidth-ansi.StringWidth(label))) + " ")
+		}
+		for c := range columns {
+			if c >= len(row) || math.IsNaN(row[c]) {
+				b.WriteString(blank)
+				continue
+			}
+			b.WriteString(h.style.render(row[c], lo, hi))
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+
+	if h.legend && !math.IsInf(lo, 0) {
+		legend := h.style.LabelStyle.Render(formatValue(lo) + " ")
+		for i := range 5 {
+			legend += h.style.render(lo+(hi-lo)*float64(i)/4, lo, hi)
+		}
+		lines = append(lines, "", indent+legend+h.style.LabelStyle.Render(" "+formatValue(hi)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,31 @@
+package echart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestHeatmapShades(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	tests := []struct {
+		shades string
+		want   string
+	}{
+		{" ░▒▓█", "  ░░▒▒▓▓██\n██    ░░\n\n0   ░░▒▒▓▓██ 4"},
+		{HeatmapStyleASCII.Shades, "  ..::**##\n##    ..\n\n0   ..::**## 4"},
+	}
+	for _, tt := range tests {
+		style := HeatmapStyleDefault
+		style.Shades = tt.shades
+		got := Heatmap([][]float64{{0, 1, 2, 3, 4}, {4, math.NaN(), 0, 1}}).WithLegend(true).WithStyle(style).Render()
+		if got != tt.want {
+			t.Errorf("Render =\n%q\nwant\n%q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package echart
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Hist is a histogram of numeric samples, a bar per bucket of values
+// labelled with the range of the bucket and annotated with its count.
+type Hist struct {
+	samples []float64
+	bins    int
+	lo, hi  float64
+	fixed   bool
+	format  func(float64) string
+	style   BarChartStyle
+}
+
+// Create a histogram of samples bucketed in bins buckets of equal width
+// between the smallest and the largest sample. The number of buckets is
+// chosen from the number of samples when bins is 0. NaN and infinite samples
+// are ignored.
+//
+//	fmt.Println(echart.Histogram(latencies, 10).Render())
+func Histogram(samples []float64, bins int) Hist {
+	return Hist{
+		samples: samples,
+		bins:    bins,
+		format:  formatValue,
+		style:   BarChartStyleDefault,
+	}
+}
+
+// Bucket the values between lo and hi instead of between the smallest and
+// the largest sample. The samples outside are counted in the first and the
+// last bucket.
+//
+//	h := echart.Histogram(latencies, 10).WithRange(0, 500)
+func (h Hist) WithRange(lo, hi float64) Hist {
+	h.lo, h.hi, h.fixed = lo, hi, hi > lo
+	return h
+}
+
+// Specify the function formatting the bounds of the buckets in the labels.
+//
+//	h := echart.Histogram(latencies, 10).WithValueFormat(func(v float64) string {
+//		return time.Duration(v * float64(time.Millisecond)).String()
+//	})
+func (h Hist) WithValueFormat(format func(float64) string) Hist {
+	h.format = format
+	return h
+}
+
+// Specify the style of the bars.
+//
+//	h := echart.Histogram(latencies, 10).WithStyle(echart.BarChartStyleDefault)
+func (h Hist) WithStyle(s BarChartStyle) Hist {
+	h.style = s
+	return h
+}
+
+// Specify the width of the chart in cells, overriding the one of the style.
+//
+//	h := echart.Histogram(latencies, 10).WithWidth(60)
+func (h Hist) WithWidth(w int) Hist {
+	h.style.Width = w
+	return h
+}
+
+// Returns the bounds and the counts of the buckets.
+func (h Hist) buckets() ([]float64, []float64) {
+	samples := []float64{}
+	for _, v := range h.samples {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			samples = append(samples, v)
+		}
+	}
+	if len(samples) == 0 {
+		return nil, nil
+	}
+
+	bins := h.bins
+	if bins <= 0 {
+		// Sturges' rule
+		bins = int(math.Ceil(math.Log2(float64(len(samples))))) + 1
+	}
+	lo, hi := h.lo, h.hi
+	if !h.fixed {
+		lo, hi = bounds(samples)
+	}
+	if hi <= lo {
+		// All the samples are equal
+		return []float64{lo, lo}, []float64{float64(len(samples))}
+	}
+
+	bounds := make([]float64, bins+1)
+	for i := range bounds {
+		bounds[i] = lo + (hi-lo)*float64(i)/float64(bins)
+	}
+	counts := make([]float64, bins)
+	for _, v := range samples {
+		i := int((v - lo) / (hi - lo) * float64(bins))
+		counts[min(max(i, 0), bins-1)]++
+	}
+	return bounds, counts
+}
+
+// Render the histogram, a line per bucket: its range, its bar and the
+// number of samples in it.
+//
+//	fmt.Println(echart.Histogram(latencies, 10).Render())
+func (h Hist) Render() string {
+	bounds, counts := h.buckets()
+	if len(counts) == 0 {
+		return ""
+	}
+
+	from, to := make([]string, len(counts)), make([]string, len(counts))
+	fromWidth, toWidth := 0, 0
+	for i := range counts {
+		from[i], to[i] = h.format(bounds[i]), h.format(bounds[i+1])
+		fromWidth = max(fromWidth, ansi.StringWidth(from[i]))
+		toWidth = max(toWidth, ansi.StringWidth(to[i]))
+	}
+	labels := make([]string, len(counts))
+	for i := range counts {
+		labels[i] = fmt.Sprintf("%*s – %-*s", fromWidth, from[i], toWidth, to[i])
+	}
+	return BarChart(labels, counts).
+		WithStyle(h.style).
+		WithValueFormat(func(v float64) string { return strconv.Itoa(int(v)) }).
+		Render()
+}
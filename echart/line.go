@@ -0,0 +1,275 @@
+package echart
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Line chart style definition.
+type LineChartStyle struct {
+	AxisStyle   lipgloss.Style
+	LabelStyle  lipgloss.Style
+	LegendStyle lipgloss.Style
+	// Colors of the series, in the order of their names, repeated when there
+	// are more series
+	Colors      []lipgloss.TerminalColor
+	LegendGlyph string
+}
+
+// Default LineChartStyle used by LineChart.
+var LineChartStyleDefault = lineChartStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		LineChartStyleDefault = lineChartStyle(t)
+	})
+}
+
+// Returns LineChartStyleDefault for the theme t.
+func lineChartStyle(t etheme.Theme) LineChartStyle {
+	return LineChartStyle{
+		AxisStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		LabelStyle:  lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		LegendStyle: lipgloss.NewStyle(),
+		Colors: []lipgloss.TerminalColor{
+			t.Primary, t.Success, t.Warning, t.Accent, t.Error,
+		},
+		LegendGlyph: "●",
+	}
+}
+
+// Lines is a line chart of one or more series drawn with braille
+// characters, with a vertical axis labelled with the range of the values and
+// a legend of the series.
+type Lines struct {
+	series map[string][]float64
+	width  int
+	height int
+	lo, hi float64
+	fixed  bool
+	format func(float64) string
+	style  LineChartStyle
+}
+
+// Create a line chart of series, a line per name, of width by height cells
+// including the axes and the legend. The width of the terminal is used when
+// width is 0 and 10 rows when height is 0. The values of each series are
+// spread over the whole width, NaN and infinite values break the lines.
+//
+//	chart := echart.LineChart(map[string][]float64{"p50": p50, "p99": p99}, 0, 12)
+//	fmt.Println(chart.Render())
+func LineChart(series map[string][]float64, width, height int) Lines {
+	return Lines{
+		series: series,
+		width:  width,
+		height: height,
+		format: formatValue,
+		style:  LineChartStyleDefault,
+	}
+}
+
+// Scale the vertical axis between lo and hi instead of between the smallest
+// and the largest value. The values outside are clipped.
+//
+//	c := echart.LineChart(series, 0, 12).WithRange(0, 100)
+func (l Lines) WithRange(lo, hi float64) Lines {
+	l.lo, l.hi, l.fixed = lo, hi, hi > lo
+	return l
+}
+
+// Specify the function formatting the labels of the vertical axis.
+//
+//	c := echart.LineChart(series, 0, 12).WithValueFormat(func(v float64) string {
+//		return fmt.Sprintf("%.0fms", v)
+//	})
+func (l Lines) WithValueFormat(format func(float64) string) Lines {
+	l.format = format
+	return l
+}
+
+// Specify the style of the chart.
+//
+//	c := echart.LineChart(series, 0, 12).WithStyle(echart.LineChartStyleDefault)
+func (l Lines) WithStyle(s LineChartStyle) Lines {
+	l.style = s
+	return l
+}
+
+// A canvas of braille cells, each of 2 by 4 dots, colored by the last series
+// drawn on them.
+type brailleCanvas struct {
+	width, height int
+	cells         [][]rune
+	colors        [][]int
+}
+
+func newBrailleCanvas(width, height int) *brailleCanvas {
+	c := &brailleCanvas{width: width, height: height}
+	c.cells = make([][]rune, height)
+	c.colors = make([][]int, height)
+	for i := range c.cells {
+		c.cells[i] = make([]rune, width)
+		c.colors[i] = make([]int, width)
+	}
+	return c
+}
+
+// Dots of a braille cell, by column and row from the top.
+var brailleDots = [2][4]rune{{0x01, 0x02, 0x04, 0x40}, {0x08, 0x10, 0x20, 0x80}}
+
+// Set the dot at x, y, from the top left corner, with the color at index
+// color.
+func (c *brailleCanvas) set(x, y, color int) {
+	if x < 0 || y < 0 || x >= c.width*2 || y >= c.height*4 {
+		return
+	}
+	c.cells[y/4][x/2] |= brailleDots[x%2][y%4]
+	c.colors[y/4][x/2] = color
+}
+
+// Draw a line between two dots.
+func (c *brailleCanvas) line(x0, y0, x1, y1, color int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		c.set(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * e; e2 >= dy {
+			e += dy
+			x0 += sx
+		} else {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	return max(n, -n)
+}
+
+// Render the chart: the series plotted above the horizontal axis, and the
+// legend.
+//
+//	fmt.Println(echart.LineChart(series, 60, 12).Render())
+func (l Lines) Render() string {
+	names := make([]string, 0, len(l.series))
+	for name := range l.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lo, hi := l.lo, l.hi
+	if !l.fixed {
+		lo, hi = math.Inf(1), math.Inf(-1)
+		for _, name := range names {
+			slo, shi := bounds(l.series[name])
+			lo, hi = min(lo, slo), max(hi, shi)
+		}
+		if math.IsInf(lo, 0) {
+			lo, hi = 0, 1
+		}
+		if hi <= lo {
+			lo, hi = lo-1, hi+1
+		}
+	}
+
+	width := l.width
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	// The axis and the legend take two rows
+	height := l.height
+	if height <= 0 {
+		height = 10
+	}
+	rows := max(height-2, 1)
+
+	labels := map[int]string{0: l.format(hi), rows - 1: l.format(lo)}
+	if rows >= 5 {
+		labels[(rows-1)/2] = l.format(hi - (hi-lo)*float64((rows-1)/2)/float64(rows-1))
+	}
+	labelWidth := 0
+	for _, label := range labels {
+		labelWidth = max(labelWidth, ansi.StringWidth(label))
+	}
+	plotWidth := max(width-labelWidth-2, 1)
+
+	canvas := newBrailleCanvas(plotWidth, rows)
+	dotsX, dotsY := plotWidth*2, rows*4
+	for color, name := range names {
+		values := resample(l.series[name], dotsX)
+		px, py, drawn := 0, 0, false
+		for i, v := range values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				drawn = false
+				continue
+			}
+			x := 0
+			if len(values) > 1 {
+				x = i * (dotsX - 1) / (len(values) - 1)
+			}
+			fraction := min(max((v-lo)/(hi-lo), 0), 1)
+			y := dotsY - 1 - int(math.Round(fraction*float64(dotsY-1)))
+			// An infinite range gives a NaN fraction, keep the line on the canvas
+			x, y = min(max(x, 0), dotsX-1), min(max(y, 0), dotsY-1)
+			if drawn {
+				canvas.line(px, py, x, y, color)
+			} else {
+				canvas.set(x, y, color)
+			}
+			px, py, drawn = x, y, true
+		}
+	}
+
+	lines := make([]string, 0, rows+2)
+	colors := l.style.Colors
+	for r := range rows {
+		label, axis := labels[r], "│"
+		if label != "" {
+			axis = "┤"
+		}
+		var b strings.Builder
+		b.WriteString(l.style.LabelStyle.Render(strings.Repeat(" ", labelWidth-ansi.StringWidth(label)) + label))
+		b.WriteString(" " + l.style.AxisStyle.Render(axis))
+		for c, cell := range canvas.cells[r] {
+			if cell == 0 {
+				b.WriteString(" ")
+				continue
+			}
+			style := lipgloss.NewStyle()
+			if len(colors) > 0 {
+				style = style.Foreground(colors[canvas.colors[r][c]%len(colors)])
+			}
+			b.WriteString(style.Render(string(0x2800 + cell)))
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	lines = append(lines, strings.Repeat(" ", labelWidth+1)+l.style.AxisStyle.Render("└"+strings.Repeat("─", plotWidth)))
+
+	legend := []string{}
+	for i, name := range names {
+		glyph := l.style.LegendGlyph
+		if len(colors) > 0 {
+			glyph = lipgloss.NewStyle().Foreground(colors[i%len(colors)]).Render(glyph)
+		}
+		legend = append(legend, glyph+" "+l.style.LegendStyle.Render(name))
+	}
+	lines = append(lines, strings.Repeat(" ", labelWidth+2)+strings.Join(legend, "  "))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,49 @@
+package echart
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		values []float64
+		lo     float64
+		hi     float64
+	}{
+		{[]float64{3, 1, 2}, 1, 3},
+		{[]float64{math.NaN(), 1, 2}, 1, 2},
+		{[]float64{math.Inf(1), 1, math.Inf(-1), 2}, 1, 2},
+		{[]float64{math.NaN(), math.Inf(1)}, math.Inf(1), math.Inf(-1)},
+		{nil, math.Inf(1), math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		if lo, hi := bounds(tt.values); lo != tt.lo || hi != tt.hi {
+			t.Errorf("bounds(%v) = %v, %v, want %v, %v", tt.values, lo, hi, tt.lo, tt.hi)
+		}
+	}
+}
+
+func TestLineChartNonFinite(t *testing.T) {
+	style := LineChartStyleDefault
+	style.Colors = nil
+	want := LineChart(map[string][]float64{"a": {math.NaN(), 1, 2}}, 30, 6).WithStyle(style).Render()
+	tests := [][]float64{
+		{math.Inf(1), 1, 2},
+		{math.Inf(-1), 1, 2},
+	}
+	for _, values := range tests {
+		got := LineChart(map[string][]float64{"a": values}, 30, 6).WithStyle(style).Render()
+		if got != want {
+			t.Errorf("LineChart(%v) =\n%s\nwant\n%s", values, got, want)
+		}
+	}
+}
+
+func TestLineChartInfiniteRange(t *testing.T) {
+	// The line stays on the canvas when the range cannot scale the values
+	got := LineChart(map[string][]float64{"a": {0, 1, 2}}, 30, 6).WithRange(math.Inf(-1), math.Inf(1)).Render()
+	if got == "" {
+		t.Error("LineChart with an infinite range rendered nothing")
+	}
+}
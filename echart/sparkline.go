@@ -0,0 +1,106 @@
+package echart
+
+import (
+	"math"
+	"strings"
+)
+
+// Blocks of increasing height drawing the values of a sparkline.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Dots of the left and right columns of a braille cell, from the bottom.
+var (
+	brailleLeft  = []rune{0x40, 0x04, 0x02, 0x01}
+	brailleRight = []rune{0x80, 0x20, 0x10, 0x08}
+)
+
+// Render values as a sparkline of at most width cells, a block of height
+// proportional to each value, to show a trend inline. Values are averaged
+// when there are more than width, all of them are shown when width is 0.
+// NaN values are drawn as spaces.
+//
+//	fmt.Println("latency " + echart.Sparkline(samples, 20))
+func Sparkline(values []float64, width int) string {
+	values = resample(values, width)
+	lo, hi := bounds(values)
+	var b strings.Builder
+	for _, v := range values {
+		if math.IsNaN(v) {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(sparkBlocks[level(v, lo, hi, len(sparkBlocks))])
+	}
+	return b.String()
+}
+
+// Render values as a sparkline of at most width cells with braille
+// characters, two values per cell with four levels each, to show twice as
+// many values as Sparkline in the same width. Values are averaged when there
+// are more than twice width, all of them are shown when width is 0.
+//
+//	fmt.Println("requests " + echart.SparklineBraille(counts, 30))
+func SparklineBraille(values []float64, width int) string {
+	values = resample(values, width*2)
+	lo, hi := bounds(values)
+	var b strings.Builder
+	for i := 0; i < len(values); i += 2 {
+		cell := rune(0x2800)
+		for j, dots := range [][]rune{brailleLeft, brailleRight} {
+			if i+j >= len(values) || math.IsNaN(values[i+j]) {
+				continue
+			}
+			// Columns are filled from the bottom up to the level of the value
+			for _, dot := range dots[:level(values[i+j], lo, hi, len(dots))+1] {
+				cell |= dot
+			}
+		}
+		b.WriteRune(cell)
+	}
+	return b.String()
+}
+
+// Returns values averaged into n buckets, or values when there are at most
+// n or n is 0. NaN values are ignored, a bucket of NaN values is NaN.
+func resample(values []float64, n int) []float64 {
+	if n <= 0 || len(values) <= n {
+		return values
+	}
+	out := make([]float64, n)
+	for i := range out {
+		from, to := i*len(values)/n, (i+1)*len(values)/n
+		sum, count := 0.0, 0
+		for _, v := range values[from:to] {
+			if !math.IsNaN(v) {
+				sum += v
+				count++
+			}
+		}
+		out[i] = math.NaN()
+		if count > 0 {
+			out[i] = sum / float64(count)
+		}
+	}
+	return out
+}
+
+// Returns the smallest and the largest of values, ignoring NaN and infinite
+// values.
+func bounds(values []float64) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+	}
+	return lo, hi
+}
+
+// Returns the level of v among n levels between lo and hi. Values are at
+// the middle level when they are all equal.
+func level(v, lo, hi float64, n int) int {
+	if hi <= lo || math.IsInf(hi-lo, 0) {
+		return (n - 1) / 2
+	}
+	return min(max(int((v-lo)/(hi-lo)*float64(n-1)+0.5), 0), n-1)
+}
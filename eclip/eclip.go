@@ -0,0 +1,96 @@
+// Package eclip copies text to the clipboard of the user through the
+// terminal, with the OSC 52 escape sequence. It works over SSH and inside
+// tmux and screen, as long as the terminal supports it: tmux needs
+// "set -g set-clipboard on", or "allow-passthrough on" for older versions.
+//
+//	if err := eclip.Copy(id); err == nil {
+//		fmt.Println("copied to the clipboard")
+//	}
+package eclip
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+var (
+	// ErrUnsupported is returned when the terminal cannot copy to the
+	// clipboard, or when the output is not a terminal.
+	ErrUnsupported = errors.New("the terminal does not support copying to the clipboard")
+	// ErrTooLarge is returned when the text is larger than what terminals
+	// accept in a sequence.
+	ErrTooLarge = errors.New("text too large to copy to the clipboard")
+)
+
+// Longest base64 encoded text accepted, the limit of several terminals
+// like hterm.
+const maxEncoded = 100000
+
+// Longest string screen accepts in a sequence, longer ones are sent in
+// chunks.
+const screenLimit = 768
+
+// Reports whether the terminal is expected to support copying to the
+// clipboard. Terminals do not report it, so the terminals known not to
+// support it are excluded, like the Linux console and the macOS Terminal,
+// and the other ones are assumed to. Useful to offer copying only when it
+// can work.
+//
+//	help := ekeys.New(ekeys.Bind("y", "copy").WithEnabled(eclip.Supported()))
+func Supported() bool {
+	if eterm.ControlWriter() == nil {
+		return false
+	}
+	switch os.Getenv("TERM") {
+	case "dumb", "linux":
+		return false
+	}
+	return os.Getenv("TERM_PROGRAM") != "Apple_Terminal"
+}
+
+// Copy text to the clipboard. The sequence is wrapped to reach the outer
+// terminal when running inside tmux or screen. The terminal does not
+// confirm the copy, so a nil error does not guarantee it succeeded.
+//
+//	if err := eclip.Copy(token); err != nil {
+//		fmt.Println(token)
+//	}
+func Copy(text string) error {
+	seq, err := Sequence(text)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(eterm.ControlWriter(), seq)
+	return err
+}
+
+// Returns the sequence copying text to the clipboard, like Copy, without
+// writing it. Useful in the Bubble Tea models, where writing it while the
+// program renders could break a frame: the sequence takes no cell, so it
+// can be rendered in the View instead.
+//
+//	seq, err := eclip.Sequence(row)
+//	...
+//	return m.clipboard + m.statusLine()
+func Sequence(text string) (string, error) {
+	if base64.StdEncoding.EncodedLen(len(text)) > maxEncoded {
+		return "", ErrTooLarge
+	}
+	if !Supported() {
+		return "", ErrUnsupported
+	}
+	seq := ansi.SetSystemClipboard(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		return ansi.TmuxPassthrough(seq), nil
+	case os.Getenv("STY") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		return ansi.ScreenPassthrough(seq, screenLimit), nil
+	}
+	return seq, nil
+}
@@ -0,0 +1,278 @@
+package ecode
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Code style definition, with the styles of the tokens of the source by
+// type. The highlighted lines are rendered with HighlightStyle, which
+// usually sets a background, and marked with HighlightMarker.
+type CodeStyle struct {
+	TextStyle       lipgloss.Style
+	KeywordStyle    lipgloss.Style
+	TypeStyle       lipgloss.Style
+	FunctionStyle   lipgloss.Style
+	StringStyle     lipgloss.Style
+	NumberStyle     lipgloss.Style
+	CommentStyle    lipgloss.Style
+	OperatorStyle   lipgloss.Style
+	LineNumberStyle lipgloss.Style
+	HighlightStyle  lipgloss.Style
+	MarkerStyle     lipgloss.Style
+	HighlightMarker string
+	Separator       string
+	TabWidth        int
+}
+
+// Default CodeStyle used by Render. Uses the primary color of the etheme.Theme
+// for the keywords, like the other components.
+var CodeStyleDefault = codeStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		CodeStyleDefault = codeStyle(t)
+	})
+}
+
+// Returns CodeStyleDefault for the theme t.
+func codeStyle(t etheme.Theme) CodeStyle {
+	return CodeStyle{
+		TextStyle:       lipgloss.NewStyle(),
+		KeywordStyle:    lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		TypeStyle:       lipgloss.NewStyle().Foreground(t.Accent),
+		FunctionStyle:   lipgloss.NewStyle().Foreground(t.Accent),
+		StringStyle:     lipgloss.NewStyle().Foreground(t.Success),
+		NumberStyle:     lipgloss.NewStyle().Foreground(t.Warning),
+		CommentStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true).Italic(true),
+		OperatorStyle:   lipgloss.NewStyle(),
+		LineNumberStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		HighlightStyle:  lipgloss.NewStyle().Background(t.Surface),
+		MarkerStyle:     lipgloss.NewStyle().Foreground(t.Warning).Bold(true),
+		HighlightMarker: "▌",
+		Separator:       "│",
+		TabWidth:        4,
+	}
+}
+
+// Returns the style of a token of the given type.
+func (s CodeStyle) token(t chroma.TokenType) lipgloss.Style {
+	switch {
+	case t.InCategory(chroma.Comment):
+		return s.CommentStyle
+	case t == chroma.KeywordType || t == chroma.NameClass || t == chroma.NameBuiltin:
+		return s.TypeStyle
+	case t.InCategory(chroma.Keyword), t == chroma.NameTag:
+		return s.KeywordStyle
+	case t == chroma.NameFunction || t == chroma.NameAttribute:
+		return s.FunctionStyle
+	case t.InSubCategory(chroma.LiteralString):
+		return s.StringStyle
+	case t.InSubCategory(chroma.LiteralNumber), t == chroma.KeywordConstant:
+		return s.NumberStyle
+	case t.InCategory(chroma.Operator):
+		return s.OperatorStyle
+	}
+	return s.TextStyle
+}
+
+// A range of lines of a Code, from from to to included.
+type lineRange struct {
+	from int
+	to   int
+}
+
+// Code renders source code highlighted by syntax, with line numbers.
+type Code struct {
+	source      string
+	language    string
+	style       CodeStyle
+	numbers     bool
+	start       int
+	width       int
+	highlighted []lineRange
+}
+
+// Create a new Code of source, written in language. The language is a name
+// like "go" or "yaml", or a file name like "main.go"; when empty or unknown
+// it is guessed from the source, falling back to plain text.
+//
+//	fmt.Println(ecode.NewCode(manifest, "yaml").WithHighlight(12, 14).Render())
+func NewCode(source string, language string) Code {
+	return Code{
+		source:   source,
+		language: language,
+		style:    CodeStyleDefault,
+		numbers:  true,
+		start:    1,
+	}
+}
+
+// Specify the style of the Code.
+//
+//	c := ecode.NewCode(src, "go").WithStyle(ecode.CodeStyleDefault)
+func (c Code) WithStyle(s CodeStyle) Code {
+	c.style = s
+	return c
+}
+
+// Specify whether the line numbers are rendered, they are by default.
+//
+//	c := ecode.NewCode(src, "go").WithLineNumbers(false)
+func (c Code) WithLineNumbers(numbers bool) Code {
+	c.numbers = numbers
+	return c
+}
+
+// Specify the number of the first line, for excerpts of a longer file.
+//
+//	c := ecode.NewCode(excerpt, "go").WithStartLine(120)
+func (c Code) WithStartLine(n int) Code {
+	c.start = n
+	return c
+}
+
+// Highlight the lines from from to to included, numbered as rendered. It
+// can be called several times to highlight several ranges.
+//
+//	c := ecode.NewCode(src, "go").WithHighlight(3, 5).WithHighlight(9, 9)
+func (c Code) WithHighlight(from int, to int) Code {
+	c.highlighted = append(c.highlighted[:len(c.highlighted):len(c.highlighted)], lineRange{from: from, to: to})
+	return c
+}
+
+// Specify the width of the Code in cells, after which the lines are
+// wrapped. A width of 0 uses the width of the terminal, a negative one
+// disables wrapping.
+//
+//	c := ecode.NewCode(src, "go").WithWidth(100)
+func (c Code) WithWidth(w int) Code {
+	c.width = w
+	return c
+}
+
+// Render source code written in language highlighted by syntax, with line
+// numbers, wrapped to the width of the terminal, see NewCode.
+//
+//	fmt.Println(ecode.Render(generated, "go"))
+func Render(source string, language string) string {
+	return NewCode(source, language).Render()
+}
+
+// Returns the lexer of the language of the Code.
+func (c Code) lexer() chroma.Lexer {
+	var lexer chroma.Lexer
+	if c.language != "" {
+		lexer = lexers.Get(c.language)
+	}
+	if lexer == nil {
+		lexer = lexers.Analyse(c.source)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	return chroma.Coalesce(lexer)
+}
+
+// Reports whether the line numbered n is highlighted.
+func (c Code) isHighlighted(n int) bool {
+	for _, r := range c.highlighted {
+		if n >= r.from && n <= r.to {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the lines of the source, split into tokens.
+func (c Code) tokenize() [][]chroma.Token {
+	source := strings.TrimSuffix(c.source, "\n")
+	source = strings.ReplaceAll(source, "\t", strings.Repeat(" ", max(c.style.TabWidth, 1)))
+	it, err := c.lexer().Tokenise(nil, source)
+	if err != nil {
+		lines := [][]chroma.Token{}
+		for _, line := range strings.Split(source, "\n") {
+			lines = append(lines, []chroma.Token{{Type: chroma.Text, Value: line}})
+		}
+		return lines
+	}
+	return chroma.SplitTokensIntoLines(it.Tokens())
+}
+
+// Render the Code.
+func (c Code) Render() string {
+	s := c.style
+	lines := c.tokenize()
+
+	last := c.start + len(lines) - 1
+	numberWidth := len(strconv.Itoa(last))
+	markerWidth := 0
+	if len(c.highlighted) > 0 {
+		markerWidth = ansi.StringWidth(s.HighlightMarker)
+	}
+	gutterWidth := markerWidth
+	if c.numbers {
+		gutterWidth += numberWidth + 1 + ansi.StringWidth(s.Separator) + 1
+	}
+
+	width := c.width
+	if width == 0 {
+		width = eterm.Width()
+	}
+	codeWidth := width - gutterWidth
+
+	out := []string{}
+	for i, tokens := range lines {
+		n := c.start + i
+		highlighted := c.isHighlighted(n)
+
+		var b strings.Builder
+		for _, t := range tokens {
+			value := strings.TrimSuffix(t.Value, "\n")
+			if value == "" {
+				continue
+			}
+			style := s.token(t.Type)
+			if highlighted {
+				style = style.Inherit(s.HighlightStyle)
+			}
+			b.WriteString(style.Render(value))
+		}
+		rows := []string{b.String()}
+		if codeWidth > 0 && width > 0 {
+			rows = strings.Split(ansi.Hardwrap(b.String(), codeWidth, true), "\n")
+		}
+
+		for j, row := range rows {
+			gutter := ""
+			if markerWidth > 0 {
+				if highlighted {
+					gutter += s.MarkerStyle.Render(s.HighlightMarker)
+				} else {
+					gutter += strings.Repeat(" ", markerWidth)
+				}
+			}
+			if c.numbers {
+				number := strings.Repeat(" ", numberWidth)
+				if j == 0 {
+					number = strconv.Itoa(n)
+					number = strings.Repeat(" ", numberWidth-len(number)) + number
+				}
+				gutter += s.LineNumberStyle.Render(number+" "+s.Separator) + " "
+			}
+			if highlighted && codeWidth > 0 && width > 0 {
+				// Extend the background of the highlighted lines to the width
+				row += s.HighlightStyle.Render(strings.Repeat(" ", max(codeWidth-ansi.StringWidth(row), 0)))
+			}
+			out = append(out, gutter+row)
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,144 @@
+// Package ecolor computes colors for the components, like the gradients of
+// heatmaps and progress bars, readable foregrounds for a background and
+// palettes derived from a brand color.
+package ecolor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrInvalidColor is returned when a color cannot be parsed.
+var ErrInvalidColor = errors.New("invalid color")
+
+// Black and white, the foregrounds chosen by Foreground.
+const (
+	Black = lipgloss.Color("#000000")
+	White = lipgloss.Color("#FFFFFF")
+)
+
+// Parse a color as #RRGGBB or #RGB, with or without #, or as the index of
+// a color of the 256 colors palette, like "4". The ANSI colors from 0 to 15
+// are converted with the xterm palette, terminals may show them
+// differently.
+//
+//	c, err := ecolor.Parse("#5E81AC")
+func Parse(s string) (colorful.Color, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n > 255 {
+			return colorful.Color{}, fmt.Errorf("%w: %q", ErrInvalidColor, s)
+		}
+		c, _ := colorful.MakeColor(ansi.IndexedColor(n))
+		return c, nil
+	}
+
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	c, err := colorful.Hex("#" + hex)
+	if err != nil || len(hex) != 6 {
+		return colorful.Color{}, fmt.Errorf("%w: %q", ErrInvalidColor, s)
+	}
+	return c, nil
+}
+
+// Parse a color like Parse, returning it as a lipgloss.Color in the
+// #RRGGBB form.
+//
+//	c, err := ecolor.ParseHex(flagColor)
+func ParseHex(s string) (lipgloss.Color, error) {
+	c, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return Hex(c), nil
+}
+
+// Returns the lipgloss.Color of c, in the #RRGGBB form.
+func Hex(c colorful.Color) lipgloss.Color {
+	return lipgloss.Color(strings.ToUpper(c.Clamped().Hex()))
+}
+
+// Returns the color at t of the way from from to to, t between 0 and 1,
+// blended in the Luv space so the lightness changes evenly. When a color
+// cannot be parsed to is returned.
+//
+//	c := ecolor.Blend("#1F2937", "#22C55E", 0.25)
+func Blend(from lipgloss.Color, to lipgloss.Color, t float64) lipgloss.Color {
+	start, err := Parse(string(from))
+	if err != nil {
+		return to
+	}
+	end, err := Parse(string(to))
+	if err != nil {
+		return to
+	}
+	return Hex(start.BlendLuv(end, min(max(t, 0), 1)))
+}
+
+// Returns steps colors going evenly from from to to, both included, see
+// Blend.
+//
+//	for i, c := range ecolor.Gradient("#5A56E0", "#EE6FF8", len(lines)) {
+//		lines[i] = lipgloss.NewStyle().Foreground(c).Render(lines[i])
+//	}
+func Gradient(from lipgloss.Color, to lipgloss.Color, steps int) []lipgloss.Color {
+	colors := make([]lipgloss.Color, max(steps, 0))
+	for i := range colors {
+		colors[i] = Blend(from, to, float64(i)/float64(max(steps-1, 1)))
+	}
+	return colors
+}
+
+// Returns the relative luminance of c, between 0 for black and 1 for
+// white, as defined by WCAG.
+func luminance(c colorful.Color) float64 {
+	channel := func(v float64) float64 {
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	c = c.Clamped()
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// Returns the contrast ratio of two colors as defined by WCAG, from 1 for
+// the same color to 21 for black on white. Text is considered readable
+// from 4.5. Returns 1 when a color cannot be parsed.
+//
+//	if ecolor.Contrast(fg, bg) < 4.5 {
+//		fg = ecolor.Foreground(bg)
+//	}
+func Contrast(a lipgloss.Color, b lipgloss.Color) float64 {
+	ca, err := Parse(string(a))
+	if err != nil {
+		return 1
+	}
+	cb, err := Parse(string(b))
+	if err != nil {
+		return 1
+	}
+	la, lb := luminance(ca), luminance(cb)
+	return (max(la, lb) + 0.05) / (min(la, lb) + 0.05)
+}
+
+// Returns Black or White, whichever contrasts the most with the background
+// bg. Returns White when bg cannot be parsed.
+//
+//	style := lipgloss.NewStyle().Background(bg).Foreground(ecolor.Foreground(bg))
+func Foreground(bg lipgloss.Color) lipgloss.Color {
+	if Contrast(Black, bg) > Contrast(White, bg) {
+		return Black
+	}
+	return White
+}
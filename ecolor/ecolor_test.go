@@ -0,0 +1,112 @@
+package ecolor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		s    string
+		want lipgloss.Color
+	}{
+		{"#5E81AC", "#5E81AC"},
+		{"#5e81ac", "#5E81AC"},
+		{"5E81AC", "#5E81AC"},
+		{"#abc", "#AABBCC"},
+		{"fff", "#FFFFFF"},
+		{" #000000 ", "#000000"},
+		{"0", "#000000"},
+		{"15", "#FFFFFF"},
+		{"196", "#FF0000"},
+	}
+	for _, tt := range tests {
+		got, err := ParseHex(tt.s)
+		if err != nil {
+			t.Errorf("ParseHex(%q): %v", tt.s, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseHex(%q) = %s, want %s", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []string{"", "#", "#12", "#1234", "#12345", "#1234567", "#GGGGGG", "256", "-1", "blue"}
+	for _, s := range tests {
+		if _, err := Parse(s); !errors.Is(err, ErrInvalidColor) {
+			t.Errorf("Parse(%q) = %v, want ErrInvalidColor", s, err)
+		}
+	}
+}
+
+func TestBlend(t *testing.T) {
+	tests := []struct {
+		from lipgloss.Color
+		to   lipgloss.Color
+		t    float64
+		want lipgloss.Color
+	}{
+		{"#000000", "#FFFFFF", 0, "#000000"},
+		{"#000000", "#FFFFFF", 1, "#FFFFFF"},
+		{"#000000", "#FFFFFF", -1, "#000000"},
+		{"#000000", "#FFFFFF", 2, "#FFFFFF"},
+		{"#123456", "#123456", 0.5, "#123456"},
+		{"invalid", "#FFFFFF", 0.5, "#FFFFFF"},
+		{"#000000", "invalid", 0.5, "invalid"},
+	}
+	for _, tt := range tests {
+		if got := Blend(tt.from, tt.to, tt.t); got != tt.want {
+			t.Errorf("Blend(%s, %s, %v) = %s, want %s", tt.from, tt.to, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	got := Gradient("#000000", "#FFFFFF", 3)
+	if len(got) != 3 || got[0] != "#000000" || got[2] != "#FFFFFF" {
+		t.Errorf("Gradient = %v, want 3 colors from #000000 to #FFFFFF", got)
+	}
+	if got := Gradient("#000000", "#FFFFFF", 0); len(got) != 0 {
+		t.Errorf("Gradient of 0 steps = %v", got)
+	}
+}
+
+func TestContrast(t *testing.T) {
+	tests := []struct {
+		a    lipgloss.Color
+		b    lipgloss.Color
+		want float64
+	}{
+		{"#000000", "#FFFFFF", 21},
+		{"#FFFFFF", "#000000", 21},
+		{"#777777", "#777777", 1},
+		{"invalid", "#FFFFFF", 1},
+	}
+	for _, tt := range tests {
+		if got := Contrast(tt.a, tt.b); got < tt.want-0.01 || got > tt.want+0.01 {
+			t.Errorf("Contrast(%s, %s) = %.2f, want %.2f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestForeground(t *testing.T) {
+	tests := []struct {
+		bg   lipgloss.Color
+		want lipgloss.Color
+	}{
+		{"#FFFFFF", Black},
+		{"#FFFF00", Black},
+		{"#000000", White},
+		{"#1E3A8A", White},
+		{"invalid", White},
+	}
+	for _, tt := range tests {
+		if got := Foreground(tt.bg); got != tt.want {
+			t.Errorf("Foreground(%s) = %s, want %s", tt.bg, got, tt.want)
+		}
+	}
+}
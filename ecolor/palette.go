@@ -0,0 +1,95 @@
+package ecolor
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Lightness of the colors of a Theme, in the HCL space, for terminals with
+// a light and with a dark background.
+const (
+	lightnessLight = 0.45
+	lightnessDark  = 0.75
+)
+
+// Lightness of the contrast and the surface colors of a Theme, in the HCL
+// space, for terminals with a light and with a dark background.
+const (
+	contrastLight = 0.97
+	contrastDark  = 0.15
+	surfaceLight  = 0.9
+	surfaceDark   = 0.3
+)
+
+// Lightness of the ends of the gradient of a Theme, in the HCL space.
+const lightnessGradient = 0.6
+
+// Hues of the colors of a Theme with a fixed meaning, in the HCL space.
+const (
+	hueSuccess = 135
+	hueWarning = 75
+	hueError   = 25
+)
+
+// Returns n colors with the chroma and the lightness of brand, their hues
+// evenly spread around the color wheel starting from the one of brand, to
+// tell apart the series of a chart.
+//
+//	colors, err := ecolor.Palette("#7D56F4", len(series))
+func Palette(brand lipgloss.Color, n int) ([]lipgloss.Color, error) {
+	c, err := Parse(string(brand))
+	if err != nil {
+		return nil, err
+	}
+	h, chroma, l := c.Hcl()
+	colors := make([]lipgloss.Color, max(n, 0))
+	for i := range colors {
+		colors[i] = Hex(colorful.Hcl(math.Mod(h+float64(i)*360/float64(n), 360), chroma, l))
+	}
+	return colors, nil
+}
+
+// Returns an etheme.Theme named name derived from the brand color: the
+// primary color has the hue of brand, the accent the opposite one, and
+// success, warning and error keep their usual hues with the chroma of
+// brand. Each color is lightened for dark backgrounds and darkened for
+// light ones, so it stays readable on both. The gradient goes from the hue
+// of brand to the opposite one.
+//
+//	theme, err := ecolor.Theme("brand", "#7D56F4")
+//	if err != nil {
+//		return err
+//	}
+//	etheme.SetTheme(theme)
+func Theme(name string, brand lipgloss.Color) (etheme.Theme, error) {
+	c, err := Parse(string(brand))
+	if err != nil {
+		return etheme.Theme{}, err
+	}
+	h, chroma, _ := c.Hcl()
+	chroma = min(max(chroma, 0.4), 0.9)
+	opposite := math.Mod(h+180, 360)
+	adaptive := func(h float64, chroma float64, light float64, dark float64) lipgloss.AdaptiveColor {
+		return lipgloss.AdaptiveColor{
+			Light: string(Hex(colorful.Hcl(h, chroma, light))),
+			Dark:  string(Hex(colorful.Hcl(h, chroma, dark))),
+		}
+	}
+	return etheme.Theme{
+		Name:    name,
+		Primary: adaptive(h, chroma, lightnessLight, lightnessDark),
+		Accent:  adaptive(opposite, chroma, lightnessLight, lightnessDark),
+		Success: adaptive(hueSuccess, chroma, lightnessLight, lightnessDark),
+		Warning: adaptive(hueWarning, chroma, lightnessLight, lightnessDark),
+		Error:   adaptive(hueError, chroma, lightnessLight, lightnessDark),
+		Muted:   adaptive(h, 0.05, lightnessLight, lightnessDark),
+
+		Contrast:     adaptive(h, 0.05, contrastLight, contrastDark),
+		Surface:      adaptive(h, 0.05, surfaceLight, surfaceDark),
+		GradientFrom: Hex(colorful.Hcl(h, chroma, lightnessGradient)),
+		GradientTo:   Hex(colorful.Hcl(opposite, chroma, lightnessGradient)),
+	}, nil
+}
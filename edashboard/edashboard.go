@@ -0,0 +1,398 @@
+// Package edashboard arranges the output of the other components, like
+// tables, gauges and logs, in the panels of a dashboard refreshing on its
+// own, for the watch mode of a CLI.
+package edashboard
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/epanel"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// Dashboard style definition. The panels are drawn in boxes of BoxStyle,
+// whose title shows a frame of Spinner while the panel refreshes.
+type DashboardStyle struct {
+	BoxStyle    epanel.BoxStyle
+	ErrorStyle  lipgloss.Style
+	StatusStyle lipgloss.Style
+	Spinner     spinner.Spinner
+}
+
+// Default DashboardStyle used by NewDashboard, with the rounded boxes of
+// epanel and the error color of the etheme.Theme.
+var DashboardStyleDefault = dashboardStyle(etheme.Current())
+
+func init() {
+	// The hook of epanel runs first, as it is registered when epanel is
+	// initialized.
+	etheme.OnChange(func(t etheme.Theme) {
+		DashboardStyleDefault = dashboardStyle(t)
+	})
+}
+
+// Returns DashboardStyleDefault for the theme t. The spinner is drawn with
+// ASCII characters when the terminal cannot display unicode.
+func dashboardStyle(t etheme.Theme) DashboardStyle {
+	s := DashboardStyle{
+		BoxStyle:    epanel.BoxStyleDefault,
+		ErrorStyle:  lipgloss.NewStyle().Foreground(t.Error),
+		StatusStyle: lipgloss.NewStyle().Reverse(true),
+		Spinner:     spinner.MiniDot,
+	}
+	if !eterm.Unicode() {
+		s.Spinner = spinner.Line
+	}
+	return s
+}
+
+// A panel of a Dashboard. Refresh returns its content, fitting width cells
+// and height lines, and is called every Interval, 2 seconds when 0. The
+// height is 0 when the dashboard is printed once, as its panels are not
+// limited then.
+//
+//	panel := edashboard.Panel{
+//		Title:    "Pods",
+//		Interval: 5 * time.Second,
+//		Refresh: func(width, height int) (string, error) {
+//			pods, err := listPods(ctx)
+//			if err != nil {
+//				return "", err
+//			}
+//			return podsTable(pods).Render(), nil
+//		},
+//	}
+type Panel struct {
+	Title    string
+	Interval time.Duration
+	Refresh  func(width, height int) (string, error)
+}
+
+// A Panel placed in the grid of a Dashboard.
+type placedPanel struct {
+	panel   Panel
+	row     int
+	col     int
+	rowSpan int
+	colSpan int
+}
+
+// Dashboard arranges Panels in a grid of rows and columns filling the
+// terminal, each refreshing on its own interval.
+type Dashboard struct {
+	title  string
+	rows   int
+	cols   int
+	panels []placedPanel
+	style  DashboardStyle
+}
+
+// Create a new Dashboard of rows by cols panels, with title in its status
+// line.
+//
+//	d := edashboard.NewDashboard("cluster", 2, 2).
+//		WithPanelSpan(0, 0, 1, 2, nodes).
+//		WithPanel(1, 0, pods).
+//		WithPanel(1, 1, events)
+//	err := d.Run()
+func NewDashboard(title string, rows int, cols int) Dashboard {
+	return Dashboard{
+		title: title,
+		rows:  max(rows, 1),
+		cols:  max(cols, 1),
+		style: DashboardStyleDefault,
+	}
+}
+
+// Place a Panel in the cell at row and col, counting from 0. The panels
+// must not overlap, panels outside of the grid are ignored.
+//
+//	d := edashboard.NewDashboard("cluster", 1, 2).WithPanel(0, 1, events)
+func (d Dashboard) WithPanel(row int, col int, p Panel) Dashboard {
+	return d.WithPanelSpan(row, col, 1, 1, p)
+}
+
+// Place a Panel in a cell spanning rowSpan rows and colSpan columns from
+// row and col, see WithPanel. The spans are limited to the size of the
+// grid.
+//
+//	d := edashboard.NewDashboard("cluster", 2, 2).WithPanelSpan(0, 0, 2, 1, nodes)
+func (d Dashboard) WithPanelSpan(row int, col int, rowSpan int, colSpan int, p Panel) Dashboard {
+	if row < 0 || col < 0 || row >= d.rows || col >= d.cols {
+		return d
+	}
+	d.panels = append(d.panels[:len(d.panels):len(d.panels)], placedPanel{
+		panel:   p,
+		row:     row,
+		col:     col,
+		rowSpan: min(max(rowSpan, 1), d.rows-row),
+		colSpan: min(max(colSpan, 1), d.cols-col),
+	})
+	return d
+}
+
+// Specify the style of the Dashboard.
+//
+//	d := edashboard.NewDashboard("cluster", 2, 2).WithStyle(style)
+func (d Dashboard) WithStyle(s DashboardStyle) Dashboard {
+	d.style = s
+	return d
+}
+
+// Run the Dashboard filling the terminal until the user quits with q, Esc
+// or Ctrl+C. r refreshes all the panels at once. The error of a refresh is
+// shown in its panel until the next one succeeds.
+// When stdout is not a terminal the panels are refreshed once and the
+// dashboard is printed instead.
+//
+//	if err := d.Run(); err != nil {
+//		return err
+//	}
+func (d Dashboard) Run() error {
+	m := dashboardModel{
+		dashboard: d,
+		states:    make([]panelState, len(d.panels)),
+	}
+	if !eterm.IsTerminal(os.Stdout) {
+		m.width = 80
+		for i, p := range d.panels {
+			width, height := m.contentSize(p)
+			m.states[i].content, m.states[i].err = p.panel.Refresh(width, height)
+		}
+		_, err := fmt.Println(ansi.Strip(m.render(false)))
+		return err
+	}
+
+	_, err := live.Run(tea.NewProgram(m, tea.WithAltScreen()))
+	return err
+}
+
+// The bubbletea.Msg starting the refresh of a panel
+type dashboardMsgRefresh struct {
+	index int
+}
+
+// The bubbletea.Msg carrying the content of a refreshed panel
+type dashboardMsgContent struct {
+	index   int
+	content string
+	err     error
+}
+
+// The bubbletea.Msg advancing the spinners of the refreshing panels
+type dashboardMsgFrame struct{}
+
+// Number of cells between the columns of panels.
+const panelGap = 1
+
+// Last refresh of a panel.
+type panelState struct {
+	content    string
+	err        error
+	refreshing bool
+}
+
+// Bubbletea model of a running Dashboard.
+type dashboardModel struct {
+	dashboard Dashboard
+	states    []panelState
+	width     int
+	height    int
+	frame     int
+	animating bool
+	updated   time.Time
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return nil
+}
+
+// Returns the interval between two refreshes of a panel.
+func interval(p Panel) time.Duration {
+	if p.Interval <= 0 {
+		return 2 * time.Second
+	}
+	return p.Interval
+}
+
+// Start refreshing the panel at index, unless it is already refreshing.
+func (m *dashboardModel) refresh(index int) tea.Cmd {
+	if m.states[index].refreshing {
+		return nil
+	}
+	m.states[index].refreshing = true
+	p := m.dashboard.panels[index]
+	width, height := m.contentSize(p)
+	refresh := p.panel.Refresh
+	cmd := func() tea.Msg {
+		content, err := refresh(width, height)
+		return dashboardMsgContent{index: index, content: content, err: err}
+	}
+	if m.animating {
+		return cmd
+	}
+	m.animating = true
+	return tea.Batch(cmd, m.nextFrame())
+}
+
+// Refresh all the panels now.
+func (m *dashboardModel) refreshAll() tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.states))
+	for i := range m.states {
+		cmds[i] = m.refresh(i)
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m dashboardModel) nextFrame() tea.Cmd {
+	return tea.Tick(m.dashboard.style.Spinner.FPS, func(time.Time) tea.Msg {
+		return dashboardMsgFrame{}
+	})
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		first := m.width == 0
+		m.width, m.height = msg.Width, msg.Height
+		cmd := m.refreshAll()
+		if first {
+			// Start the refresh loops once the size of the panels is known
+			for i := range m.states {
+				cmd = tea.Batch(cmd, m.schedule(i))
+			}
+		}
+		return m, cmd
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "r":
+			return m, m.refreshAll()
+		}
+	case dashboardMsgRefresh:
+		return m, tea.Batch(m.refresh(msg.index), m.schedule(msg.index))
+	case dashboardMsgContent:
+		m.states[msg.index] = panelState{content: msg.content, err: msg.err}
+		m.updated = time.Now()
+	case dashboardMsgFrame:
+		m.frame++
+		for _, s := range m.states {
+			if s.refreshing {
+				return m, m.nextFrame()
+			}
+		}
+		m.animating = false
+	}
+	return m, nil
+}
+
+// Returns the command refreshing the panel at index after its interval.
+func (m dashboardModel) schedule(index int) tea.Cmd {
+	return tea.Tick(interval(m.dashboard.panels[index].panel), func(time.Time) tea.Msg {
+		return dashboardMsgRefresh{index: index}
+	})
+}
+
+// Returns the grid of the panels, without the status line.
+func (m dashboardModel) grid() epanel.GridLayout {
+	return epanel.Grid(m.dashboard.rows, m.dashboard.cols).WithSize(m.width, 0).WithGap(panelGap, 0)
+}
+
+// Returns the size of a panel in cells, including its box. The height is 0
+// when the height of the terminal is unknown.
+func (m dashboardModel) panelSize(p placedPanel) (width int, height int) {
+	widths := m.grid().ColumnWidths()
+	width = panelGap * (p.colSpan - 1)
+	for _, w := range widths[p.col : p.col+p.colSpan] {
+		width += w
+	}
+	if m.height > 0 {
+		// Rows share the lines above the status line evenly
+		available := m.height - 1
+		for row := p.row; row < p.row+p.rowSpan; row++ {
+			height += available / m.dashboard.rows
+			if row < available%m.dashboard.rows {
+				height++
+			}
+		}
+	}
+	return width, height
+}
+
+// Returns the size of the content of a panel, inside its box.
+func (m dashboardModel) contentSize(p placedPanel) (width int, height int) {
+	width, height = m.panelSize(p)
+	padding := max(m.dashboard.style.BoxStyle.Padding, 0)
+	width = max(width-2-2*padding, 1)
+	if height > 0 {
+		height = max(height-2-2*max(m.dashboard.style.BoxStyle.VerticalPadding, 0), 1)
+	}
+	return width, height
+}
+
+// Render the panels in their boxes, filling their cells when fill is set.
+func (m dashboardModel) render(fill bool) string {
+	style := m.dashboard.style
+	g := m.grid()
+	for i, p := range m.dashboard.panels {
+		state := m.states[i]
+		width, _ := m.panelSize(p)
+		contentWidth, contentHeight := m.contentSize(p)
+
+		content := state.content
+		if state.err != nil {
+			content = style.ErrorStyle.Render("error: " + state.err.Error())
+		}
+		lines := strings.Split(content, "\n")
+		for j, line := range lines {
+			lines[j] = ansi.Truncate(line, contentWidth, "…")
+		}
+		if fill && contentHeight > 0 {
+			lines = lines[:min(len(lines), contentHeight)]
+			for len(lines) < contentHeight {
+				lines = append(lines, "")
+			}
+		}
+
+		title := p.panel.Title
+		if state.refreshing && len(style.Spinner.Frames) > 0 {
+			title += " " + style.Spinner.Frames[m.frame%len(style.Spinner.Frames)]
+		}
+		box := style.BoxStyle
+		box.Width = width
+		g = g.WithCellSpan(p.row, p.col, p.rowSpan, p.colSpan, epanel.Box(title, strings.Join(lines, "\n"), box))
+	}
+	return g.Render()
+}
+
+func (m dashboardModel) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	body := m.render(true)
+	lines := strings.Split(body, "\n")
+	for len(lines) < m.height-1 {
+		lines = append(lines, "")
+	}
+	lines = lines[:max(m.height-1, 0)]
+
+	left := " " + m.dashboard.title
+	if !m.updated.IsZero() {
+		left += " · updated " + m.updated.Format("15:04:05")
+	}
+	right := " " + ekeys.Plain(ekeys.Bind("r", "refresh"), ekeys.Quit) + " "
+	left = ansi.Truncate(left, max(m.width-ansi.StringWidth(right), 0), "…")
+	fill := strings.Repeat(" ", max(m.width-ansi.StringWidth(left)-ansi.StringWidth(right), 0))
+	return strings.Join(lines, "\n") + "\n" + m.dashboard.style.StatusStyle.Render(left+fill+right)
+}
@@ -0,0 +1,501 @@
+package ediff
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Layout of a rendered Diff.
+type DiffMode int
+
+const (
+	// Side by side on terminals at least 140 cells wide, unified otherwise
+	DiffAuto DiffMode = iota
+	DiffUnified
+	DiffSideBySide
+)
+
+// Diff style definition. The emphasis styles mark the parts of the changed
+// lines that differ from the line they replace.
+type DiffStyle struct {
+	HeaderStyle          lipgloss.Style
+	HunkStyle            lipgloss.Style
+	ContextStyle         lipgloss.Style
+	AddedStyle           lipgloss.Style
+	RemovedStyle         lipgloss.Style
+	AddedEmphasisStyle   lipgloss.Style
+	RemovedEmphasisStyle lipgloss.Style
+	LineNumberStyle      lipgloss.Style
+	Separator            string
+}
+
+// Default DiffStyle used by Diff, with the additions in green and the
+// removals in red.
+var DiffStyleDefault = diffStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		DiffStyleDefault = diffStyle(t)
+	})
+}
+
+// Returns DiffStyleDefault for the theme t.
+func diffStyle(t etheme.Theme) DiffStyle {
+	return DiffStyle{
+		HeaderStyle:          lipgloss.NewStyle().Bold(true),
+		HunkStyle:            lipgloss.NewStyle().Foreground(t.Accent),
+		ContextStyle:         lipgloss.NewStyle(),
+		AddedStyle:           lipgloss.NewStyle().Foreground(t.Success),
+		RemovedStyle:         lipgloss.NewStyle().Foreground(t.Error),
+		AddedEmphasisStyle:   lipgloss.NewStyle().Foreground(t.Success).Reverse(true),
+		RemovedEmphasisStyle: lipgloss.NewStyle().Foreground(t.Error).Reverse(true),
+		LineNumberStyle:      lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		Separator:            "│",
+	}
+}
+
+// Diff renders the changes between two texts, line by line.
+type Diff struct {
+	from     string
+	to       string
+	fromName string
+	toName   string
+	context  int
+	mode     DiffMode
+	style    DiffStyle
+	width    int
+}
+
+// Create a new Diff of the changes turning from into to, with 3 lines of
+// context around them. When more than 2000 lines change, the lines of from
+// are shown as removed and the ones of to as added, rather than searching
+// for the smallest diff.
+//
+//	d := ediff.NewDiff(current, desired).WithNames("live", "manifest")
+//	fmt.Println(d.Render())
+func NewDiff(from string, to string) Diff {
+	return Diff{
+		from:    from,
+		to:      to,
+		context: 3,
+		style:   DiffStyleDefault,
+	}
+}
+
+// Create a new Diff of the changes turning the file from into the file to,
+// named after their paths. Files too different from each other are shown
+// as entirely replaced, see NewDiff.
+//
+//	d, err := ediff.NewDiffFromFiles("config.old.yaml", "config.yaml")
+func NewDiffFromFiles(from string, to string) (Diff, error) {
+	a, err := os.ReadFile(from)
+	if err != nil {
+		return Diff{}, err
+	}
+	b, err := os.ReadFile(to)
+	if err != nil {
+		return Diff{}, err
+	}
+	return NewDiff(string(a), string(b)).WithNames(from, to), nil
+}
+
+// Render the changes turning from into to, see NewDiff.
+//
+//	fmt.Println(ediff.Render(before, after))
+func Render(from string, to string) string {
+	return NewDiff(from, to).Render()
+}
+
+// Specify the names of the texts, rendered in the header.
+//
+//	d := ediff.NewDiff(a, b).WithNames("a/main.go", "b/main.go")
+func (d Diff) WithNames(from string, to string) Diff {
+	d.fromName = from
+	d.toName = to
+	return d
+}
+
+// Specify the number of unchanged lines rendered around the changes.
+//
+//	d := ediff.NewDiff(a, b).WithContext(1)
+func (d Diff) WithContext(lines int) Diff {
+	d.context = max(lines, 0)
+	return d
+}
+
+// Specify the layout of the Diff.
+//
+//	d := ediff.NewDiff(a, b).WithMode(ediff.DiffSideBySide)
+func (d Diff) WithMode(mode DiffMode) Diff {
+	d.mode = mode
+	return d
+}
+
+// Specify the style of the Diff.
+//
+//	d := ediff.NewDiff(a, b).WithStyle(ediff.DiffStyleDefault)
+func (d Diff) WithStyle(s DiffStyle) Diff {
+	d.style = s
+	return d
+}
+
+// Specify the width of the Diff in cells, used to choose the layout and to
+// wrap the lines side by side. A width of 0 uses the width of the terminal.
+//
+//	d := ediff.NewDiff(a, b).WithWidth(200)
+func (d Diff) WithWidth(w int) Diff {
+	d.width = w
+	return d
+}
+
+// Returns the lines of a text, with the tabs expanded.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(strings.TrimSuffix(s, "\n"), "\t", "    ")
+	return strings.Split(s, "\n")
+}
+
+// Reports whether the texts differ.
+func (d Diff) HasChanges() bool {
+	return d.from != d.to
+}
+
+// A group of changes with their context, as edits of the lines, starting
+// after the first aStart lines of the first text and bStart of the second.
+type hunk struct {
+	ops    []op
+	aStart int
+	bStart int
+}
+
+// Returns the position of the hunk in the texts, as in unified diffs.
+func (h hunk) header() string {
+	aLen, bLen := 0, 0
+	for _, o := range h.ops {
+		if o.a >= 0 {
+			aLen++
+		}
+		if o.b >= 0 {
+			bLen++
+		}
+	}
+	// The position of an empty range is the line before it
+	position := func(start int, n int) string {
+		if n == 0 {
+			return strconv.Itoa(start) + ",0"
+		}
+		return strconv.Itoa(start+1) + "," + strconv.Itoa(n)
+	}
+	return "@@ -" + position(h.aStart, aLen) + " +" + position(h.bStart, bLen) + " @@"
+}
+
+// Group the edits in hunks of changes surrounded by context lines.
+func (d Diff) hunks(ops []op) []hunk {
+	hunks := []hunk{}
+	add := func(start int, end int) {
+		h := hunk{ops: ops[start:end]}
+		for _, o := range ops[:start] {
+			if o.a >= 0 {
+				h.aStart++
+			}
+			if o.b >= 0 {
+				h.bStart++
+			}
+		}
+		hunks = append(hunks, h)
+	}
+
+	start, end := -1, -1
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		from, to := max(i-d.context, 0), min(i+d.context+1, len(ops))
+		if start >= 0 && from <= end {
+			end = to
+			continue
+		}
+		if start >= 0 {
+			add(start, end)
+		}
+		start, end = from, to
+	}
+	if start >= 0 {
+		add(start, end)
+	}
+	return hunks
+}
+
+// Render the Diff, empty when the texts are equal.
+func (d Diff) Render() string {
+	if !d.HasChanges() {
+		return ""
+	}
+	a, b := splitLines(d.from), splitLines(d.to)
+	hunks := d.hunks(diff(a, b))
+
+	width := d.width
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	mode := d.mode
+	if mode == DiffAuto {
+		mode = DiffUnified
+		if width >= 140 {
+			mode = DiffSideBySide
+		}
+	}
+	if mode == DiffSideBySide {
+		return d.renderSideBySide(a, b, hunks, width)
+	}
+	return d.renderUnified(a, b, hunks)
+}
+
+// A part of a hunk: an unchanged line, or a run of removed lines followed by
+// the lines added in their place.
+type segment struct {
+	equal   op
+	removed []op
+	added   []op
+}
+
+// Split the edits of a hunk into segments.
+func segments(ops []op) []segment {
+	segs := []segment{}
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			segs = append(segs, segment{equal: ops[i]})
+			i++
+			continue
+		}
+		seg := segment{}
+		for ; i < len(ops) && ops[i].kind != opEqual; i++ {
+			if ops[i].kind == opDelete {
+				seg.removed = append(seg.removed, ops[i])
+			} else {
+				seg.added = append(seg.added, ops[i])
+			}
+		}
+		segs = append(segs, seg)
+	}
+	return segs
+}
+
+// Render the removed and the added lines of a segment. The lines replacing
+// each other in order are compared to emphasize their differences.
+func (d Diff) renderChanges(a []string, b []string, seg segment) ([]string, []string) {
+	removed := make([]string, len(seg.removed))
+	added := make([]string, len(seg.added))
+	for i, o := range seg.removed {
+		removed[i] = d.style.RemovedStyle.Render(a[o.a])
+	}
+	for i, o := range seg.added {
+		added[i] = d.style.AddedStyle.Render(b[o.b])
+	}
+	for i := 0; i < min(len(removed), len(added)); i++ {
+		removed[i], added[i] = d.emphasize(a[seg.removed[i].a], b[seg.added[i].b])
+	}
+	return removed, added
+}
+
+// Split a line into words, runs of spaces and single symbols.
+func tokenize(line string) []string {
+	class := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		}
+		return 0
+	}
+	tokens := []string{}
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		if c := class(runes[i]); c != 0 {
+			for j < len(runes) && class(runes[j]) == c {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+// Largest number of words of a pair of lines compared by emphasize, longer
+// lines like minified documents are not emphasized.
+const maxEmphasisTokens = 1000
+
+// Render a removed line and the line added in its place, emphasizing the
+// words that differ. Lines too different from each other or too long are
+// not emphasized, as it would only add noise.
+func (d Diff) emphasize(from string, to string) (string, string) {
+	s := d.style
+	ta, tb := tokenize(from), tokenize(to)
+	if len(ta)+len(tb) > maxEmphasisTokens {
+		return s.RemovedStyle.Render(from), s.AddedStyle.Render(to)
+	}
+	ops := diff(ta, tb)
+
+	common := 0
+	for _, o := range ops {
+		if o.kind == opEqual {
+			common += len(ta[o.a])
+		}
+	}
+	if common*2 < max(len(from), len(to)) {
+		return s.RemovedStyle.Render(from), s.AddedStyle.Render(to)
+	}
+
+	var removed, added styledBuilder
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			removed.write(s.RemovedStyle, ta[o.a])
+			added.write(s.AddedStyle, tb[o.b])
+		case opDelete:
+			removed.write(s.RemovedEmphasisStyle, ta[o.a])
+		case opInsert:
+			added.write(s.AddedEmphasisStyle, tb[o.b])
+		}
+	}
+	return removed.String(), added.String()
+}
+
+// Builds a string from parts, rendering the consecutive parts of the same
+// style together.
+type styledBuilder struct {
+	b      strings.Builder
+	style  lipgloss.Style
+	buffer string
+}
+
+func (sb *styledBuilder) write(style lipgloss.Style, s string) {
+	if sb.buffer != "" && style.String() != sb.style.String() {
+		sb.flush()
+	}
+	sb.style = style
+	sb.buffer += s
+}
+
+func (sb *styledBuilder) flush() {
+	if sb.buffer != "" {
+		sb.b.WriteString(sb.style.Render(sb.buffer))
+		sb.buffer = ""
+	}
+}
+
+func (sb *styledBuilder) String() string {
+	sb.flush()
+	return sb.b.String()
+}
+
+func (d Diff) renderUnified(a []string, b []string, hunks []hunk) string {
+	s := d.style
+	lines := []string{}
+	if d.fromName != "" || d.toName != "" {
+		lines = append(lines, s.HeaderStyle.Render("--- "+d.fromName), s.HeaderStyle.Render("+++ "+d.toName))
+	}
+	for _, h := range hunks {
+		lines = append(lines, s.HunkStyle.Render(h.header()))
+		for _, seg := range segments(h.ops) {
+			if seg.removed == nil && seg.added == nil {
+				lines = append(lines, s.ContextStyle.Render(" "+a[seg.equal.a]))
+				continue
+			}
+			removed, added := d.renderChanges(a, b, seg)
+			for _, line := range removed {
+				lines = append(lines, s.RemovedStyle.Render("-")+line)
+			}
+			for _, line := range added {
+				lines = append(lines, s.AddedStyle.Render("+")+line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// A line of one side of a side by side Diff.
+type sideLine struct {
+	number int
+	marker string
+	text   string
+}
+
+func (d Diff) renderSideBySide(a []string, b []string, hunks []hunk, width int) string {
+	s := d.style
+	numberWidth := len(strconv.Itoa(max(len(a), len(b))))
+	separator := " " + s.LineNumberStyle.Render(s.Separator) + " "
+	side := max((width-ansi.StringWidth(separator))/2, numberWidth+4)
+	textWidth := side - numberWidth - 3
+
+	// Render a side, wrapped to its width
+	render := func(l *sideLine) []string {
+		if l == nil {
+			return []string{""}
+		}
+		rows := strings.Split(ansi.Hardwrap(l.text, textWidth, true), "\n")
+		for i := range rows {
+			number := strings.Repeat(" ", numberWidth)
+			if i == 0 {
+				number = fmt.Sprintf("%*d", numberWidth, l.number)
+			}
+			rows[i] = s.LineNumberStyle.Render(number) + " " + l.marker + " " + rows[i]
+		}
+		return rows
+	}
+	lines := []string{}
+	row := func(left *sideLine, right *sideLine) {
+		l, r := render(left), render(right)
+		for i := range max(len(l), len(r)) {
+			cell := ""
+			if i < len(l) {
+				cell = l[i]
+			}
+			line := cell + strings.Repeat(" ", max(side-ansi.StringWidth(cell), 0)) + separator
+			if i < len(r) {
+				line += r[i]
+			}
+			lines = append(lines, strings.TrimRight(line, " "))
+		}
+	}
+
+	if d.fromName != "" || d.toName != "" {
+		from := ansi.Truncate(d.fromName, side, "…")
+		lines = append(lines, s.HeaderStyle.Render(from)+strings.Repeat(" ", side-ansi.StringWidth(from))+separator+s.HeaderStyle.Render(ansi.Truncate(d.toName, side, "…")))
+	}
+	for _, h := range hunks {
+		lines = append(lines, s.HunkStyle.Render(h.header()))
+		for _, seg := range segments(h.ops) {
+			if seg.removed == nil && seg.added == nil {
+				o := seg.equal
+				row(&sideLine{o.a + 1, " ", s.ContextStyle.Render(a[o.a])}, &sideLine{o.b + 1, " ", s.ContextStyle.Render(b[o.b])})
+				continue
+			}
+			removed, added := d.renderChanges(a, b, seg)
+			for i := range max(len(removed), len(added)) {
+				var left, right *sideLine
+				if i < len(removed) {
+					left = &sideLine{seg.removed[i].a + 1, s.RemovedStyle.Render("-"), removed[i]}
+				}
+				if i < len(added) {
+					right = &sideLine{seg.added[i].b + 1, s.AddedStyle.Render("+"), added[i]}
+				}
+				row(left, right)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,29 @@
+package ediff_test
+
+import (
+	"testing"
+
+	"github.com/ravvio/easycli-ui/ediff"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etest"
+)
+
+const (
+	before = "name: web\nreplicas: 2\nimage: nginx:1.25\nport: 80\n"
+	after  = "name: web\nreplicas: 3\nimage: nginx:1.27\nport: 80\ntls: true\n"
+)
+
+func TestRenderUnified(t *testing.T) {
+	etest.Setup(t)
+	d := ediff.NewDiff(before, after).WithNames("live", "manifest").WithMode(ediff.DiffUnified)
+	etest.Golden(t, d.Render())
+}
+
+func TestRenderSideBySide(t *testing.T) {
+	term := etest.TerminalDefault
+	term.Width = 100
+	term.Colors = eterm.Colors16
+	etest.SetupTerminal(t, term)
+	d := ediff.NewDiff(before, after).WithNames("live", "manifest").WithMode(ediff.DiffSideBySide)
+	etest.Golden(t, d.Render())
+}
@@ -0,0 +1,144 @@
+package ediff
+
+// Kind of an edit of a diff.
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// An edit turning a into b: an element kept, removed from a or inserted
+// from b. a and b are the indexes of the element in the sequences, -1 when
+// it is not part of one of them.
+type op struct {
+	kind opKind
+	a    int
+	b    int
+}
+
+// Largest number of edits searched by diff. Beyond it the sequences are
+// considered entirely different, which bounds the time and the memory to
+// O(N+M+maxEdits²) for large inputs.
+const maxEdits = 2000
+
+// Returns the shortest sequence of edits turning a into b, computed with
+// the algorithm of Myers. Deletions precede the insertions they replace.
+// When more than maxEdits edits are needed, every element of a is deleted
+// and every element of b inserted instead.
+func diff[T comparable](a []T, b []T) []op {
+	n, m := len(a), len(b)
+	maxD := min(n+m, maxEdits)
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// Diagonals -d to d of v before each round d, the ones it reads
+	trace := [][]int{}
+
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return replace(n, m)
+	}
+
+	// Walk back from the end through the snapshots of v, taken before each
+	// round d.
+	ops := []op{}
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[d+k-1] < v[d+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = v[d+prevK]
+		}
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, op{kind: opEqual, a: x, b: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, op{kind: opInsert, a: -1, b: y})
+		} else {
+			x--
+			ops = append(ops, op{kind: opDelete, a: x, b: -1})
+		}
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return reorder(ops)
+}
+
+// Returns the edits deleting the n elements of a and inserting the m
+// elements of b.
+func replace(n int, m int) []op {
+	ops := make([]op, 0, n+m)
+	for i := range n {
+		ops = append(ops, op{kind: opDelete, a: i, b: -1})
+	}
+	for j := range m {
+		ops = append(ops, op{kind: opInsert, a: -1, b: j})
+	}
+	return ops
+}
+
+// Move the deletions before the insertions in each run of changes.
+func reorder(ops []op) []op {
+	out := make([]op, 0, len(ops))
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			out = append(out, ops[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(ops) && ops[j].kind != opEqual {
+			j++
+		}
+		for _, o := range ops[i:j] {
+			if o.kind == opDelete {
+				out = append(out, o)
+			}
+		}
+		for _, o := range ops[i:j] {
+			if o.kind == opInsert {
+				out = append(out, o)
+			}
+		}
+		i = j
+	}
+	return out
+}
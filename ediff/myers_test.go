@@ -0,0 +1,128 @@
+package ediff
+
+import (
+	"strings"
+	"testing"
+)
+
+// Returns the sequences rebuilt from ops, the elements kept or deleted for
+// a and the ones kept or inserted for b.
+func apply(ops []op, a []string, b []string) ([]string, []string) {
+	var gotA, gotB []string
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			gotA = append(gotA, a[o.a])
+			gotB = append(gotB, b[o.b])
+		case opDelete:
+			gotA = append(gotA, a[o.a])
+		case opInsert:
+			gotB = append(gotB, b[o.b])
+		}
+	}
+	return gotA, gotB
+}
+
+// Returns the length of the longest common subsequence of a and b.
+func lcs(a []string, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{"empty", "", "", ""},
+		{"equal", "abc", "abc", "==="},
+		{"insert all", "", "abc", "+++"},
+		{"delete all", "abc", "", "---"},
+		{"insert middle", "ac", "abc", "=+="},
+		{"delete middle", "abc", "ac", "=-="},
+		{"replace", "abc", "axc", "=-+="},
+		{"deletions first", "ab", "cd", "--++"},
+		{"prefix and suffix", "xabcy", "xbcdy", "=-==+="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := strings.Split(tt.a, ""), strings.Split(tt.b, "")
+			ops := diff(a, b)
+			var got strings.Builder
+			for _, o := range ops {
+				got.WriteByte("=-+"[o.kind])
+			}
+			if got.String() != tt.want {
+				t.Errorf("diff(%q, %q) = %q, want %q", tt.a, tt.b, got.String(), tt.want)
+			}
+			gotA, gotB := apply(ops, a, b)
+			if strings.Join(gotA, "") != tt.a || strings.Join(gotB, "") != tt.b {
+				t.Errorf("diff(%q, %q) rebuilds %q and %q", tt.a, tt.b, strings.Join(gotA, ""), strings.Join(gotB, ""))
+			}
+		})
+	}
+}
+
+func TestDiffShortest(t *testing.T) {
+	tests := []struct {
+		a string
+		b string
+	}{
+		{"abcabba", "cbabac"},
+		{"the quick brown fox", "the slow brown dog"},
+		{"aaaaaaaaaa", "aaaaabaaaaa"},
+		{"abcdefgh", "hgfedcba"},
+	}
+	for _, tt := range tests {
+		a, b := strings.Split(tt.a, ""), strings.Split(tt.b, "")
+		ops := diff(a, b)
+		equal := 0
+		for _, o := range ops {
+			if o.kind == opEqual {
+				equal++
+			}
+		}
+		if want := lcs(a, b); equal != want {
+			t.Errorf("diff(%q, %q) keeps %d elements, want %d", tt.a, tt.b, equal, want)
+		}
+		gotA, gotB := apply(ops, a, b)
+		if strings.Join(gotA, "") != tt.a || strings.Join(gotB, "") != tt.b {
+			t.Errorf("diff(%q, %q) rebuilds %q and %q", tt.a, tt.b, strings.Join(gotA, ""), strings.Join(gotB, ""))
+		}
+	}
+}
+
+func TestDiffMaxEdits(t *testing.T) {
+	a := make([]int, maxEdits)
+	b := make([]int, maxEdits)
+	for i := range a {
+		a[i] = i
+		b[i] = -i - 1
+	}
+	ops := diff(a, b)
+	if len(ops) != 2*maxEdits {
+		t.Fatalf("got %d edits, want %d", len(ops), 2*maxEdits)
+	}
+	for i, o := range ops {
+		want := opDelete
+		if i >= maxEdits {
+			want = opInsert
+		}
+		if o.kind != want {
+			t.Fatalf("edit %d is %d, want %d", i, o.kind, want)
+		}
+	}
+}
@@ -0,0 +1,563 @@
+// Package efuzzy is a full screen fuzzy finder, like fzf, to pick one or
+// more items of a long list by typing a part of them. It draws on stderr
+// and reads the keys from the terminal, so the items can be piped in and
+// the choice piped out.
+//
+//	files, err := efuzzy.FindLines(os.Stdin).RunMulti()
+package efuzzy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/fuzzy"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// ErrAborted is returned when the user leaves the finder with Esc or
+// Ctrl+C.
+var ErrAborted = errors.New("aborted")
+
+// ErrNonInteractive is returned when the finder cannot be displayed because
+// stderr is not a terminal.
+var ErrNonInteractive = errors.New("the terminal is not interactive")
+
+// ErrNoItems is returned when the finder has no items and no stream of
+// items.
+var ErrNoItems = errors.New("no items to find")
+
+// Finder style definition.
+type FinderStyle struct {
+	PromptStyle   lipgloss.Style
+	MatchStyle    lipgloss.Style
+	SelectedStyle lipgloss.Style
+	MarkStyle     lipgloss.Style
+	StatusStyle   lipgloss.Style
+	BorderStyle   lipgloss.Style
+	Prompt        string
+	Cursor        string
+	// Rendered before the items marked with Tab by RunMulti
+	Mark string
+	// Rendered between the items and the preview
+	Border string
+}
+
+// Default FinderStyle used by Find. Uses the primary color of the
+// etheme.Theme for the prompt and the item under the cursor, and the accent
+// color for the matched characters.
+var FinderStyleDefault = finderStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		FinderStyleDefault = finderStyle(t)
+	})
+}
+
+// Returns FinderStyleDefault for the theme t, FinderStyleASCII when the
+// terminal cannot display unicode.
+func finderStyle(t etheme.Theme) FinderStyle {
+	if !eterm.Unicode() {
+		return FinderStyleASCII
+	}
+	return FinderStyle{
+		PromptStyle:   lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		MatchStyle:    lipgloss.NewStyle().Foreground(t.Accent).Bold(true),
+		SelectedStyle: lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		MarkStyle:     lipgloss.NewStyle().Foreground(t.Success),
+		StatusStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		BorderStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		Prompt:        "> ",
+		Cursor:        ">",
+		Mark:          "●",
+		Border:        "│",
+	}
+}
+
+// FinderStyle without colors nor unicode characters, for terminals with
+// limited support.
+var FinderStyleASCII = FinderStyle{
+	PromptStyle:   lipgloss.NewStyle().Bold(true),
+	MatchStyle:    lipgloss.NewStyle().Underline(true),
+	SelectedStyle: lipgloss.NewStyle().Reverse(true),
+	MarkStyle:     lipgloss.NewStyle(),
+	StatusStyle:   lipgloss.NewStyle(),
+	BorderStyle:   lipgloss.NewStyle(),
+	Prompt:        "> ",
+	Cursor:        ">",
+	Mark:          "*",
+	Border:        "|",
+}
+
+// Finder lets the user filter items by typing and pick one, with Run, or
+// several, with RunMulti. The items are matched fuzzily on their label, the
+// best matches first.
+type Finder[T any] struct {
+	items   []T
+	stream  <-chan T
+	label   func(T) string
+	preview func(T) string
+	query   string
+	style   FinderStyle
+}
+
+// Create a Finder of strings.
+//
+//	branch, err := efuzzy.Find(branches).Run()
+func Find(items []string) Finder[string] {
+	return FindFunc(items, func(s string) string {
+		return s
+	})
+}
+
+// Create a Finder of items of any type, matched and rendered by their
+// label.
+//
+//	pod, err := efuzzy.FindFunc(pods, func(p Pod) string {
+//		return p.Namespace + "/" + p.Name
+//	}).Run()
+func FindFunc[T any](items []T, label func(T) string) Finder[T] {
+	return Finder[T]{
+		items: items,
+		label: label,
+		style: FinderStyleDefault,
+	}
+}
+
+// Specify the style of the Finder.
+//
+//	f := efuzzy.Find(items).WithStyle(efuzzy.FinderStyleASCII)
+func (f Finder[T]) WithStyle(s FinderStyle) Finder[T] {
+	f.style = s
+	return f
+}
+
+// Specify the query typed when the Finder starts.
+//
+//	f := efuzzy.Find(items).WithQuery(flagQuery)
+func (f Finder[T]) WithQuery(q string) Finder[T] {
+	f.query = q
+	return f
+}
+
+// Show the content returned by preview for the item under the cursor next
+// to the items. preview is called in the background, once per item, so it
+// can read files or query services.
+//
+//	f := efuzzy.Find(files).WithPreview(func(path string) string {
+//		b, _ := os.ReadFile(path)
+//		return string(b)
+//	})
+func (f Finder[T]) WithPreview(preview func(T) string) Finder[T] {
+	f.preview = preview
+	return f
+}
+
+// Add the items received from items after the initial ones, while the
+// user types, until the channel is closed.
+//
+//	f := efuzzy.FindFunc(nil, label).WithStream(results)
+func (f Finder[T]) WithStream(items <-chan T) Finder[T] {
+	f.stream = items
+	return f
+}
+
+// Let the user pick one item, returning it. Up and Down move the cursor,
+// Enter chooses the item under it.
+// When stderr is not a terminal ErrNonInteractive is returned.
+//
+//	branch, err := efuzzy.Find(branches).Run()
+func (f Finder[T]) Run() (T, error) {
+	var zero T
+	m, err := f.run(false)
+	if err != nil {
+		return zero, err
+	}
+	return m.items[m.matches[m.cursor]], nil
+}
+
+// Let the user pick several items, marking them with Tab, or all the
+// matches with Ctrl+A, returning them in the order of the items. When
+// nothing is marked the item under the cursor is returned.
+//
+//	files, err := efuzzy.FindLines(os.Stdin).RunMulti()
+func (f Finder[T]) RunMulti() ([]T, error) {
+	m, err := f.run(true)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.marked) == 0 {
+		return []T{m.items[m.matches[m.cursor]]}, nil
+	}
+	indexes := make([]int, 0, len(m.marked))
+	for i := range m.marked {
+		indexes = append(indexes, i)
+	}
+	slices.Sort(indexes)
+	chosen := make([]T, len(indexes))
+	for i, index := range indexes {
+		chosen[i] = m.items[index]
+	}
+	return chosen, nil
+}
+
+// Run the Finder until the user chooses or aborts.
+func (f Finder[T]) run(multi bool) (finderModel[T], error) {
+	if len(f.items) == 0 && f.stream == nil {
+		return finderModel[T]{}, ErrNoItems
+	}
+	if !eterm.IsTerminal(os.Stderr) {
+		return finderModel[T]{}, ErrNonInteractive
+	}
+
+	m := newFinderModel(f, multi)
+	final, err := live.Run(tea.NewProgram(m,
+		tea.WithAltScreen(),
+		tea.WithOutput(os.Stderr),
+		tea.WithInputTTY(),
+	))
+	if err != nil {
+		return finderModel[T]{}, err
+	}
+	m = final.(finderModel[T])
+	if m.aborted {
+		return finderModel[T]{}, ErrAborted
+	}
+	return m, nil
+}
+
+// The bubbletea.Msg sent when the preview of an item has been computed
+type finderMsgPreview struct {
+	index   int
+	content string
+}
+
+// Bubbletea model of a running Finder.
+type finderModel[T any] struct {
+	finder Finder[T]
+	multi  bool
+	input  textinput.Model
+	items  []T
+	labels []string
+	// Indexes of the items matching the query, best matches first
+	matches []int
+	marked  map[int]bool
+	// Previews by index of item, computed or being computed
+	previews  map[int]*string
+	streaming bool
+	cursor    int
+	offset    int
+	width     int
+	height    int
+	done      bool
+	aborted   bool
+}
+
+func newFinderModel[T any](f Finder[T], multi bool) finderModel[T] {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.SetValue(f.query)
+	ti.Focus()
+
+	width, height := eterm.Size()
+	m := finderModel[T]{
+		finder:    f,
+		multi:     multi,
+		input:     ti,
+		marked:    map[int]bool{},
+		previews:  map[int]*string{},
+		streaming: f.stream != nil,
+		width:     width,
+		height:    height,
+	}
+	m.add(f.items)
+	return m
+}
+
+func (m finderModel[T]) Init() tea.Cmd {
+	cmds := []tea.Cmd{textinput.Blink, m.previewCmd()}
+	if m.streaming {
+		cmds = append(cmds, readStream(m.finder.stream))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Number of items rendered at once, below the prompt and above the status
+// line.
+func (m finderModel[T]) listHeight() int {
+	return max(m.height-2, 1)
+}
+
+// Append items and filter them with the query, keeping the cursor on the
+// same item.
+func (m *finderModel[T]) add(items []T) {
+	for _, item := range items {
+		m.items = append(m.items, item)
+		m.labels = append(m.labels, m.finder.label(item))
+	}
+	current := -1
+	if m.cursor < len(m.matches) {
+		current = m.matches[m.cursor]
+	}
+	m.matches = fuzzy.Filter(m.input.Value(), m.labels)
+	if i := slices.Index(m.matches, current); i >= 0 {
+		m.moveTo(i)
+	} else {
+		m.moveTo(0)
+	}
+}
+
+// Move the cursor to the i-th match, scrolling if needed.
+func (m *finderModel[T]) moveTo(i int) {
+	m.cursor = min(max(i, 0), max(len(m.matches)-1, 0))
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = max(min(m.offset, len(m.matches)-height), 0)
+}
+
+// Returns the command computing the preview of the item under the cursor,
+// if it is not known yet.
+func (m finderModel[T]) previewCmd() tea.Cmd {
+	if m.finder.preview == nil || len(m.matches) == 0 {
+		return nil
+	}
+	index := m.matches[m.cursor]
+	if _, ok := m.previews[index]; ok {
+		return nil
+	}
+	// The map is shared by the copies of the model, the entry marks the
+	// preview as being computed
+	m.previews[index] = nil
+	item, preview := m.items[index], m.finder.preview
+	return func() tea.Msg {
+		return finderMsgPreview{index: index, content: preview(item)}
+	}
+}
+
+// Mark or unmark the item under the cursor.
+func (m *finderModel[T]) toggle() {
+	if len(m.matches) == 0 {
+		return
+	}
+	index := m.matches[m.cursor]
+	if m.marked[index] {
+		delete(m.marked, index)
+	} else {
+		m.marked[index] = true
+	}
+}
+
+// Mark all the matches, or unmark them when they are all marked.
+func (m *finderModel[T]) toggleAll() {
+	all := true
+	for _, index := range m.matches {
+		all = all && m.marked[index]
+	}
+	for _, index := range m.matches {
+		if all {
+			delete(m.marked, index)
+		} else {
+			m.marked[index] = true
+		}
+	}
+}
+
+func (m finderModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.moveTo(m.cursor)
+		return m, nil
+	case streamMsg[T]:
+		m.add(msg.items)
+		m.streaming = !msg.closed
+		if m.streaming {
+			return m, tea.Batch(readStream(m.finder.stream), m.previewCmd())
+		}
+		return m, m.previewCmd()
+	case finderMsgPreview:
+		m.previews[msg.index] = &msg.content
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case "enter":
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+			m.done = true
+			return m, tea.Quit
+		case "up", "ctrl+p", "ctrl+k":
+			m.moveTo(m.cursor - 1)
+			return m, m.previewCmd()
+		case "down", "ctrl+n", "ctrl+j":
+			m.moveTo(m.cursor + 1)
+			return m, m.previewCmd()
+		case "pgup":
+			m.moveTo(m.cursor - m.listHeight())
+			return m, m.previewCmd()
+		case "pgdown":
+			m.moveTo(m.cursor + m.listHeight())
+			return m, m.previewCmd()
+		case "tab":
+			if m.multi {
+				m.toggle()
+			}
+			m.moveTo(m.cursor + 1)
+			return m, m.previewCmd()
+		case "shift+tab":
+			if m.multi {
+				m.toggle()
+			}
+			m.moveTo(m.cursor - 1)
+			return m, m.previewCmd()
+		case "ctrl+a":
+			if m.multi {
+				m.toggleAll()
+			}
+			return m, nil
+		}
+	}
+
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() == query {
+		return m, cmd
+	}
+	m.matches = fuzzy.Filter(m.input.Value(), m.labels)
+	m.moveTo(0)
+	return m, tea.Batch(cmd, m.previewCmd())
+}
+
+// Render the label of the i-th match, highlighting the matched characters.
+func (m finderModel[T]) renderLabel(i int, width int) string {
+	style := m.finder.style
+	label := m.labels[m.matches[i]]
+	matched := fuzzy.Indexes(m.input.Value(), label)
+	base := lipgloss.NewStyle()
+	if i == m.cursor {
+		base = style.SelectedStyle
+	}
+
+	var b strings.Builder
+	next := 0
+	for j, r := range []rune(strings.ReplaceAll(label, "\t", " ")) {
+		if next < len(matched) && matched[next] == j {
+			b.WriteString(style.MatchStyle.Render(string(r)))
+			next++
+			continue
+		}
+		b.WriteString(base.Render(string(r)))
+	}
+	return ansi.Truncate(b.String(), width, "…")
+}
+
+// Render the lines of the visible matches, width cells wide.
+func (m finderModel[T]) renderList(width int) []string {
+	style := m.finder.style
+	cursorWidth := ansi.StringWidth(style.Cursor)
+	markWidth := 0
+	if m.multi {
+		markWidth = ansi.StringWidth(style.Mark) + 1
+	}
+
+	end := min(m.offset+m.listHeight(), len(m.matches))
+	lines := make([]string, 0, end-m.offset)
+	for i := m.offset; i < end; i++ {
+		line := strings.Repeat(" ", cursorWidth+1)
+		if i == m.cursor {
+			line = style.SelectedStyle.Render(style.Cursor) + " "
+		}
+		if m.multi {
+			if m.marked[m.matches[i]] {
+				line += style.MarkStyle.Render(style.Mark) + " "
+			} else {
+				line += strings.Repeat(" ", markWidth)
+			}
+		}
+		line += m.renderLabel(i, max(width-cursorWidth-1-markWidth, 1))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Render the lines of the preview of the item under the cursor, width cells
+// wide.
+func (m finderModel[T]) renderPreview(width int) []string {
+	if len(m.matches) == 0 {
+		return nil
+	}
+	content := m.previews[m.matches[m.cursor]]
+	if content == nil {
+		return []string{m.finder.style.StatusStyle.Render("Loading...")}
+	}
+	lines := strings.Split(strings.ReplaceAll(*content, "\t", "    "), "\n")
+	lines = lines[:min(len(lines), m.listHeight())]
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	return lines
+}
+
+// Render the status line, with the number of matches and the keys.
+func (m finderModel[T]) renderStatus() string {
+	status := fmt.Sprintf("%d/%d", len(m.matches), len(m.items))
+	if m.streaming {
+		status += " loading..."
+	}
+	if m.multi {
+		status += fmt.Sprintf(" · %d marked", len(m.marked))
+	}
+	status += " · " + ekeys.Plain(ekeys.Bind("tab", "mark").WithEnabled(m.multi), ekeys.Bind("enter", "choose"), ekeys.Bind("esc", "quit"))
+	return m.finder.style.StatusStyle.Render(ansi.Truncate(status, m.width, "…"))
+}
+
+func (m finderModel[T]) View() string {
+	if m.done || m.aborted {
+		return ""
+	}
+
+	style := m.finder.style
+	lines := []string{ansi.Truncate(style.PromptStyle.Render(style.Prompt)+m.input.View(), m.width, "")}
+
+	listWidth := m.width
+	var preview []string
+	if m.finder.preview != nil {
+		listWidth = m.width / 2
+		preview = m.renderPreview(max(m.width-listWidth-ansi.StringWidth(style.Border)-1, 1))
+	}
+	list := m.renderList(listWidth)
+	for i := range m.listHeight() {
+		line := ""
+		if i < len(list) {
+			line = list[i]
+		}
+		if m.finder.preview != nil {
+			line += strings.Repeat(" ", max(listWidth-ansi.StringWidth(line), 0))
+			line += style.BorderStyle.Render(style.Border) + " "
+			if i < len(preview) {
+				line += preview[i]
+			}
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, m.renderStatus())
+	return strings.Join(lines, "\n")
+}
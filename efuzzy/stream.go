@@ -0,0 +1,69 @@
+package efuzzy
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Maximum time and number of items received from a stream before they are
+// added to the Finder, so fast streams do not filter the items once per
+// item.
+const (
+	streamBatchWait = 50 * time.Millisecond
+	streamBatchSize = 10000
+)
+
+// The bubbletea.Msg sent when items have been received from the stream
+type streamMsg[T any] struct {
+	items  []T
+	closed bool
+}
+
+// Returns the command receiving the next batch of items from ch.
+func readStream[T any](ch <-chan T) tea.Cmd {
+	return func() tea.Msg {
+		item, ok := <-ch
+		if !ok {
+			return streamMsg[T]{closed: true}
+		}
+		items := []T{item}
+		timeout := time.After(streamBatchWait)
+		for len(items) < streamBatchSize {
+			select {
+			case item, ok := <-ch:
+				if !ok {
+					return streamMsg[T]{items: items, closed: true}
+				}
+				items = append(items, item)
+			case <-timeout:
+				return streamMsg[T]{items: items}
+			}
+		}
+		return streamMsg[T]{items: items}
+	}
+}
+
+// Create a Finder of the lines read from r, added while they are read so
+// the user can type before a slow command completes. Empty lines are
+// skipped.
+//
+//	// find . -type f | myapp pick
+//	path, err := efuzzy.FindLines(os.Stdin).Run()
+func FindLines(r io.Reader) Finder[string] {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+				lines <- line
+			}
+		}
+	}()
+	return Find(nil).WithStream(lines)
+}
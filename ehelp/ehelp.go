@@ -0,0 +1,332 @@
+// Package ehelp renders the help of the commands of a CLI, with their
+// synopsis, flags, subcommands and examples, so it matches the rest of the
+// output.
+package ehelp
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Help style definition. TableStyle is the style of the table of the flags,
+// Indent the number of spaces before the content of each section.
+type HelpStyle struct {
+	HeadingStyle lipgloss.Style
+	CommandStyle lipgloss.Style
+	ArgStyle     lipgloss.Style
+	FlagStyle    lipgloss.Style
+	TypeStyle    lipgloss.Style
+	DefaultStyle lipgloss.Style
+	ExampleStyle lipgloss.Style
+	CommentStyle lipgloss.Style
+	TableStyle   etable.TableStyle
+	// Rendered before each command of the examples
+	Prompt string
+	Indent int
+}
+
+// Default HelpStyle used by NewUsage. Uses the primary color of the
+// etheme.Theme for the headings and the accent color for the flags.
+var HelpStyleDefault = helpStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		HelpStyleDefault = helpStyle(t)
+	})
+}
+
+// Returns HelpStyleDefault for the theme t, HelpStyleASCII when the terminal
+// cannot display unicode.
+func helpStyle(t etheme.Theme) HelpStyle {
+	if !eterm.Unicode() {
+		return HelpStyleASCII
+	}
+	return HelpStyle{
+		HeadingStyle: lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		CommandStyle: lipgloss.NewStyle().Bold(true),
+		ArgStyle:     lipgloss.NewStyle().Foreground(t.Accent),
+		FlagStyle:    lipgloss.NewStyle().Foreground(t.Accent),
+		TypeStyle:    lipgloss.NewStyle().Italic(true),
+		DefaultStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		ExampleStyle: lipgloss.NewStyle(),
+		CommentStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		TableStyle: etable.TableStyle{
+			HeaderStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true).PaddingRight(2),
+			RowStyle:    lipgloss.NewStyle().PaddingRight(2),
+			BorderStyle: lipgloss.HiddenBorder(),
+		},
+		Prompt: "$ ",
+		Indent: 2,
+	}
+}
+
+// HelpStyle without colors, for terminals and logs with limited support.
+var HelpStyleASCII = HelpStyle{
+	HeadingStyle: lipgloss.NewStyle(),
+	CommandStyle: lipgloss.NewStyle(),
+	ArgStyle:     lipgloss.NewStyle(),
+	FlagStyle:    lipgloss.NewStyle(),
+	TypeStyle:    lipgloss.NewStyle(),
+	DefaultStyle: lipgloss.NewStyle(),
+	ExampleStyle: lipgloss.NewStyle(),
+	CommentStyle: lipgloss.NewStyle(),
+	TableStyle: etable.TableStyle{
+		HeaderStyle: lipgloss.NewStyle().PaddingRight(2),
+		RowStyle:    lipgloss.NewStyle().PaddingRight(2),
+		BorderStyle: lipgloss.HiddenBorder(),
+	},
+	Prompt: "$ ",
+	Indent: 2,
+}
+
+// A flag of a command. Name is the long name and Short the one letter name,
+// both without dashes. Type is the placeholder of the value, like string or
+// duration, and is empty for boolean flags.
+type Flag struct {
+	Name    string
+	Short   string
+	Type    string
+	Default string
+	Usage   string
+}
+
+// A subcommand of a command, with its one line description.
+type Command struct {
+	Name  string
+	Usage string
+}
+
+// Usage is the help of a command, rendered in sections: the description,
+// the synopsis, the subcommands, the flags and the examples. Empty sections
+// are omitted.
+type Usage struct {
+	name        string
+	synopsis    string
+	description string
+	commands    []Command
+	flags       []Flag
+	examples    []string
+	style       HelpStyle
+}
+
+// Create the help of the command name, which may include its parents.
+//
+//	u := ehelp.NewUsage("myapp deploy").
+//		WithSynopsis("[flags] <service>").
+//		WithDescription("Deploy a service to the cluster.").
+//		WithFlags(ehelp.FlagsFromFlagSet(fs)).
+//		WithExamples("myapp deploy api --wait")
+//	fmt.Fprintln(os.Stderr, u.Render())
+func NewUsage(name string) Usage {
+	return Usage{
+		name:  name,
+		style: HelpStyleDefault,
+	}
+}
+
+// Specify the arguments of the command, rendered after its name in the
+// synopsis.
+//
+//	u := ehelp.NewUsage("cp").WithSynopsis("[flags] <source>... <target>")
+func (u Usage) WithSynopsis(s string) Usage {
+	u.synopsis = s
+	return u
+}
+
+// Specify the description of the command, rendered first.
+//
+//	u := ehelp.NewUsage("cp").WithDescription("Copy files and directories.")
+func (u Usage) WithDescription(s string) Usage {
+	u.description = s
+	return u
+}
+
+// Specify the subcommands of the command, rendered in order.
+//
+//	u := ehelp.NewUsage("myapp").WithCommands([]ehelp.Command{
+//		{Name: "deploy", Usage: "Deploy a service"},
+//		{Name: "logs", Usage: "Show the logs of a service"},
+//	})
+func (u Usage) WithCommands(commands []Command) Usage {
+	u.commands = commands
+	return u
+}
+
+// Specify the flags of the command, rendered in order as a table. See
+// FlagsFromFlagSet and FlagsFromCLI to list them from a flag set.
+//
+//	u := ehelp.NewUsage("cp").WithFlags([]ehelp.Flag{
+//		{Name: "recursive", Short: "r", Usage: "Copy directories recursively"},
+//	})
+func (u Usage) WithFlags(flags []Flag) Usage {
+	u.flags = flags
+	return u
+}
+
+// Specify the examples of the command. The lines of each example starting
+// with # are rendered as comments, the others as commands after the prompt.
+//
+//	u := ehelp.NewUsage("cp").WithExamples(
+//		"# Copy a directory\ncp -r src/ dst/",
+//	)
+func (u Usage) WithExamples(examples ...string) Usage {
+	u.examples = examples
+	return u
+}
+
+// Specify the style of the help.
+//
+//	u := ehelp.NewUsage("cp").WithStyle(ehelp.HelpStyleASCII)
+func (u Usage) WithStyle(s HelpStyle) Usage {
+	u.style = s
+	return u
+}
+
+// Render the help.
+//
+//	fmt.Println(ehelp.NewUsage("cp").WithFlags(flags).Render())
+func (u Usage) Render() string {
+	sections := []string{}
+	if u.description != "" {
+		sections = append(sections, u.description)
+	}
+	sections = append(sections, u.section("Usage", u.renderSynopsis()))
+	if len(u.commands) > 0 {
+		sections = append(sections, u.section("Commands", u.renderCommands()))
+	}
+	if len(u.flags) > 0 {
+		sections = append(sections, u.section("Flags", u.renderFlags()))
+	}
+	if len(u.examples) > 0 {
+		sections = append(sections, u.section("Examples", u.renderExamples()))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// Render a section with its heading and its content indented.
+func (u Usage) section(heading string, content string) string {
+	indent := strings.Repeat(" ", max(u.style.Indent, 0))
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return u.style.HeadingStyle.Render(heading) + "\n" + strings.Join(lines, "\n")
+}
+
+func (u Usage) renderSynopsis() string {
+	synopsis := u.style.CommandStyle.Render(u.name)
+	if u.synopsis != "" {
+		synopsis += " " + u.style.ArgStyle.Render(u.synopsis)
+	}
+	return synopsis
+}
+
+// Render the subcommands with their descriptions aligned.
+func (u Usage) renderCommands() string {
+	width := 0
+	for _, c := range u.commands {
+		width = max(width, ansi.StringWidth(c.Name))
+	}
+	lines := make([]string, len(u.commands))
+	for i, c := range u.commands {
+		line := u.style.CommandStyle.Render(c.Name)
+		if c.Usage != "" {
+			line += strings.Repeat(" ", width-ansi.StringWidth(c.Name)+2) + c.Usage
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Render the table of the flags, without the type and default columns when
+// no flag has one.
+func (u Usage) renderFlags() string {
+	hasType, hasDefault := false, false
+	rows := make([]etable.TableRow, len(u.flags))
+	for i, f := range u.flags {
+		hasType = hasType || f.Type != ""
+		hasDefault = hasDefault || f.Default != ""
+		rows[i] = etable.TableRow{
+			"flag":    flagNames(f),
+			"type":    f.Type,
+			"default": f.Default,
+			"usage":   f.Usage,
+		}
+	}
+
+	s := u.style
+	columns := []etable.TableColumn{
+		etable.NewTableColumn("flag", "Flag").WithStyleFunc(func(style lipgloss.Style, _ string) lipgloss.Style {
+			return style.Inherit(s.FlagStyle)
+		}),
+		etable.NewTableColumn("type", "Type").WithActive(hasType).WithStyleFunc(func(style lipgloss.Style, _ string) lipgloss.Style {
+			return style.Inherit(s.TypeStyle)
+		}),
+		etable.NewTableColumn("default", "Default").WithActive(hasDefault).WithStyleFunc(func(style lipgloss.Style, _ string) lipgloss.Style {
+			return style.Inherit(s.DefaultStyle)
+		}),
+		etable.NewTableColumn("usage", "Description"),
+	}
+	t := etable.NewTable(columns).WithStyle(s.TableStyle).WithRows(rows)
+	return strings.Join(trimTable(strings.Split(t.Render(), "\n")), "\n")
+}
+
+// Returns the lines of a rendered table without the empty lines of its
+// hidden border and the trailing spaces.
+func trimTable(lines []string) []string {
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.TrimSpace(ansi.Strip(line)) == "" {
+			continue
+		}
+		out = append(out, trimRight(line))
+	}
+	return out
+}
+
+// Returns line without its trailing spaces, keeping the escape sequences
+// before them.
+func trimRight(line string) string {
+	width := ansi.StringWidth(strings.TrimRight(ansi.Strip(line), " "))
+	return ansi.Truncate(line, width, "")
+}
+
+// Returns the names of a flag with their dashes, aligning the long names of
+// the flags without a short name.
+func flagNames(f Flag) string {
+	switch {
+	case f.Short != "" && f.Name != "":
+		return "-" + f.Short + ", --" + f.Name
+	case f.Short != "":
+		return "-" + f.Short
+	case ansi.StringWidth(f.Name) == 1:
+		return "-" + f.Name
+	}
+	return "    --" + f.Name
+}
+
+// Render the examples, with the comments styled apart from the commands.
+func (u Usage) renderExamples() string {
+	lines := []string{}
+	for i, example := range u.examples {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		for _, line := range strings.Split(strings.TrimSpace(example), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "#") {
+				lines = append(lines, u.style.CommentStyle.Render(line))
+			} else {
+				lines = append(lines, u.style.Prompt+u.style.ExampleStyle.Render(line))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,88 @@
+package ehelp
+
+import (
+	"flag"
+	"strings"
+)
+
+// Returns the flags of a flag set of the standard library, sorted by name,
+// omitting the defaults that are zero values like flag.PrintDefaults. Names
+// of one letter are rendered with a single dash.
+//
+//	fs.Usage = func() {
+//		u := ehelp.NewUsage("myapp").WithFlags(ehelp.FlagsFromFlagSet(fs))
+//		fmt.Fprintln(fs.Output(), u.Render())
+//	}
+//
+// The flags of cobra commands are listed from their pflag.FlagSet:
+//
+//	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+//		if !f.Hidden {
+//			flags = append(flags, ehelp.Flag{Name: f.Name, Short: f.Shorthand,
+//				Type: f.Value.Type(), Default: f.DefValue, Usage: f.Usage})
+//		}
+//	})
+func FlagsFromFlagSet(fs *flag.FlagSet) []Flag {
+	flags := []Flag{}
+	fs.VisitAll(func(f *flag.Flag) {
+		typ, usage := flag.UnquoteUsage(f)
+		def := f.DefValue
+		switch def {
+		case "", "0", "0s", "false", "[]":
+			def = ""
+		}
+		flags = append(flags, Flag{
+			Name:    f.Name,
+			Type:    typ,
+			Default: def,
+			Usage:   usage,
+		})
+	})
+	return flags
+}
+
+// Flag of urfave/cli, implemented by its flags from v2 onwards.
+type CLIFlag interface {
+	Names() []string
+	TakesValue() bool
+	GetUsage() string
+	GetDefaultText() string
+}
+
+// Returns the flags of a urfave/cli command, given as the Flags of the
+// command. The flags not implementing CLIFlag and the hidden ones are
+// omitted.
+//
+//	u := ehelp.NewUsage(cmd.FullName()).WithFlags(ehelp.FlagsFromCLI(cmd.Flags))
+func FlagsFromCLI[F any](flags []F) []Flag {
+	out := []Flag{}
+	for _, f := range flags {
+		cf, ok := any(f).(CLIFlag)
+		if !ok {
+			continue
+		}
+		if v, ok := any(f).(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+			continue
+		}
+
+		flag := Flag{Usage: cf.GetUsage(), Default: cf.GetDefaultText()}
+		for _, name := range cf.Names() {
+			if len(name) == 1 && flag.Short == "" {
+				flag.Short = name
+			} else if flag.Name == "" {
+				flag.Name = name
+			}
+		}
+		if cf.TakesValue() {
+			flag.Type = "value"
+			if t, ok := any(f).(interface{ TypeName() string }); ok && t.TypeName() != "" {
+				flag.Type = strings.ToLower(t.TypeName())
+			}
+		}
+		if flag.Default == "false" {
+			flag.Default = ""
+		}
+		out = append(out, flag)
+	}
+	return out
+}
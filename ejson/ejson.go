@@ -0,0 +1,273 @@
+package ejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// JSON style definition. Indent is the number of spaces of each level.
+type JSONStyle struct {
+	KeyStyle         lipgloss.Style
+	StringStyle      lipgloss.Style
+	NumberStyle      lipgloss.Style
+	BoolStyle        lipgloss.Style
+	NullStyle        lipgloss.Style
+	PunctuationStyle lipgloss.Style
+	SummaryStyle     lipgloss.Style
+	Indent           int
+}
+
+// Default JSONStyle used by Render. Uses the primary color of the etheme.Theme
+// for the keys, like the other components.
+var JSONStyleDefault = jsonStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		JSONStyleDefault = jsonStyle(t)
+	})
+}
+
+// Returns JSONStyleDefault for the theme t.
+func jsonStyle(t etheme.Theme) JSONStyle {
+	return JSONStyle{
+		KeyStyle:         lipgloss.NewStyle().Foreground(t.Primary),
+		StringStyle:      lipgloss.NewStyle().Foreground(t.Success),
+		NumberStyle:      lipgloss.NewStyle().Foreground(t.Accent),
+		BoolStyle:        lipgloss.NewStyle().Foreground(t.Warning),
+		NullStyle:        lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		PunctuationStyle: lipgloss.NewStyle(),
+		SummaryStyle:     lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		Indent:           2,
+	}
+}
+
+// Kind of a JSON value.
+type valueKind int
+
+const (
+	kindObject valueKind = iota
+	kindArray
+	kindString
+	kindNumber
+	kindBool
+	kindNull
+)
+
+// A value of a parsed JSON document. The members of the objects keep the
+// order of the document.
+type node struct {
+	// Key of the member, set when the parent is an object
+	key string
+	// Position in the parent
+	index    int
+	kind     valueKind
+	scalar   string
+	parent   *node
+	children []*node
+}
+
+// Parse a JSON document.
+func parse(data []byte) (*node, error) {
+	if !json.Valid(data) {
+		// Decode again to get a descriptive error
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decode(dec, nil, "", 0)
+}
+
+// Returns the node of the next value of dec.
+func decode(dec *json.Decoder, parent *node, key string, index int) (*node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &node{key: key, index: index, parent: parent}
+	switch t := tok.(type) {
+	case json.Delim:
+		n.kind = kindArray
+		if t == '{' {
+			n.kind = kindObject
+		}
+		for i := 0; dec.More(); i++ {
+			childKey := ""
+			if n.kind == kindObject {
+				k, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				childKey = k.(string)
+			}
+			child, err := decode(dec, n, childKey, i)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, child)
+		}
+		// Closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		n.kind, n.scalar = kindString, quote(t)
+	case json.Number:
+		n.kind, n.scalar = kindNumber, t.String()
+	case bool:
+		n.kind, n.scalar = kindBool, strconv.FormatBool(t)
+	default:
+		n.kind, n.scalar = kindNull, "null"
+	}
+	return n, nil
+}
+
+// Reports whether the node is an object or an array.
+func (n *node) composite() bool {
+	return n.kind == kindObject || n.kind == kindArray
+}
+
+// Returns the opening and the closing delimiters of an object or an array.
+func (n *node) delimiters() (string, string) {
+	if n.kind == kindObject {
+		return "{", "}"
+	}
+	return "[", "]"
+}
+
+// Returns the path of the node from the root, like .spec.ports[0].name.
+func (n *node) path() string {
+	if n.parent == nil {
+		return "."
+	}
+	parts := []string{}
+	for c := n; c.parent != nil; c = c.parent {
+		if c.parent.kind == kindArray {
+			parts = append(parts, "["+strconv.Itoa(c.index)+"]")
+		} else if isIdentifier(c.key) {
+			parts = append(parts, "."+c.key)
+		} else {
+			parts = append(parts, "["+quote(c.key)+"]")
+		}
+	}
+	var b strings.Builder
+	for i := len(parts) - 1; i >= 0; i-- {
+		b.WriteString(parts[i])
+	}
+	return b.String()
+}
+
+// Returns s as a JSON string, without escaping the HTML characters.
+func quote(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	// Encoding a string cannot fail
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Reports whether key can be written in a path after a dot.
+func isIdentifier(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, r := range key {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Render a scalar value.
+func (s JSONStyle) renderScalar(n *node) string {
+	switch n.kind {
+	case kindString:
+		return s.StringStyle.Render(n.scalar)
+	case kindNumber:
+		return s.NumberStyle.Render(n.scalar)
+	case kindBool:
+		return s.BoolStyle.Render(n.scalar)
+	}
+	return s.NullStyle.Render(n.scalar)
+}
+
+// Render the key of a member of an object, followed by a colon.
+func (s JSONStyle) renderKey(n *node) string {
+	if n.parent == nil || n.parent.kind != kindObject {
+		return ""
+	}
+	return s.KeyStyle.Render(quote(n.key)) + s.PunctuationStyle.Render(": ")
+}
+
+// Returns the comma following the node, if it is not the last of its parent.
+func (s JSONStyle) renderComma(n *node) string {
+	if n.parent == nil || n.index == len(n.parent.children)-1 {
+		return ""
+	}
+	return s.PunctuationStyle.Render(",")
+}
+
+// Append the lines of the node at depth to lines.
+func (s JSONStyle) render(n *node, depth int, lines *[]string) {
+	indent := strings.Repeat(" ", depth*max(s.Indent, 0))
+	if !n.composite() {
+		*lines = append(*lines, indent+s.renderKey(n)+s.renderScalar(n)+s.renderComma(n))
+		return
+	}
+
+	open, close := n.delimiters()
+	if len(n.children) == 0 {
+		*lines = append(*lines, indent+s.renderKey(n)+s.PunctuationStyle.Render(open+close)+s.renderComma(n))
+		return
+	}
+	*lines = append(*lines, indent+s.renderKey(n)+s.PunctuationStyle.Render(open))
+	for _, child := range n.children {
+		s.render(child, depth+1, lines)
+	}
+	*lines = append(*lines, indent+s.PunctuationStyle.Render(close)+s.renderComma(n))
+}
+
+// Returns the JSON text of the node, indented and without colors.
+func (n *node) text() string {
+	// Detached from its parent, to render it without its key and comma
+	c := *n
+	c.parent = nil
+	lines := []string{}
+	JSONStyle{Indent: 2}.render(&c, 0, &lines)
+	return strings.Join(lines, "\n")
+}
+
+// Render the JSON document data indented and colored by type with
+// JSONStyleDefault, keeping the order of the keys.
+//
+//	out, err := ejson.Render(body)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(out)
+func Render(data []byte) (string, error) {
+	return RenderWithStyle(data, JSONStyleDefault)
+}
+
+// Render the JSON document data like Render, styled with s.
+//
+//	out, err := ejson.RenderWithStyle(body, style)
+func RenderWithStyle(data []byte, s JSONStyle) (string, error) {
+	root, err := parse(data)
+	if err != nil {
+		return "", err
+	}
+	lines := []string{}
+	s.render(root, 0, &lines)
+	return strings.Join(lines, "\n"), nil
+}
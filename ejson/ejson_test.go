@@ -0,0 +1,44 @@
+package ejson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"plain", `"plain"`},
+		{"\x00", `"\u0000"`},
+		{"\a", `"\u0007"`},
+		{"line\nbreak\ttab", `"line\nbreak\ttab"`},
+		{`"quoted" \ back`, `"\"quoted\" \\ back"`},
+		{"<a href='x'>&</a>", `"<a href='x'>&</a>"`},
+		{"日本", `"日本"`},
+	}
+	for _, tt := range tests {
+		if got := quote(tt.s); got != tt.want {
+			t.Errorf("quote(%q) = %s, want %s", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestRenderValidJSON(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	data := `{"bell\u0007": "\u0000", "list": [1, true, null, "<b>"], "nested": {"a": {}}}`
+	got, err := Render([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid([]byte(got)) {
+		t.Errorf("Render(%s) is not valid JSON:\n%s", data, got)
+	}
+}
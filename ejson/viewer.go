@@ -0,0 +1,404 @@
+package ejson
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eclip"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+	"github.com/ravvio/easycli-ui/internal/search"
+)
+
+// Style of the interactive parts of a Viewer.
+type ViewerStyle struct {
+	CursorStyle lipgloss.Style
+	MatchStyle  lipgloss.Style
+	StatusStyle lipgloss.Style
+	Cursor      string
+}
+
+// Default ViewerStyle used by Viewer, with the status line in reverse video.
+var ViewerStyleDefault = viewerStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		ViewerStyleDefault = viewerStyle(t)
+	})
+}
+
+// Returns ViewerStyleDefault for the theme t.
+func viewerStyle(t etheme.Theme) ViewerStyle {
+	return ViewerStyle{
+		CursorStyle: lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		MatchStyle:  lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Warning),
+		StatusStyle: lipgloss.NewStyle().Reverse(true),
+		Cursor:      "▸",
+	}
+}
+
+// Viewer lets the user browse a JSON document interactively, folding its
+// objects and arrays and searching its keys.
+type Viewer struct {
+	data        []byte
+	title       string
+	style       JSONStyle
+	viewerStyle ViewerStyle
+}
+
+// Create a new Viewer of the JSON document data.
+//
+//	err := ejson.NewViewer(body).WithTitle("GET /v1/clusters").Run()
+func NewViewer(data []byte) Viewer {
+	return Viewer{
+		data:        data,
+		style:       JSONStyleDefault,
+		viewerStyle: ViewerStyleDefault,
+	}
+}
+
+// Specify a title rendered in the status line.
+//
+//	v := ejson.NewViewer(body).WithTitle("response")
+func (v Viewer) WithTitle(title string) Viewer {
+	v.title = title
+	return v
+}
+
+// Specify the style of the document.
+//
+//	v := ejson.NewViewer(body).WithStyle(ejson.JSONStyleDefault)
+func (v Viewer) WithStyle(s JSONStyle) Viewer {
+	v.style = s
+	return v
+}
+
+// Specify the style of the cursor, the matches and the status line.
+//
+//	v := ejson.NewViewer(body).WithViewerStyle(ejson.ViewerStyleDefault)
+func (v Viewer) WithViewerStyle(s ViewerStyle) Viewer {
+	v.viewerStyle = s
+	return v
+}
+
+// Browse the JSON document data with the default Viewer, see Viewer.Run.
+//
+//	if err := ejson.View(body); err != nil {
+//		return err
+//	}
+func View(data []byte) error {
+	return NewViewer(data).Run()
+}
+
+// Browse the document until the user quits with q or Ctrl+C. Enter or Space
+// folds and unfolds the object or the array under the cursor, / searches
+// the keys, n and N move to the next and the previous match, y copies the
+// value under the cursor to the clipboard.
+// When stdout is not a terminal the document is printed as by Render.
+//
+//	err := ejson.NewViewer(body).Run()
+func (v Viewer) Run() error {
+	root, err := parse(v.data)
+	if err != nil {
+		return err
+	}
+	if !eterm.IsTerminal(os.Stdout) {
+		lines := []string{}
+		v.style.render(root, 0, &lines)
+		_, err := fmt.Println(ansi.Strip(strings.Join(lines, "\n")))
+		return err
+	}
+
+	m := viewerModel{
+		viewer: v,
+		root:   root,
+		folded: make(map[*node]bool),
+	}
+	m.refresh()
+	_, err = live.Run(tea.NewProgram(m, tea.WithAltScreen()))
+	return err
+}
+
+// A line of the document shown by the Viewer: a value, or the closing
+// delimiter of an object or an array.
+type viewerLine struct {
+	node    *node
+	depth   int
+	closing bool
+}
+
+// Bubbletea model of a running Viewer.
+type viewerModel struct {
+	viewer  Viewer
+	root    *node
+	folded  map[*node]bool
+	lines   []viewerLine
+	cursor  int
+	offset  int
+	width   int
+	height  int
+	search  search.Search
+	message string
+	// Sequence copying to the clipboard, rendered with the status line
+	// rather than written while the program renders
+	clipboard string
+}
+
+// Rebuild the visible lines, after a value was folded or unfolded.
+func (m *viewerModel) refresh() {
+	current := m.root
+	if m.cursor < len(m.lines) {
+		current = m.lines[m.cursor].node
+	}
+	m.lines = nil
+	m.walk(m.root, 0)
+	m.moveToNode(current)
+}
+
+func (m *viewerModel) walk(n *node, depth int) {
+	m.lines = append(m.lines, viewerLine{node: n, depth: depth})
+	if !n.composite() || len(n.children) == 0 || m.folded[n] {
+		return
+	}
+	for _, child := range n.children {
+		m.walk(child, depth+1)
+	}
+	m.lines = append(m.lines, viewerLine{node: n, depth: depth, closing: true})
+}
+
+// Number of lines of the document shown at once, above the status line.
+func (m viewerModel) page() int {
+	return max(m.height-1, 1)
+}
+
+// Move the cursor to the i-th line, scrolling if needed.
+func (m *viewerModel) moveTo(i int) {
+	m.cursor = min(max(i, 0), len(m.lines)-1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.page() {
+		m.offset = m.cursor - m.page() + 1
+	}
+	m.offset = max(min(m.offset, len(m.lines)-m.page()), 0)
+}
+
+// Move the cursor to the first line of n, if it is visible.
+func (m *viewerModel) moveToNode(n *node) {
+	for i, line := range m.lines {
+		if line.node == n && !line.closing {
+			m.moveTo(i)
+			return
+		}
+	}
+	m.moveTo(m.cursor)
+}
+
+// Reveal n, unfolding its parents, and move the cursor to it.
+func (m *viewerModel) reveal(n *node) {
+	for p := n.parent; p != nil; p = p.parent {
+		delete(m.folded, p)
+	}
+	m.refresh()
+	m.moveToNode(n)
+}
+
+// Returns the values of the document in order.
+func (m viewerModel) nodes() []*node {
+	all := []*node{}
+	var walk func(n *node)
+	walk = func(n *node) {
+		all = append(all, n)
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(m.root)
+	return all
+}
+
+// Reports whether the key of n matches the query of the search.
+func (m viewerModel) matches(n *node) bool {
+	return n.parent != nil && n.parent.kind == kindObject && m.search.Match(n.key)
+}
+
+// Move to the next key matching the query after the cursor, or the previous
+// one when backward is set, wrapping around the document.
+func (m *viewerModel) findNext(backward bool) {
+	if !m.search.Active() {
+		return
+	}
+	all := m.nodes()
+	current := 0
+	for i, n := range all {
+		if n == m.lines[m.cursor].node {
+			current = i
+		}
+	}
+	i, ok := search.Next(len(all), current, backward, func(i int) bool {
+		return m.matches(all[i])
+	})
+	if !ok {
+		m.message = "no key matching " + strconv.Quote(m.search.Query())
+		return
+	}
+	m.reveal(all[i])
+}
+
+func (m viewerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m viewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.moveTo(m.cursor)
+	case tea.KeyMsg:
+		m.message, m.clipboard = "", ""
+		if m.search.Typing() {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			if m.search.Update(msg) {
+				m.findNext(false)
+			}
+			return m, nil
+		}
+		line := m.lines[m.cursor]
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			m.search.Clear()
+		case "/":
+			m.search.Start()
+		case "y":
+			if seq, err := eclip.Sequence(line.node.text()); err != nil {
+				m.message = "copy failed: " + err.Error()
+			} else {
+				m.message, m.clipboard = "copied "+line.node.path(), seq
+			}
+		case "n":
+			m.findNext(false)
+		case "N":
+			m.findNext(true)
+		case "enter", " ":
+			if line.node.composite() && len(line.node.children) > 0 {
+				m.folded[line.node] = !m.folded[line.node]
+				m.refresh()
+			}
+		case "left", "h":
+			if line.node.composite() && len(line.node.children) > 0 && !m.folded[line.node] {
+				m.folded[line.node] = true
+				m.refresh()
+			} else if line.node.parent != nil {
+				m.moveToNode(line.node.parent)
+			}
+		case "right", "l":
+			if m.folded[line.node] {
+				delete(m.folded, line.node)
+				m.refresh()
+			}
+		case "up", "k":
+			m.moveTo(m.cursor - 1)
+		case "down", "j":
+			m.moveTo(m.cursor + 1)
+		case "pgup", "b", "ctrl+b":
+			m.moveTo(m.cursor - m.page())
+		case "pgdown", "f", "ctrl+f":
+			m.moveTo(m.cursor + m.page())
+		case "home", "g":
+			m.moveTo(0)
+		case "end", "G":
+			m.moveTo(len(m.lines) - 1)
+		}
+	}
+	return m, nil
+}
+
+// Render the key of n, highlighting the matches of the query.
+func (m viewerModel) renderKey(n *node) string {
+	s := m.viewer.style
+	if !m.matches(n) {
+		return s.renderKey(n)
+	}
+	key := m.search.Highlight(s.KeyStyle.Render(quote(n.key)), m.viewer.viewerStyle.MatchStyle)
+	return key + s.PunctuationStyle.Render(": ")
+}
+
+// Render the i-th line of the document.
+func (m viewerModel) renderLine(i int) string {
+	s := m.viewer.style
+	line := m.lines[i]
+	n := line.node
+
+	cursor := strings.Repeat(" ", ansi.StringWidth(m.viewer.viewerStyle.Cursor))
+	if i == m.cursor {
+		cursor = m.viewer.viewerStyle.CursorStyle.Render(m.viewer.viewerStyle.Cursor)
+	}
+	prefix := cursor + " " + strings.Repeat(" ", line.depth*max(s.Indent, 0))
+
+	open, close := "", ""
+	if n.composite() {
+		open, close = n.delimiters()
+	}
+	switch {
+	case line.closing:
+		return prefix + s.PunctuationStyle.Render(close) + s.renderComma(n)
+	case !n.composite():
+		return prefix + m.renderKey(n) + s.renderScalar(n) + s.renderComma(n)
+	case len(n.children) == 0:
+		return prefix + m.renderKey(n) + s.PunctuationStyle.Render(open+close) + s.renderComma(n)
+	case m.folded[n]:
+		summary := strconv.Itoa(len(n.children)) + " items"
+		if n.kind == kindObject {
+			summary = strconv.Itoa(len(n.children)) + " keys"
+		}
+		return prefix + m.renderKey(n) + s.PunctuationStyle.Render(open+"…"+close) + s.renderComma(n) + " " + s.SummaryStyle.Render(summary)
+	}
+	return prefix + m.renderKey(n) + s.PunctuationStyle.Render(open)
+}
+
+// Render the status line: the search being typed, or the title, the path
+// of the value under the cursor and the position in the document.
+func (m viewerModel) status() string {
+	style := m.viewer.viewerStyle.StatusStyle
+	if m.search.Typing() {
+		return style.Render(ansi.Truncate(m.search.Prompt(), m.width, "…"))
+	}
+
+	left := m.lines[m.cursor].node.path()
+	if m.viewer.title != "" {
+		left = m.viewer.title + "  " + left
+	}
+	if m.message != "" {
+		left = m.message
+	}
+	right := fmt.Sprintf(" %d/%d · %s ", m.cursor+1, len(m.lines), ekeys.Plain(ekeys.Search, ekeys.Bind("enter", "fold"), ekeys.Bind("y", "copy").WithEnabled(eclip.Supported()), ekeys.Quit))
+	left = ansi.Truncate(" "+left, max(m.width-ansi.StringWidth(right), 0), "…")
+	fill := strings.Repeat(" ", max(m.width-ansi.StringWidth(left)-ansi.StringWidth(right), 0))
+	return style.Render(left + fill + right)
+}
+
+func (m viewerModel) View() string {
+	if m.height == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i := m.offset; i < m.offset+m.page(); i++ {
+		if i < len(m.lines) {
+			b.WriteString(ansi.Truncate(m.renderLine(i), m.width, "…"))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.clipboard + m.status())
+	return b.String()
+}
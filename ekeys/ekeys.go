@@ -0,0 +1,153 @@
+// Package ekeys renders the help line of the key bindings of an
+// interactive view, like "↑/↓ move · enter select · q quit", the same way
+// in every component, and maps the keys moving the cursor of the lists.
+package ekeys
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// A key binding shown in a Help, as its Key followed by its Label. The
+// disabled bindings are not shown, so a Help can list the bindings which
+// depend on the state of the view.
+type Binding struct {
+	Key     string
+	Label   string
+	Enabled bool
+}
+
+// Create an enabled Binding.
+//
+//	b := ekeys.Bind("s", "sort")
+func Bind(key string, label string) Binding {
+	return Binding{Key: key, Label: label, Enabled: true}
+}
+
+// Enable or disable the Binding.
+//
+//	b := ekeys.Bind("esc", "back").WithEnabled(depth > 0)
+func (b Binding) WithEnabled(e bool) Binding {
+	b.Enabled = e
+	return b
+}
+
+// Bindings shared by the components.
+var (
+	Move   = Bind("↑/↓", "move")
+	Search = Bind("/", "search")
+	Quit   = Bind("q", "quit")
+)
+
+// Help style definition.
+type HelpStyle struct {
+	KeyStyle       lipgloss.Style
+	LabelStyle     lipgloss.Style
+	SeparatorStyle lipgloss.Style
+	Separator      string
+	// Rendered in place of the bindings which do not fit the width
+	Ellipsis string
+}
+
+// Default HelpStyle used by New, with the keys in bold and the labels
+// faint.
+var HelpStyleDefault = helpStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		HelpStyleDefault = helpStyle(t)
+	})
+}
+
+// Returns HelpStyleDefault for the theme t.
+func helpStyle(t etheme.Theme) HelpStyle {
+	return HelpStyle{
+		KeyStyle:       lipgloss.NewStyle().Bold(true),
+		LabelStyle:     lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		SeparatorStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		Separator:      " · ",
+		Ellipsis:       "…",
+	}
+}
+
+// HelpStyle without styles, for the help rendered in a status line or
+// with the style of another component.
+var HelpStylePlain = HelpStyle{
+	KeyStyle:       lipgloss.NewStyle(),
+	LabelStyle:     lipgloss.NewStyle(),
+	SeparatorStyle: lipgloss.NewStyle(),
+	Separator:      " · ",
+	Ellipsis:       "…",
+}
+
+// Help is a line of key bindings, rendered at the bottom of an interactive
+// view.
+type Help struct {
+	bindings []Binding
+	style    HelpStyle
+	width    int
+}
+
+// Create a Help of bindings.
+//
+//	help := ekeys.New(ekeys.Move, ekeys.Bind("enter", "select"), ekeys.Quit)
+func New(bindings ...Binding) Help {
+	return Help{
+		bindings: bindings,
+		style:    HelpStyleDefault,
+	}
+}
+
+// Specify the style of the Help.
+//
+//	help := ekeys.New(bindings...).WithStyle(ekeys.HelpStylePlain)
+func (h Help) WithStyle(s HelpStyle) Help {
+	h.style = s
+	return h
+}
+
+// Specify the maximum width of the Help, in cells. The last bindings are
+// replaced by the Ellipsis of the style when they do not fit.
+//
+//	help := ekeys.New(bindings...).WithWidth(m.width)
+func (h Help) WithWidth(w int) Help {
+	h.width = w
+	return h
+}
+
+// Render the enabled bindings of the Help.
+//
+//	fmt.Println(ekeys.New(ekeys.Move, ekeys.Quit).Render())
+func (h Help) Render() string {
+	s := h.style
+	parts := make([]string, 0, len(h.bindings))
+	for _, b := range h.bindings {
+		if b.Enabled {
+			parts = append(parts, s.KeyStyle.Render(b.Key)+" "+s.LabelStyle.Render(b.Label))
+		}
+	}
+
+	separator := s.SeparatorStyle.Render(s.Separator)
+	line := strings.Join(parts, separator)
+	if h.width <= 0 || ansi.StringWidth(line) <= h.width {
+		return line
+	}
+	ellipsis := s.SeparatorStyle.Render(s.Ellipsis)
+	for n := len(parts) - 1; n > 0; n-- {
+		line = strings.Join(parts[:n], separator) + separator + ellipsis
+		if ansi.StringWidth(line) <= h.width {
+			return line
+		}
+	}
+	return ansi.Truncate(strings.Join(parts, separator), h.width, s.Ellipsis)
+}
+
+// Render bindings with HelpStylePlain, to embed them in a status line.
+//
+//	right := " " + ekeys.Plain(ekeys.Search, ekeys.Quit) + " "
+func Plain(bindings ...Binding) string {
+	return New(bindings...).WithStyle(HelpStylePlain).Render()
+}
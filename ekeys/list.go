@@ -0,0 +1,60 @@
+package ekeys
+
+// Movement of the cursor of a list, triggered by a key.
+type ListAction int
+
+const (
+	ListNone ListAction = iota
+	ListUp
+	ListDown
+	ListPageUp
+	ListPageDown
+	ListHome
+	ListEnd
+)
+
+// Returns the movement of the cursor of a list for key, a tea.KeyMsg as a
+// string, so the lists of every component move with the same keys. When
+// typing is set the keys edited by a text input, like the letters and
+// ctrl+b, are left to it, for the lists filtered by typing.
+//
+//	switch ekeys.ListKey(msg.String(), false) {
+//	case ekeys.ListUp:
+//		m.cursor--
+//	case ekeys.ListDown:
+//		m.cursor++
+//	}
+func ListKey(key string, typing bool) ListAction {
+	switch key {
+	case "up", "shift+tab", "ctrl+p":
+		return ListUp
+	case "down", "tab", "ctrl+n":
+		return ListDown
+	case "pgup":
+		return ListPageUp
+	case "pgdown":
+		return ListPageDown
+	case "home":
+		return ListHome
+	case "end":
+		return ListEnd
+	}
+	if typing {
+		return ListNone
+	}
+	switch key {
+	case "k":
+		return ListUp
+	case "j":
+		return ListDown
+	case "ctrl+b":
+		return ListPageUp
+	case "ctrl+f":
+		return ListPageDown
+	case "g":
+		return ListHome
+	case "G":
+		return ListEnd
+	}
+	return ListNone
+}
@@ -0,0 +1,202 @@
+// Package ekv renders the details of a single resource as a card of labeled
+// fields, the "describe" view complementing the list view of etable.
+package ekv
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/elist"
+	"github.com/ravvio/easycli-ui/epanel"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Card style definition. The fields are split in up to Columns columns,
+// separated by Gap cells, when there are at least MinColumnFields of them
+// and they fit the width of the card. EmptyValue replaces the empty values.
+type CardStyle struct {
+	BoxStyle         epanel.BoxStyle
+	DefinitionsStyle elist.DefinitionsStyle
+	SectionStyle     lipgloss.Style
+	EmptyValue       string
+	Columns          int
+	MinColumnFields  int
+	Gap              int
+	// Number of spaces before the fields of a section
+	Indent int
+}
+
+// Default CardStyle used by Card, a rounded box with the labels and the
+// section titles in the primary color of the etheme.Theme.
+var CardStyleDefault = cardStyle(etheme.Current())
+
+func init() {
+	// The hooks of epanel and elist run first, as they are registered when
+	// their packages are initialized.
+	etheme.OnChange(func(t etheme.Theme) {
+		CardStyleDefault = cardStyle(t)
+	})
+}
+
+// Returns CardStyleDefault for the theme t.
+func cardStyle(t etheme.Theme) CardStyle {
+	return CardStyle{
+		BoxStyle:         epanel.BoxStyleDefault,
+		DefinitionsStyle: elist.DefinitionsStyleDefault,
+		SectionStyle:     lipgloss.NewStyle().Foreground(t.Primary).Underline(true),
+		EmptyValue:       "-",
+		Columns:          2,
+		MinColumnFields:  8,
+		Gap:              4,
+		Indent:           2,
+	}
+}
+
+// CardStyle without colors nor unicode characters, for terminals and logs
+// with limited support.
+var CardStyleASCII = CardStyle{
+	BoxStyle: epanel.BoxStyleASCII,
+	DefinitionsStyle: elist.DefinitionsStyle{
+		KeyStyle:   lipgloss.NewStyle(),
+		ValueStyle: lipgloss.NewStyle(),
+		Separator:  ":",
+	},
+	SectionStyle:    lipgloss.NewStyle(),
+	EmptyValue:      "-",
+	Columns:         2,
+	MinColumnFields: 8,
+	Gap:             4,
+	Indent:          2,
+}
+
+// A labeled field of a card. A field with Fields is a section: its label is
+// rendered as a heading above its fields, and its Value is ignored.
+type Field struct {
+	Label  string
+	Value  string
+	Fields []Field
+}
+
+// DetailCard renders the fields of a resource in a box, with the values
+// aligned after the labels.
+type DetailCard struct {
+	title  string
+	fields []Field
+	style  CardStyle
+	width  int
+}
+
+// Create a card of fields, rendered in order under title. The consecutive
+// fields outside of sections are split in columns when there are many.
+//
+//	c := ekv.Card("pod/api-7d9f", []ekv.Field{
+//		{Label: "Namespace", Value: "default"},
+//		{Label: "Status", Value: "Running"},
+//		{Label: "Container", Fields: []ekv.Field{
+//			{Label: "Image", Value: "api:1.4.2"},
+//			{Label: "Restarts", Value: "0"},
+//		}},
+//	})
+//	fmt.Println(c.Render())
+func Card(title string, fields []Field) DetailCard {
+	return DetailCard{
+		title:  title,
+		fields: fields,
+		style:  CardStyleDefault,
+	}
+}
+
+// Specify the style of the card.
+//
+//	c := ekv.Card(title, fields).WithStyle(ekv.CardStyleASCII)
+func (c DetailCard) WithStyle(s CardStyle) DetailCard {
+	c.style = s
+	return c
+}
+
+// Specify the width of the card in cells, including its border. When 0 the
+// card fits its fields, up to the width of the terminal.
+//
+//	c := ekv.Card(title, fields).WithWidth(60)
+func (c DetailCard) WithWidth(w int) DetailCard {
+	c.width = max(w, 0)
+	return c
+}
+
+// Render the card.
+//
+//	fmt.Println(ekv.Card(title, fields).Render())
+func (c DetailCard) Render() string {
+	box := c.style.BoxStyle
+	box.Width = c.width
+	maxWidth := c.width
+	if maxWidth <= 0 {
+		maxWidth = eterm.Width()
+	}
+	// Cells between the padding of the box, see epanel.Box
+	inner := max(maxWidth-2-2*max(box.Padding, 0), 1)
+	return epanel.Box(c.title, c.renderFields(c.fields, inner), box)
+}
+
+// Render fields in width cells, the consecutive plain fields as a block and
+// each section as a heading followed by its fields indented.
+func (c DetailCard) renderFields(fields []Field, width int) string {
+	blocks := []string{}
+	plain := []Field{}
+	flush := func() {
+		if len(plain) > 0 {
+			blocks = append(blocks, c.renderPlain(plain, width))
+			plain = nil
+		}
+	}
+	for _, f := range fields {
+		if len(f.Fields) == 0 {
+			plain = append(plain, f)
+			continue
+		}
+		flush()
+		indent := max(c.style.Indent, 0)
+		section := c.renderFields(f.Fields, max(width-indent, 1))
+		lines := strings.Split(section, "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = strings.Repeat(" ", indent) + line
+			}
+		}
+		blocks = append(blocks, c.style.SectionStyle.Render(f.Label)+"\n"+strings.Join(lines, "\n"))
+	}
+	flush()
+	return strings.Join(blocks, "\n\n")
+}
+
+// Render plain fields with their values aligned, split in as many columns
+// as fit width.
+func (c DetailCard) renderPlain(fields []Field, width int) string {
+	pairs := make([]elist.Definition, len(fields))
+	for i, f := range fields {
+		value := f.Value
+		if value == "" {
+			value = c.style.EmptyValue
+		}
+		pairs[i] = elist.Definition{Key: f.Label, Value: value}
+	}
+
+	if len(pairs) >= max(c.style.MinColumnFields, 1) {
+		gap := max(c.style.Gap, 0)
+		for n := c.style.Columns; n > 1; n-- {
+			blocks := make([]string, 0, n)
+			total := gap * (n - 1)
+			size := (len(pairs) + n - 1) / n
+			for start := 0; start < len(pairs); start += size {
+				block := elist.Definitions(pairs[start:min(start+size, len(pairs))]).WithStyle(c.style.DefinitionsStyle).Render()
+				blocks = append(blocks, block)
+				total += lipgloss.Width(block)
+			}
+			if total <= width {
+				return epanel.Columns(blocks...).WithGap(gap).Render()
+			}
+		}
+	}
+	return elist.Definitions(pairs).WithStyle(c.style.DefinitionsStyle).WithWidth(width).Render()
+}
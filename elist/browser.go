@@ -0,0 +1,369 @@
+package elist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// The bubbletea.Msg sent when the children of a Node have been loaded
+type browserMsgLoaded struct {
+	path     string
+	children []Node
+	err      error
+}
+
+// TreeBrowser lets the user browse a tree of Nodes interactively, expanding
+// and collapsing its branches, and select one of its Nodes.
+type TreeBrowser struct {
+	root   Node
+	title  string
+	style  TreeStyle
+	height int
+}
+
+// Create a new TreeBrowser of the tree starting at root.
+//
+//	node, err := elist.NewTreeBrowser(root).WithTitle("Pick a resource").Run()
+func NewTreeBrowser(root Node) TreeBrowser {
+	return TreeBrowser{
+		root:   root,
+		style:  TreeStyleDefault,
+		height: 15,
+	}
+}
+
+// Specify a title rendered above the tree.
+//
+//	b := elist.NewTreeBrowser(root).WithTitle("Pick a resource")
+func (b TreeBrowser) WithTitle(title string) TreeBrowser {
+	b.title = title
+	return b
+}
+
+// Specify the style of the TreeBrowser.
+//
+//	b := elist.NewTreeBrowser(root).WithStyle(elist.TreeStyleDefault)
+func (b TreeBrowser) WithStyle(s TreeStyle) TreeBrowser {
+	b.style = s
+	return b
+}
+
+// Specify the maximum number of rows of the tree rendered at once, the
+// TreeBrowser scrolls to follow the cursor.
+//
+//	b := elist.NewTreeBrowser(root).WithHeight(30)
+func (b TreeBrowser) WithHeight(h int) TreeBrowser {
+	b.height = max(h, 1)
+	return b
+}
+
+// Run the TreeBrowser until the user selects a Node, which is returned.
+// Enter expands or collapses the Node under the cursor, or selects it when
+// it has no children; Space selects any Node. The children of the Nodes with
+// a loader are loaded the first time they are expanded.
+// On Esc or Ctrl+C ErrInterrupted is returned.
+//
+//	node, err := elist.NewTreeBrowser(root).Run()
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(node.Label())
+func (b TreeBrowser) Run() (Node, error) {
+	m := browserModel{
+		browser:  b,
+		expanded: map[string]bool{"": true},
+		loaded:   make(map[string][]Node),
+		loading:  make(map[string]bool),
+		errs:     make(map[string]error),
+	}
+	m.refresh()
+
+	final, err := live.Run(tea.NewProgram(m))
+	if err != nil {
+		return Node{}, err
+	}
+	m = final.(browserModel)
+	if m.aborted {
+		return Node{}, ErrInterrupted
+	}
+	return m.selected, nil
+}
+
+// A row rendered by the TreeBrowser: a Node, or the loading state or the
+// loading error of its parent.
+type browserRow struct {
+	path   string
+	node   Node
+	prefix string
+	note   string
+	err    bool
+}
+
+// Bubbletea model of a running TreeBrowser. Nodes are identified by their
+// path, the indexes of the Nodes leading to them joined by dots.
+type browserModel struct {
+	browser  TreeBrowser
+	expanded map[string]bool
+	loaded   map[string][]Node
+	loading  map[string]bool
+	errs     map[string]error
+	rows     []browserRow
+	cursor   int
+	offset   int
+	selected Node
+	done     bool
+	aborted  bool
+}
+
+// Returns the path of the i-th child of the Node at path.
+func childPath(path string, i int) string {
+	if path == "" {
+		return strconv.Itoa(i)
+	}
+	return path + "." + strconv.Itoa(i)
+}
+
+// Returns the path of the parent of the Node at path.
+func parentPath(path string) string {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+// Returns the children of the Node at path, loaded or not.
+func (m browserModel) children(path string, n Node) []Node {
+	if n.loader != nil {
+		return m.loaded[path]
+	}
+	return n.children
+}
+
+// Reports whether the Node can be expanded.
+func expandable(n Node) bool {
+	return len(n.children) > 0 || n.loader != nil
+}
+
+// Rebuild the visible rows, after a Node was expanded, collapsed or loaded.
+func (m *browserModel) refresh() {
+	m.rows = []browserRow{{path: "", node: m.browser.root}}
+	m.walk("", m.browser.root, "")
+	m.moveTo(m.cursor)
+}
+
+func (m *browserModel) walk(path string, n Node, prefix string) {
+	if !m.expanded[path] {
+		return
+	}
+	last, _ := m.browser.style.branch(true)
+	if m.loading[path] {
+		m.rows = append(m.rows, browserRow{path: path, prefix: prefix + last, note: "loading..."})
+		return
+	}
+	if err := m.errs[path]; err != nil {
+		m.rows = append(m.rows, browserRow{path: path, prefix: prefix + last, note: err.Error(), err: true})
+		return
+	}
+
+	children := m.children(path, n)
+	for i, child := range children {
+		branch, indent := m.browser.style.branch(i == len(children)-1)
+		p := childPath(path, i)
+		m.rows = append(m.rows, browserRow{path: p, node: child, prefix: prefix + branch})
+		m.walk(p, child, prefix+indent)
+	}
+}
+
+// Move the cursor to the i-th row, scrolling if needed.
+func (m *browserModel) moveTo(i int) {
+	m.cursor = min(max(i, 0), len(m.rows)-1)
+	height := m.browser.height
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = max(min(m.offset, len(m.rows)-height), 0)
+}
+
+// Move the cursor to the row of the Node at path, if it is visible.
+func (m *browserModel) moveToPath(path string) {
+	for i, row := range m.rows {
+		if row.path == path && row.note == "" {
+			m.moveTo(i)
+			return
+		}
+	}
+}
+
+// Expand the Node under the cursor, loading its children if needed.
+func (m *browserModel) expand(row browserRow) tea.Cmd {
+	m.expanded[row.path] = true
+	var cmd tea.Cmd
+	if _, ok := m.loaded[row.path]; row.node.loader != nil && !ok && !m.loading[row.path] {
+		delete(m.errs, row.path)
+		m.loading[row.path] = true
+		path, load := row.path, row.node.loader
+		cmd = func() tea.Msg {
+			children, err := load()
+			return browserMsgLoaded{path: path, children: children, err: err}
+		}
+	}
+	m.refresh()
+	return cmd
+}
+
+func (m *browserModel) collapse(row browserRow) {
+	m.expanded[row.path] = false
+	m.refresh()
+}
+
+func (m browserModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m browserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case browserMsgLoaded:
+		delete(m.loading, msg.path)
+		if msg.err != nil {
+			m.errs[msg.path] = msg.err
+		} else {
+			m.loaded[msg.path] = msg.children
+		}
+		m.refresh()
+	case tea.KeyMsg:
+		row := m.rows[m.cursor]
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case "enter":
+			switch {
+			case row.note != "":
+				// Retry a failed load from its row
+				if row.err {
+					m.expanded[row.path] = false
+					m.moveToPath(row.path)
+					return m, m.expand(m.rows[m.cursor])
+				}
+			case !expandable(row.node):
+				m.selected = row.node
+				m.done = true
+				return m, tea.Quit
+			case m.expanded[row.path]:
+				m.collapse(row)
+			default:
+				return m, m.expand(row)
+			}
+		case " ":
+			if row.note == "" {
+				m.selected = row.node
+				m.done = true
+				return m, tea.Quit
+			}
+		case "right", "l":
+			switch {
+			case row.note != "" || !expandable(row.node):
+			case !m.expanded[row.path]:
+				return m, m.expand(row)
+			default:
+				m.moveTo(m.cursor + 1)
+			}
+		case "left", "h":
+			if row.note == "" && expandable(row.node) && m.expanded[row.path] {
+				m.collapse(row)
+			} else if row.path != "" {
+				if row.note == "" {
+					m.moveToPath(parentPath(row.path))
+				} else {
+					m.moveToPath(row.path)
+				}
+			}
+		case "up", "k":
+			m.moveTo(m.cursor - 1)
+		case "down", "j":
+			m.moveTo(m.cursor + 1)
+		case "pgup", "ctrl+b":
+			m.moveTo(m.cursor - m.browser.height)
+		case "pgdown", "ctrl+f":
+			m.moveTo(m.cursor + m.browser.height)
+		case "home", "g":
+			m.moveTo(0)
+		case "end", "G":
+			m.moveTo(len(m.rows) - 1)
+		}
+	}
+	return m, nil
+}
+
+// Render a row of the tree, with the marker of the expandable Nodes.
+func (m browserModel) renderRow(i int) string {
+	style := m.browser.style
+	row := m.rows[i]
+	prefix := style.BranchStyle.Render(row.prefix)
+	if row.note != "" {
+		if row.err {
+			return prefix + style.ValueStyle.Render("error: "+row.note)
+		}
+		return prefix + style.ValueStyle.Render(row.note)
+	}
+
+	marker := "  "
+	if expandable(row.node) {
+		marker = "▸ "
+		if m.expanded[row.path] {
+			marker = "▾ "
+		}
+	}
+
+	depth := 0
+	if row.path != "" {
+		depth = strings.Count(row.path, ".") + 1
+	}
+	base := style.nodeStyle(depth)
+	if row.node.style != nil {
+		base = *row.node.style
+	}
+	if i == m.cursor {
+		base = style.SelectedStyle
+	}
+
+	// Only the first line of the label is rendered in the browser
+	label, _, _ := strings.Cut(row.node.label, "\n")
+	return prefix + style.BranchStyle.Render(marker) + base.Render(label) + row.node.renderValue(style)
+}
+
+func (m browserModel) View() string {
+	style := m.browser.style
+	var b strings.Builder
+	if m.browser.title != "" {
+		b.WriteString(style.RootStyle.Render(m.browser.title))
+		if m.done {
+			b.WriteString(" " + m.selected.label)
+		}
+		b.WriteString("\n")
+	}
+	if m.done || m.aborted {
+		return b.String()
+	}
+
+	end := min(m.offset+m.browser.height, len(m.rows))
+	for i := m.offset; i < end; i++ {
+		b.WriteString(m.renderRow(i) + "\n")
+	}
+
+	help := ekeys.Plain(ekeys.Move, ekeys.Bind("enter", "expand/select"), ekeys.Bind("space", "select"), ekeys.Bind("esc", "cancel"))
+	if len(m.rows) > m.browser.height {
+		help = fmt.Sprintf("%d/%d · %s", m.cursor+1, len(m.rows), help)
+	}
+	b.WriteString(style.BranchStyle.Render(help) + "\n")
+	return b.String()
+}
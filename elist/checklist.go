@@ -0,0 +1,346 @@
+package elist
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// ErrInterrupted is returned when a live Checklist or a TreeBrowser is
+// stopped by the user.
+var ErrInterrupted = eterm.ErrInterrupted
+
+// Interval between two refreshes of a live Checklist.
+const checklistRefresh = 100 * time.Millisecond
+
+// The bubbletea.Msg sent to refresh a live Checklist
+type checklistMsgTick struct{}
+
+// The bubbletea.Msg sent when the task of a live Checklist returns
+type checklistMsgStop struct {
+	err error
+}
+
+// State of an item of a Checklist.
+type CheckState int
+
+const (
+	CheckPending CheckState = iota
+	CheckInProgress
+	CheckDone
+	CheckFailed
+	CheckSkipped
+)
+
+func (s CheckState) String() string {
+	switch s {
+	case CheckPending:
+		return "pending"
+	case CheckInProgress:
+		return "in progress"
+	case CheckDone:
+		return "done"
+	case CheckFailed:
+		return "failed"
+	case CheckSkipped:
+		return "skipped"
+	}
+	return fmt.Sprintf("CheckState(%d)", int(s))
+}
+
+// Checklist style definition.
+type ChecklistStyle struct {
+	PendingStyle    lipgloss.Style
+	InProgressStyle lipgloss.Style
+	DoneStyle       lipgloss.Style
+	FailedStyle     lipgloss.Style
+	SkippedStyle    lipgloss.Style
+	NoteStyle       lipgloss.Style
+
+	PendingGlyph    string
+	InProgressGlyph string
+	DoneGlyph       string
+	FailedGlyph     string
+	SkippedGlyph    string
+}
+
+// Default ChecklistStyle used by NewChecklist.
+var ChecklistStyleDefault = checklistStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		ChecklistStyleDefault = checklistStyle(t)
+	})
+}
+
+// Returns ChecklistStyleDefault for the theme t.
+func checklistStyle(t etheme.Theme) ChecklistStyle {
+	return ChecklistStyle{
+		PendingStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		InProgressStyle: lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		DoneStyle:       lipgloss.NewStyle().Foreground(t.Success),
+		FailedStyle:     lipgloss.NewStyle().Foreground(t.Error).Bold(true),
+		SkippedStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		NoteStyle:       lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+
+		PendingGlyph:    "○",
+		InProgressGlyph: "◐",
+		DoneGlyph:       "✓",
+		FailedGlyph:     "✗",
+		SkippedGlyph:    "–",
+	}
+}
+
+// Returns the style and the glyph of the given state.
+func (s ChecklistStyle) state(state CheckState) (lipgloss.Style, string) {
+	switch state {
+	case CheckInProgress:
+		return s.InProgressStyle, s.InProgressGlyph
+	case CheckDone:
+		return s.DoneStyle, s.DoneGlyph
+	case CheckFailed:
+		return s.FailedStyle, s.FailedGlyph
+	case CheckSkipped:
+		return s.SkippedStyle, s.SkippedGlyph
+	}
+	return s.PendingStyle, s.PendingGlyph
+}
+
+// An item of a Checklist. Note is rendered after the label, like the
+// reason an item was skipped or the error that made it fail.
+type ChecklistItem struct {
+	Label string
+	State CheckState
+	Note  string
+}
+
+// A rapresentation of a Checklist, rendered once with Render or updated live
+// by a task with Run.
+type Checklist struct {
+	items []ChecklistItem
+	style ChecklistStyle
+}
+
+// Create a new empty Checklist.
+//
+//	c := elist.NewChecklist().
+//		WithItem("Fetch sources", elist.CheckDone).
+//		WithItem("Build", elist.CheckFailed).
+//		WithItem("Deploy", elist.CheckPending)
+//	fmt.Println(c.Render())
+func NewChecklist() Checklist {
+	return Checklist{
+		style: ChecklistStyleDefault,
+	}
+}
+
+// Adds an item to the Checklist in the given state.
+//
+//	c := elist.NewChecklist().WithItem("Build", elist.CheckPending)
+func (c Checklist) WithItem(label string, state CheckState) Checklist {
+	return c.WithItems(ChecklistItem{Label: label, State: state})
+}
+
+// Adds several items to the Checklist.
+//
+//	c := elist.NewChecklist().WithItems(
+//		elist.ChecklistItem{Label: "Lint", State: elist.CheckSkipped, Note: "no changes"},
+//	)
+func (c Checklist) WithItems(items ...ChecklistItem) Checklist {
+	c.items = append(append(make([]ChecklistItem, 0, len(c.items)+len(items)), c.items...), items...)
+	return c
+}
+
+// Specify the style of the Checklist.
+//
+//	c := elist.NewChecklist().WithStyle(elist.ChecklistStyleDefault)
+func (c Checklist) WithStyle(s ChecklistStyle) Checklist {
+	c.style = s
+	return c
+}
+
+// Render a single item of the Checklist.
+func (c Checklist) renderItem(item ChecklistItem) string {
+	style, glyph := c.style.state(item.State)
+	s := style.Render(glyph) + " " + style.Render(item.Label)
+	if item.Note != "" {
+		s += "  " + c.style.NoteStyle.Render(item.Note)
+	}
+	return s
+}
+
+// Render the Checklist.
+//
+//	c := elist.NewChecklist().WithItem(...)
+//	fmt.Println(c.Render())
+func (c Checklist) Render() string {
+	lines := make([]string, len(c.items))
+	for i, item := range c.items {
+		lines[i] = c.renderItem(item)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ChecklistHandle lets the task of a live Checklist update its items.
+type ChecklistHandle struct {
+	mu    sync.Mutex
+	items []ChecklistItem
+}
+
+// Set the state of the i-th item, starting from 0, clearing its note.
+func (h *ChecklistHandle) Set(i int, state CheckState) {
+	h.update(i, state, "")
+}
+
+// Mark the i-th item as failed because of err.
+func (h *ChecklistHandle) Fail(i int, err error) {
+	note := ""
+	if err != nil {
+		note = err.Error()
+	}
+	h.update(i, CheckFailed, note)
+}
+
+// Mark the i-th item as skipped, for the given reason.
+func (h *ChecklistHandle) Skip(i int, reason string) {
+	h.update(i, CheckSkipped, reason)
+}
+
+func (h *ChecklistHandle) update(i int, state CheckState, note string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if i < 0 || i >= len(h.items) {
+		return
+	}
+	h.items[i].State = state
+	h.items[i].Note = note
+}
+
+// Returns a copy of the items.
+func (h *ChecklistHandle) snapshot() []ChecklistItem {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]ChecklistItem(nil), h.items...)
+}
+
+// Run task, which updates the items of the Checklist through the
+// ChecklistHandle, redrawing the Checklist until it returns.
+// When the output is not a terminal a line is printed every time an item
+// changes state. On Ctrl+C the display is stopped and ErrInterrupted is
+// returned.
+//
+//	err := elist.NewChecklist().WithItem("Build", elist.CheckPending).Run(func(h *elist.ChecklistHandle) error {
+//		h.Set(0, elist.CheckInProgress)
+//		if err := build(); err != nil {
+//			h.Fail(0, err)
+//			return err
+//		}
+//		h.Set(0, elist.CheckDone)
+//		return nil
+//	})
+func (c Checklist) Run(task func(h *ChecklistHandle) error) error {
+	h := &ChecklistHandle{items: append([]ChecklistItem(nil), c.items...)}
+	if !eterm.IsTerminal(os.Stdout) {
+		return c.runPlain(h, task)
+	}
+
+	m := checklistModel{checklist: c, handle: h, task: task}
+	final, err := live.Run(tea.NewProgram(m))
+	if err != nil {
+		return err
+	}
+	return final.(checklistModel).err
+}
+
+// Run the Checklist printing a plain line every time an item changes state,
+// for outputs that are not a terminal.
+func (c Checklist) runPlain(h *ChecklistHandle, task func(h *ChecklistHandle) error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- task(h)
+	}()
+
+	ticker := time.NewTicker(checklistRefresh)
+	defer ticker.Stop()
+
+	printed := make([]ChecklistItem, len(c.items))
+	for i := range printed {
+		printed[i].State = -1
+	}
+	flush := func() {
+		for i, item := range h.snapshot() {
+			if item != printed[i] && item.State != CheckPending {
+				fmt.Println(c.renderItem(item))
+				printed[i] = item
+			}
+		}
+	}
+	for {
+		select {
+		case err := <-done:
+			flush()
+			return err
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// Bubbletea model of a live Checklist.
+type checklistModel struct {
+	checklist Checklist
+	handle    *ChecklistHandle
+	task      func(h *ChecklistHandle) error
+	finished  bool
+	err       error
+}
+
+func checklistTick() tea.Cmd {
+	return tea.Tick(checklistRefresh, func(time.Time) tea.Msg {
+		return checklistMsgTick{}
+	})
+}
+
+func (m checklistModel) Init() tea.Cmd {
+	return tea.Batch(
+		checklistTick(),
+		func() tea.Msg {
+			return checklistMsgStop{err: m.task(m.handle)}
+		},
+	)
+}
+
+func (m checklistModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.finished = true
+			m.err = ErrInterrupted
+			return m, tea.Quit
+		}
+	case checklistMsgTick:
+		if m.finished {
+			return m, nil
+		}
+		m.checklist.items = m.handle.snapshot()
+		return m, checklistTick()
+	case checklistMsgStop:
+		m.checklist.items = m.handle.snapshot()
+		m.finished = true
+		m.err = msg.err
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m checklistModel) View() string {
+	return m.checklist.Render() + "\n"
+}
@@ -0,0 +1,139 @@
+package elist
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/etext"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Definitions style definition.
+type DefinitionsStyle struct {
+	KeyStyle   lipgloss.Style
+	ValueStyle lipgloss.Style
+	// Rendered right after each key
+	Separator string
+}
+
+// Default DefinitionsStyle used by Definitions. Uses the primary color
+// of the etheme.Theme for the keys.
+var DefinitionsStyleDefault = definitionsStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		DefinitionsStyleDefault = definitionsStyle(t)
+	})
+}
+
+// Returns DefinitionsStyleDefault for the theme t.
+func definitionsStyle(t etheme.Theme) DefinitionsStyle {
+	return DefinitionsStyle{
+		KeyStyle:   lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		ValueStyle: lipgloss.NewStyle(),
+		Separator:  ":",
+	}
+}
+
+// A key and its value in a DefinitionList.
+type Definition struct {
+	Key   string
+	Value string
+}
+
+// DefinitionList renders key-value pairs with the values aligned on the
+// same column, like the "describe" output of many CLIs.
+type DefinitionList struct {
+	pairs     []Definition
+	style     DefinitionsStyle
+	width     int
+	styleFunc func(style lipgloss.Style, key string) lipgloss.Style
+}
+
+// Create a new DefinitionList of the given pairs, rendered in order.
+//
+//	d := elist.Definitions([]elist.Definition{
+//		{Key: "Name", Value: "api-server"},
+//		{Key: "Namespace", Value: "default"},
+//		{Key: "Status", Value: "Running"},
+//	})
+//	fmt.Println(d.Render())
+func Definitions(pairs []Definition) DefinitionList {
+	return DefinitionList{
+		pairs: pairs,
+		style: DefinitionsStyleDefault,
+		styleFunc: func(style lipgloss.Style, key string) lipgloss.Style {
+			return style
+		},
+	}
+}
+
+// Specify the style of the DefinitionList.
+//
+//	d := elist.Definitions(pairs).WithStyle(elist.DefinitionsStyleDefault)
+func (d DefinitionList) WithStyle(s DefinitionsStyle) DefinitionList {
+	d.style = s
+	return d
+}
+
+// Specify the width of the DefinitionList in cells, after which the values
+// are wrapped on the column of the values. A width of 0 or less disables
+// wrapping.
+//
+//	d := elist.Definitions(pairs).WithWidth(80)
+func (d DefinitionList) WithWidth(w int) DefinitionList {
+	d.width = w
+	return d
+}
+
+// Specify a function returning the style of each key, given the KeyStyle of
+// the DefinitionsStyle.
+//
+//	d := elist.Definitions(pairs).WithKeyStyleFunc(func(style lipgloss.Style, key string) lipgloss.Style {
+//		if key == "Status" {
+//			return style.Foreground(lipgloss.Color("2"))
+//		}
+//		return style
+//	})
+func (d DefinitionList) WithKeyStyleFunc(
+	styleFunc func(style lipgloss.Style, key string) lipgloss.Style,
+) DefinitionList {
+	d.styleFunc = styleFunc
+	return d
+}
+
+// Render the DefinitionList.
+//
+//	d := elist.Definitions(pairs)
+//	fmt.Println(d.Render())
+func (d DefinitionList) Render() string {
+	keyWidth := 0
+	for _, p := range d.pairs {
+		keyWidth = max(keyWidth, ansi.StringWidth(p.Key+d.style.Separator))
+	}
+	indent := strings.Repeat(" ", keyWidth+1)
+
+	lines := []string{}
+	for _, p := range d.pairs {
+		key := p.Key + d.style.Separator
+		pad := strings.Repeat(" ", keyWidth-ansi.StringWidth(key)+1)
+
+		value := p.Value
+		if d.width > 0 {
+			value = etext.Wrap(value, d.width-keyWidth-1)
+		}
+		for i, line := range strings.Split(value, "\n") {
+			prefix := indent
+			if i == 0 {
+				prefix = d.styleFunc(d.style.KeyStyle, p.Key).Render(key) + pad
+			}
+			if line == "" {
+				lines = append(lines, strings.TrimRight(prefix, " "))
+				continue
+			}
+			lines = append(lines, prefix+d.style.ValueStyle.Render(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
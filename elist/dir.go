@@ -0,0 +1,125 @@
+package elist
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/units"
+)
+
+// Style of the directories in the trees built by TreeFromDir.
+var dirStyle = lipgloss.NewStyle().Foreground(etheme.Current().Primary).Bold(true)
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		dirStyle = lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+	})
+}
+
+// Options of TreeFromDir.
+type DirOptions struct {
+	// Maximum depth of the entries included, 0 means no limit
+	MaxDepth int
+	// Glob patterns, see filepath.Match, of the names of the entries to
+	// exclude
+	Ignore []string
+	// Include the entries whose name starts with a dot
+	Hidden bool
+	// Annotate the files with their size
+	Sizes bool
+}
+
+// Returns the tree of the entries of the directory at path, like the tree
+// command, to be rendered with Tree or browsed with a TreeBrowser.
+// Directories are listed before files, both sorted by name, and symbolic
+// links are annotated with their target instead of being followed.
+// Directories that cannot be read are annotated with the error.
+//
+//	root, err := elist.TreeFromDir(".", elist.DirOptions{
+//		MaxDepth: 2,
+//		Ignore:   []string{"node_modules", "*.log"},
+//		Sizes:    true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(elist.Tree(root).Render())
+func TreeFromDir(path string, opts DirOptions) (Node, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Node{}, err
+	}
+	root := NewNode(path)
+	if !info.IsDir() {
+		return opts.file(root, info), nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return Node{}, err
+	}
+	root.children = opts.children(path, entries, 1)
+	return root, nil
+}
+
+// Reports whether the entry called name is excluded by the options.
+func (o DirOptions) ignored(name string) bool {
+	if !o.Hidden && strings.HasPrefix(name, ".") {
+		return true
+	}
+	for _, pattern := range o.Ignore {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Annotate the Node of a file with its size, if requested.
+func (o DirOptions) file(n Node, info os.FileInfo) Node {
+	if o.Sizes {
+		n.value = units.Bytes(info.Size())
+	}
+	return n
+}
+
+// Returns the Nodes of the entries of the directory at path, found at the
+// given depth.
+func (o DirOptions) children(path string, entries []os.DirEntry, depth int) []Node {
+	dirs, files := []Node{}, []Node{}
+	for _, e := range entries {
+		if o.ignored(e.Name()) {
+			continue
+		}
+		p := filepath.Join(path, e.Name())
+
+		switch {
+		case e.Type()&os.ModeSymlink != 0:
+			n := NewNode(e.Name())
+			if target, err := os.Readlink(p); err == nil {
+				n.value = "→ " + target
+			}
+			files = append(files, n)
+		case e.IsDir():
+			n := NewNode(e.Name() + "/").WithStyle(dirStyle)
+			if o.MaxDepth <= 0 || depth < o.MaxDepth {
+				sub, err := os.ReadDir(p)
+				if err != nil {
+					n.value = err.Error()
+				}
+				n.children = o.children(p, sub, depth+1)
+			}
+			dirs = append(dirs, n)
+		default:
+			n := NewNode(e.Name())
+			if info, err := e.Info(); err == nil {
+				n = o.file(n, info)
+			}
+			files = append(files, n)
+		}
+	}
+	return append(dirs, files...)
+}
@@ -0,0 +1,249 @@
+package elist
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etext"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Kind of enumeration of a List.
+//
+//	l := elist.NewList(elist.ListNumbered)
+type ListKind int
+
+const (
+	ListBulleted ListKind = iota
+	ListNumbered
+	ListLettered
+)
+
+// List style definition.
+type ListStyle struct {
+	EnumeratorStyle lipgloss.Style
+	ItemStyle       lipgloss.Style
+	// Styles of the enumerators by nesting depth, replacing EnumeratorStyle.
+	// The last one is used for deeper lists.
+	DepthStyles []lipgloss.Style
+	// Bullets of the bulleted lists, by nesting depth. The last one is
+	// used for deeper lists.
+	Bullets []string
+	// Cells by which nested lists are indented from the enumerator of their
+	// parent item. With 0 they are aligned with the text of the item.
+	Indent int
+}
+
+// Default ListStyle used by NewList. Uses the primary color of the
+// etheme.Theme for the enumerators.
+var ListStyleDefault = listStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		ListStyleDefault = listStyle(t)
+	})
+}
+
+// Returns ListStyleDefault for the theme t, ListStyleASCII when the terminal
+// cannot display unicode.
+func listStyle(t etheme.Theme) ListStyle {
+	if !eterm.Unicode() {
+		return ListStyleASCII
+	}
+	return ListStyle{
+		EnumeratorStyle: lipgloss.NewStyle().Foreground(t.Primary),
+		ItemStyle:       lipgloss.NewStyle(),
+		Bullets:         []string{"•", "◦", "▪"},
+	}
+}
+
+// ListStyle using ASCII characters only for the bullets, for terminals and
+// logs without unicode support.
+var ListStyleASCII = ListStyle{
+	EnumeratorStyle: lipgloss.NewStyle().Bold(true),
+	ItemStyle:       lipgloss.NewStyle(),
+	Bullets:         []string{"*", "-", "+"},
+}
+
+// ListStyle for markdown formatting of the list.
+var ListStyleMarkdown = ListStyle{
+	EnumeratorStyle: lipgloss.NewStyle(),
+	ItemStyle:       lipgloss.NewStyle(),
+	Bullets:         []string{"-"},
+}
+
+// An item of a List with its nested list, if any.
+type listItem struct {
+	text     string
+	children *List
+}
+
+// A rapresentation of a bulleted, numbered or lettered List, whose items
+// can contain nested lists.
+type List struct {
+	kind  ListKind
+	items []listItem
+	style ListStyle
+	width int
+}
+
+// Create a new empty List of the given kind.
+//
+//	l := elist.NewList(elist.ListBulleted).
+//		WithItem("Compute").
+//		WithItem("Storage").
+//		WithSublist(elist.NewList(elist.ListNumbered).WithItems("Buckets", "Volumes"))
+//	fmt.Println(l.Render())
+func NewList(kind ListKind) List {
+	return List{
+		kind:  kind,
+		style: ListStyleDefault,
+	}
+}
+
+// Returns a copy of the items, so that the builders never share them
+// between Lists.
+func (l List) cloneItems() []listItem {
+	return append(make([]listItem, 0, len(l.items)+1), l.items...)
+}
+
+// Adds an item to the List. Text containing new lines spans several lines,
+// aligned after the enumerator.
+//
+//	l := elist.NewList(elist.ListBulleted).WithItem("Compute")
+func (l List) WithItem(text string) List {
+	l.items = append(l.cloneItems(), listItem{text: text})
+	return l
+}
+
+// Adds several items to the List.
+//
+//	l := elist.NewList(elist.ListNumbered).WithItems("Build", "Test", "Deploy")
+func (l List) WithItems(texts ...string) List {
+	for _, text := range texts {
+		l = l.WithItem(text)
+	}
+	return l
+}
+
+// Nest sub under the last item of the List, or under an empty item when the
+// List has none.
+//
+//	l := elist.NewList(elist.ListBulleted).
+//		WithItem("Storage").
+//		WithSublist(elist.NewList(elist.ListLettered).WithItems("Buckets", "Volumes"))
+func (l List) WithSublist(sub List) List {
+	items := l.cloneItems()
+	if len(items) == 0 {
+		items = append(items, listItem{})
+	}
+	items[len(items)-1].children = &sub
+	l.items = items
+	return l
+}
+
+// Specify the style of the List. Nested lists keep their own style.
+//
+//	l := elist.NewList(elist.ListBulleted).WithStyle(elist.ListStyleDefault)
+func (l List) WithStyle(s ListStyle) List {
+	l.style = s
+	return l
+}
+
+// Specify the width of the List in cells, after which the items are
+// wrapped with a hanging indentation. A width of 0 or less disables wrapping.
+//
+//	l := elist.NewList(elist.ListBulleted).WithWidth(80)
+func (l List) WithWidth(w int) List {
+	l.width = w
+	return l
+}
+
+// Returns the letters enumerating the i-th item, starting from 0: a, b, ...,
+// z, aa, ab, ...
+func letters(i int) string {
+	s := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		s = string(rune('a'+(i-1)%26)) + s
+	}
+	return s
+}
+
+// Returns the enumerator of the i-th item of the List at the given depth.
+func (l List) enumerator(i int, depth int) string {
+	switch l.kind {
+	case ListNumbered:
+		return strconv.Itoa(i+1) + "."
+	case ListLettered:
+		return letters(i) + "."
+	}
+	if len(l.style.Bullets) == 0 {
+		return "•"
+	}
+	return l.style.Bullets[min(depth, len(l.style.Bullets)-1)]
+}
+
+// Returns the style of the enumerators of the List at the given depth.
+func (l List) enumeratorStyle(depth int) lipgloss.Style {
+	if len(l.style.DepthStyles) == 0 {
+		return l.style.EnumeratorStyle
+	}
+	return l.style.DepthStyles[min(depth, len(l.style.DepthStyles)-1)]
+}
+
+// Render the List.
+//
+//	l := elist.NewList(...).WithItems(...)
+//	fmt.Println(l.Render())
+func (l List) Render() string {
+	return strings.Join(l.render(0, l.width), "\n")
+}
+
+// Render the lines of the List nested at the given depth, wrapping them at
+// width cells.
+func (l List) render(depth int, width int) []string {
+	// Enumerators are right aligned, so that the items start on the same
+	// column
+	enumerators := make([]string, len(l.items))
+	enumWidth := 0
+	for i := range l.items {
+		enumerators[i] = l.enumerator(i, depth)
+		enumWidth = max(enumWidth, ansi.StringWidth(enumerators[i]))
+	}
+	indent := strings.Repeat(" ", enumWidth+1)
+
+	lines := []string{}
+	for i, item := range l.items {
+		if item.text != "" {
+			text := item.text
+			if width > 0 {
+				text = etext.Wrap(text, width-enumWidth-1)
+			}
+			for j, line := range strings.Split(text, "\n") {
+				prefix := indent
+				if j == 0 {
+					prefix = etext.PadLeft(l.enumeratorStyle(depth).Render(enumerators[i]), enumWidth) + " "
+				}
+				lines = append(lines, prefix+l.style.ItemStyle.Render(line))
+			}
+		}
+
+		if item.children != nil {
+			childIndent := indent
+			if l.style.Indent > 0 {
+				childIndent = strings.Repeat(" ", l.style.Indent)
+			}
+			childWidth := item.children.width
+			if width > 0 {
+				childWidth = max(width-etext.Width(childIndent), 1)
+			}
+			for _, line := range item.children.render(depth+1, childWidth) {
+				lines = append(lines, childIndent+line)
+			}
+		}
+	}
+	return lines
+}
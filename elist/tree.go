@@ -0,0 +1,277 @@
+package elist
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Characters drawing the branches of a tree.
+type TreeBranches struct {
+	// Branch to a child followed by other children
+	Tee string
+	// Branch to the last child
+	Corner string
+	// Line continuing to the next children
+	Vertical string
+	// Line leading to the label of a child
+	Horizontal string
+}
+
+// Tree style definition.
+type TreeStyle struct {
+	RootStyle   lipgloss.Style
+	NodeStyle   lipgloss.Style
+	ValueStyle  lipgloss.Style
+	BranchStyle lipgloss.Style
+	// Node under the cursor of a TreeBrowser
+	SelectedStyle lipgloss.Style
+	// Styles of the Nodes by depth, starting from the children of the root,
+	// replacing NodeStyle. The last one is used for deeper Nodes.
+	DepthStyles []lipgloss.Style
+	Branches    TreeBranches
+	// Width of each level of the tree in cells, including the branches.
+	// The minimum is 2.
+	Indent int
+}
+
+// Default TreeStyle used by Tree. Uses the primary color of the etheme.Theme
+// for the root and faint unicode branches and values.
+var TreeStyleDefault = treeStyle(etheme.Current())
+
+// TreeStyle with rounded corners.
+var TreeStyleRounded = treeStyleRounded(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		TreeStyleDefault = treeStyle(t)
+		TreeStyleRounded = treeStyleRounded(t)
+	})
+}
+
+// Returns TreeStyleDefault for the theme t, TreeStyleASCII when the terminal
+// cannot display unicode.
+func treeStyle(t etheme.Theme) TreeStyle {
+	if !eterm.Unicode() {
+		return TreeStyleASCII
+	}
+	return TreeStyle{
+		RootStyle:     lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		NodeStyle:     lipgloss.NewStyle(),
+		ValueStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		BranchStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		SelectedStyle: lipgloss.NewStyle().Foreground(t.Primary).Reverse(true),
+		Branches:      TreeBranches{Tee: "├", Corner: "└", Vertical: "│", Horizontal: "─"},
+		Indent:        4,
+	}
+}
+
+// Returns TreeStyleRounded for the theme t.
+func treeStyleRounded(t etheme.Theme) TreeStyle {
+	return TreeStyle{
+		RootStyle:     lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		NodeStyle:     lipgloss.NewStyle(),
+		ValueStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		BranchStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		SelectedStyle: lipgloss.NewStyle().Foreground(t.Primary).Reverse(true),
+		Branches:      TreeBranches{Tee: "├", Corner: "╰", Vertical: "│", Horizontal: "─"},
+		Indent:        4,
+	}
+}
+
+// TreeStyle drawing the branches with ASCII characters only, for terminals
+// and logs without unicode support.
+var TreeStyleASCII = TreeStyle{
+	RootStyle:     lipgloss.NewStyle().Bold(true),
+	NodeStyle:     lipgloss.NewStyle(),
+	ValueStyle:    lipgloss.NewStyle().Faint(true),
+	BranchStyle:   lipgloss.NewStyle(),
+	SelectedStyle: lipgloss.NewStyle().Reverse(true),
+	Branches:      TreeBranches{Tee: "|", Corner: "`", Vertical: "|", Horizontal: "-"},
+	Indent:        4,
+}
+
+// Returns the branch leading to a child, and the indentation continuing
+// under it, depending on whether it is the last child of its parent.
+func (s TreeStyle) branch(last bool) (string, string) {
+	indent := max(s.Indent, 2)
+	line := strings.Repeat(s.Branches.Horizontal, indent-2) + " "
+	if last {
+		return s.Branches.Corner + line, strings.Repeat(" ", indent)
+	}
+	return s.Branches.Tee + line, s.Branches.Vertical + strings.Repeat(" ", indent-1)
+}
+
+// Returns the style of the Nodes at the given depth, the root being at
+// depth 0.
+func (s TreeStyle) nodeStyle(depth int) lipgloss.Style {
+	switch {
+	case depth == 0:
+		return s.RootStyle
+	case len(s.DepthStyles) > 0:
+		return s.DepthStyles[min(depth, len(s.DepthStyles))-1]
+	}
+	return s.NodeStyle
+}
+
+// Node is a node of a tree, with a label, an optional value annotation and
+// its children.
+type Node struct {
+	label      string
+	value      string
+	style      *lipgloss.Style
+	valueStyle *lipgloss.Style
+	children   []Node
+	loader     func() ([]Node, error)
+}
+
+// Create a new Node given its label and children.
+//
+//	n := elist.NewNode("api", elist.NewNode("db"), elist.NewNode("cache"))
+func NewNode(label string, children ...Node) Node {
+	return Node{label: label, children: children}
+}
+
+// Specify a value rendered after the label of the Node, like a version or a
+// size.
+//
+//	n := elist.NewNode("lipgloss").WithValue("v1.1.0")
+func (n Node) WithValue(v string) Node {
+	n.value = v
+	return n
+}
+
+// Specify the style of the label of the Node, replacing the one of the
+// TreeStyle.
+//
+//	n := elist.NewNode("broken").WithStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+func (n Node) WithStyle(s lipgloss.Style) Node {
+	n.style = &s
+	return n
+}
+
+// Specify the style of the value of the Node, replacing the one of the
+// TreeStyle.
+//
+//	n := elist.NewNode("status").WithValue("failing").WithValueStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+func (n Node) WithValueStyle(s lipgloss.Style) Node {
+	n.valueStyle = &s
+	return n
+}
+
+// Adds children to the Node.
+//
+//	n := elist.NewNode("api").WithChildren(elist.NewNode("db"))
+func (n Node) WithChildren(children ...Node) Node {
+	n.children = append(append(make([]Node, 0, len(n.children)+len(children)), n.children...), children...)
+	return n
+}
+
+// Specify a function loading the children of the Node when it is expanded
+// in a TreeBrowser, for hierarchies too large to load upfront. The children
+// loaded this way are not rendered by TreeView.
+//
+//	n := elist.NewNode("bucket").WithLoader(func() ([]elist.Node, error) {
+//		return listObjects("bucket")
+//	})
+func (n Node) WithLoader(load func() ([]Node, error)) Node {
+	n.loader = load
+	return n
+}
+
+// Label of the Node.
+func (n Node) Label() string {
+	return n.label
+}
+
+// Value annotation of the Node.
+func (n Node) Value() string {
+	return n.value
+}
+
+// Children of the Node.
+func (n Node) Children() []Node {
+	return n.children
+}
+
+// TreeView renders a tree of Nodes with box-drawing branches.
+type TreeView struct {
+	root  Node
+	style TreeStyle
+}
+
+// Create a new TreeView of the tree starting at root.
+//
+//	t := elist.Tree(elist.NewNode("app",
+//		elist.NewNode("api").WithValue("v2.1.0"),
+//		elist.NewNode("web", elist.NewNode("assets")),
+//	))
+//	fmt.Println(t.Render())
+func Tree(root Node) TreeView {
+	return TreeView{
+		root:  root,
+		style: TreeStyleDefault,
+	}
+}
+
+// Specify the style of the TreeView.
+//
+//	t := elist.Tree(root).WithStyle(elist.TreeStyleDefault)
+func (t TreeView) WithStyle(s TreeStyle) TreeView {
+	t.style = s
+	return t
+}
+
+// Render the label of n, followed by its value, with base as the style
+// unless n has its own.
+func (t TreeView) renderLabel(n Node, base lipgloss.Style) string {
+	style := base
+	if n.style != nil {
+		style = *n.style
+	}
+	return style.Render(n.label) + n.renderValue(t.style)
+}
+
+// Render the value of the Node after its label, if it has one.
+func (n Node) renderValue(s TreeStyle) string {
+	if n.value == "" {
+		return ""
+	}
+	style := s.ValueStyle
+	if n.valueStyle != nil {
+		style = *n.valueStyle
+	}
+	return "  " + style.Render(n.value)
+}
+
+// Render the TreeView.
+//
+//	t := elist.Tree(root)
+//	fmt.Println(t.Render())
+func (t TreeView) Render() string {
+	lines := strings.Split(t.renderLabel(t.root, t.style.nodeStyle(0)), "\n")
+	lines = append(lines, t.renderChildren(t.root, "", 1)...)
+	return strings.Join(lines, "\n")
+}
+
+// Render the lines of the children of n, prefixed by the branches of their
+// ancestors.
+func (t TreeView) renderChildren(n Node, prefix string, depth int) []string {
+	lines := []string{}
+	for i, child := range n.children {
+		branch, indent := t.style.branch(i == len(n.children)-1)
+
+		// Labels spanning several lines continue under the branch
+		for j, line := range strings.Split(t.renderLabel(child, t.style.nodeStyle(depth)), "\n") {
+			b := branch
+			if j > 0 {
+				b = indent
+			}
+			lines = append(lines, t.style.BranchStyle.Render(prefix+b)+line)
+		}
+		lines = append(lines, t.renderChildren(child, prefix+indent, depth+1)...)
+	}
+	return lines
+}
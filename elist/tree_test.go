@@ -0,0 +1,34 @@
+package elist_test
+
+import (
+	"testing"
+
+	"github.com/ravvio/easycli-ui/elist"
+	"github.com/ravvio/easycli-ui/etest"
+)
+
+// Returns a tree of the resources of a cluster.
+func cluster() elist.Node {
+	return elist.NewNode("cluster",
+		elist.NewNode("default",
+			elist.NewNode("web").WithValue("3 pods"),
+			elist.NewNode("worker").WithValue("1 pod"),
+		),
+		elist.NewNode("monitoring",
+			elist.NewNode("prometheus"),
+		),
+		elist.NewNode("kube-system"),
+	)
+}
+
+func TestTree(t *testing.T) {
+	etest.Setup(t)
+	etest.Golden(t, elist.Tree(cluster()).Render())
+}
+
+func TestTreeASCII(t *testing.T) {
+	term := etest.TerminalDefault
+	term.Unicode = false
+	etest.SetupTerminal(t, term)
+	etest.Golden(t, elist.Tree(cluster()).Render())
+}
@@ -0,0 +1,187 @@
+package elist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/etheme"
+	"gopkg.in/yaml.v3"
+)
+
+// Style of the trees built by TreeFromValue, by type of value.
+type DataStyle struct {
+	KeyStyle     lipgloss.Style
+	StringStyle  lipgloss.Style
+	NumberStyle  lipgloss.Style
+	BoolStyle    lipgloss.Style
+	NullStyle    lipgloss.Style
+	SummaryStyle lipgloss.Style
+}
+
+// Default DataStyle used by TreeFromValue.
+var DataStyleDefault = dataStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		DataStyleDefault = dataStyle(t)
+	})
+}
+
+// Returns DataStyleDefault for the theme t.
+func dataStyle(t etheme.Theme) DataStyle {
+	return DataStyle{
+		KeyStyle:     lipgloss.NewStyle().Foreground(t.Primary),
+		StringStyle:  lipgloss.NewStyle().Foreground(t.Success),
+		NumberStyle:  lipgloss.NewStyle().Foreground(t.Accent),
+		BoolStyle:    lipgloss.NewStyle().Foreground(t.Warning),
+		NullStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		SummaryStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+	}
+}
+
+// Returns the tree of a nested value, to be rendered with Tree or browsed
+// with a TreeBrowser. The keys of the objects and the scalar values are
+// styled by type with DataStyleDefault, see TreeFromValueWithStyle.
+//
+// A []byte, like a json.RawMessage, is parsed as a JSON or a YAML document,
+// keeping the order of the keys. Any other value is encoded as JSON first,
+// so structs are converted following their json tags.
+//
+//	root, err := elist.TreeFromValue(config)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(elist.Tree(root).Render())
+func TreeFromValue(v any) (Node, error) {
+	return TreeFromValueWithStyle(v, DataStyleDefault)
+}
+
+// Returns the tree of a nested value like TreeFromValue, styled with s.
+//
+//	root, err := elist.TreeFromValueWithStyle(data, style)
+func TreeFromValueWithStyle(v any, s DataStyle) (Node, error) {
+	data, ok := v.([]byte)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return Node{}, err
+		}
+	}
+
+	if json.Valid(data) {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		return s.fromJSON(dec, "")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return Node{}, err
+	}
+	return s.fromYAML(&doc, "")
+}
+
+// Returns the Node of a scalar value. The value is the label of the root,
+// otherwise it annotates its key.
+func (s DataStyle) scalar(key string, value string, style lipgloss.Style) Node {
+	if key == "" {
+		return NewNode(value).WithStyle(style)
+	}
+	return NewNode(key).WithStyle(s.KeyStyle).WithValue(value).WithValueStyle(style)
+}
+
+// Returns the Node of an object or an array, annotated with the number of
+// its children, where open is { or [.
+func (s DataStyle) composite(key string, open rune, children []Node) Node {
+	summary := fmt.Sprintf("%c%d%c", open, len(children), open+2)
+	if key == "" {
+		return NewNode(summary, children...)
+	}
+	return NewNode(key, children...).WithStyle(s.KeyStyle).WithValue(summary).WithValueStyle(s.SummaryStyle)
+}
+
+// Returns the Node of the next JSON value of dec, under key.
+func (s DataStyle) fromJSON(dec *json.Decoder, key string) (Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return Node{}, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		children := []Node{}
+		for i := 0; dec.More(); i++ {
+			label := "[" + strconv.Itoa(i) + "]"
+			if t == '{' {
+				k, err := dec.Token()
+				if err != nil {
+					return Node{}, err
+				}
+				label = k.(string)
+			}
+			child, err := s.fromJSON(dec, label)
+			if err != nil {
+				return Node{}, err
+			}
+			children = append(children, child)
+		}
+		// Closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return Node{}, err
+		}
+		return s.composite(key, rune(t), children), nil
+	case string:
+		return s.scalar(key, strconv.Quote(t), s.StringStyle), nil
+	case json.Number:
+		return s.scalar(key, t.String(), s.NumberStyle), nil
+	case bool:
+		return s.scalar(key, strconv.FormatBool(t), s.BoolStyle), nil
+	}
+	return s.scalar(key, "null", s.NullStyle), nil
+}
+
+// Returns the Node of a YAML value, under key.
+func (s DataStyle) fromYAML(n *yaml.Node, key string) (Node, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return s.scalar(key, "null", s.NullStyle), nil
+		}
+		return s.fromYAML(n.Content[0], key)
+	case yaml.AliasNode:
+		return s.fromYAML(n.Alias, key)
+	case yaml.MappingNode:
+		children := []Node{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			child, err := s.fromYAML(n.Content[i+1], n.Content[i].Value)
+			if err != nil {
+				return Node{}, err
+			}
+			children = append(children, child)
+		}
+		return s.composite(key, '{', children), nil
+	case yaml.SequenceNode:
+		children := []Node{}
+		for i, item := range n.Content {
+			child, err := s.fromYAML(item, "["+strconv.Itoa(i)+"]")
+			if err != nil {
+				return Node{}, err
+			}
+			children = append(children, child)
+		}
+		return s.composite(key, '[', children), nil
+	}
+
+	switch n.ShortTag() {
+	case "!!int", "!!float":
+		return s.scalar(key, n.Value, s.NumberStyle), nil
+	case "!!bool":
+		return s.scalar(key, n.Value, s.BoolStyle), nil
+	case "!!null":
+		return s.scalar(key, "null", s.NullStyle), nil
+	}
+	return s.scalar(key, strconv.Quote(n.Value), s.StringStyle), nil
+}
@@ -0,0 +1,167 @@
+package elog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"sync"
+)
+
+// The Logger used by the package level functions.
+var (
+	stdMu sync.RWMutex
+	std   = New()
+)
+
+// Returns the Logger used by the package level functions.
+func Default() Logger {
+	stdMu.RLock()
+	defer stdMu.RUnlock()
+	return std
+}
+
+// Replace the Logger used by the package level functions.
+//
+//	elog.SetDefault(elog.New().WithStyle(style))
+func SetDefault(l Logger) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	std = l
+}
+
+// Specify the io.Writer the package level functions write to.
+//
+//	elog.SetOutput(os.Stdout)
+func SetOutput(w io.Writer) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	std = std.WithOutput(w)
+}
+
+// Specify the minimum level of the messages written by the package level
+// functions.
+//
+//	elog.SetLevel(elog.LevelDebug)
+func SetLevel(level Level) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	std = std.WithLevel(level)
+}
+
+// Set the minimum level of the messages written by the package level
+// functions from the environment variable name, like MYAPP_LOG=debug, so
+// debug output can be enabled without changing the code. The level is left
+// unchanged when the variable is not set, an error is returned when it is
+// not a valid level, see ParseLevel.
+//
+//	if err := elog.SetLevelFromEnv("MYAPP_LOG"); err != nil {
+//		elog.Warn("ignoring MYAPP_LOG", "err", err)
+//	}
+func SetLevelFromEnv(name string) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+	level, err := ParseLevel(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	SetLevel(level)
+	return nil
+}
+
+// Write title, then run fn indenting the messages written meanwhile by the
+// default Logger, see Logger.Group.
+//
+//	elog.Group("deploy", func() {
+//		elog.Info("uploading artifacts")
+//	})
+func Group(title string, fn func()) {
+	Default().Group(title, fn)
+}
+
+// Specify a prefix written before the messages of the package level
+// functions.
+//
+//	elog.SetPrefix("myapp")
+func SetPrefix(prefix string) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	std = std.WithPrefix(prefix)
+}
+
+// Write the time of the messages of the package level functions, formatted
+// with layout. An empty layout disables the timestamps.
+//
+//	elog.SetTimestamp(time.TimeOnly)
+func SetTimestamp(layout string) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	std = std.WithTimestamp(layout)
+}
+
+// Render err with the chain of the errors it wraps and its hints, with the
+// style of the default Logger, see Logger.RenderError.
+//
+//	if err := run(); err != nil {
+//		fmt.Fprintln(os.Stderr, elog.RenderError(err))
+//		os.Exit(1)
+//	}
+func RenderError(err error) string {
+	return Default().RenderError(err)
+}
+
+// Render a recovered panic with its stack trace, with the style of the
+// default Logger, see Logger.RenderPanic.
+func RenderPanic(recovered any, stack []byte) string {
+	return Default().RenderPanic(recovered, stack)
+}
+
+// Recover a panic, write it with its stack trace with the default Logger and
+// exit with status 2. It must be deferred directly.
+//
+//	func main() {
+//		defer elog.Recover()
+//		...
+//	}
+func Recover() {
+	if r := recover(); r != nil {
+		Default().exitPanic(r, debug.Stack())
+	}
+}
+
+// Write a message of level Debug with the default Logger.
+//
+//	elog.Debug("using cache", "dir", dir)
+func Debug(msg string, keyvals ...any) {
+	Default().Debug(msg, keyvals...)
+}
+
+// Write a message of level Info with the default Logger.
+//
+//	elog.Info("deploying", "services", 3)
+func Info(msg string, keyvals ...any) {
+	Default().Info(msg, keyvals...)
+}
+
+// Write a message of level Success with the default Logger.
+//
+//	elog.Success("deployed")
+func Success(msg string, keyvals ...any) {
+	Default().Success(msg, keyvals...)
+}
+
+// Write a message of level Warn with the default Logger.
+//
+//	elog.Warn("no region configured, using eu-west-1")
+func Warn(msg string, keyvals ...any) {
+	Default().Warn(msg, keyvals...)
+}
+
+// Write a message of level Error with the default Logger.
+//
+//	elog.Error("deployment failed", "err", err)
+func Error(msg string, keyvals ...any) {
+	Default().Error(msg, keyvals...)
+}
@@ -0,0 +1,390 @@
+package elog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// Level of a log message, messages below the level of a Logger are
+// discarded.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelSuccess
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelSuccess:
+		return "success"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// Parse the name of a level, case insensitively. Warning is accepted for
+// LevelWarn.
+//
+//	level, err := elog.ParseLevel("debug")
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "success":
+		return LevelSuccess, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", s)
+}
+
+// Log style definition.
+type LogStyle struct {
+	DebugStyle   lipgloss.Style
+	InfoStyle    lipgloss.Style
+	SuccessStyle lipgloss.Style
+	WarnStyle    lipgloss.Style
+	ErrorStyle   lipgloss.Style
+	MessageStyle lipgloss.Style
+	PrefixStyle  lipgloss.Style
+	TimeStyle    lipgloss.Style
+	GroupStyle   lipgloss.Style
+	KeyStyle     lipgloss.Style
+	ValueStyle   lipgloss.Style
+	CauseStyle   lipgloss.Style
+	HintStyle    lipgloss.Style
+
+	DebugGlyph   string
+	InfoGlyph    string
+	SuccessGlyph string
+	WarnGlyph    string
+	ErrorGlyph   string
+	GroupGlyph   string
+
+	// Column at which the key-value pairs of the messages start, when the
+	// messages are shorter, to align them across lines
+	KeyValueColumn int
+}
+
+// Default LogStyle used by New, built from the current etheme.Theme.
+var LogStyleDefault = logStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		LogStyleDefault = logStyle(t)
+		// The default Logger is restyled too
+		stdMu.Lock()
+		std = std.WithStyle(LogStyleDefault)
+		stdMu.Unlock()
+	})
+}
+
+// Returns the LogStyle of the theme t.
+func logStyle(t etheme.Theme) LogStyle {
+	return LogStyle{
+		DebugStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		InfoStyle:    lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		SuccessStyle: lipgloss.NewStyle().Foreground(t.Success).Bold(true),
+		WarnStyle:    lipgloss.NewStyle().Foreground(t.Warning).Bold(true),
+		ErrorStyle:   lipgloss.NewStyle().Foreground(t.Error).Bold(true),
+		MessageStyle: lipgloss.NewStyle(),
+		PrefixStyle:  lipgloss.NewStyle().Bold(true),
+		TimeStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		GroupStyle:   lipgloss.NewStyle().Bold(true),
+		KeyStyle:     lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		ValueStyle:   lipgloss.NewStyle().Faint(true),
+		CauseStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		HintStyle:    lipgloss.NewStyle().Foreground(t.Warning),
+
+		DebugGlyph:   "·",
+		InfoGlyph:    "•",
+		SuccessGlyph: "✓",
+		WarnGlyph:    "!",
+		ErrorGlyph:   "✗",
+		GroupGlyph:   "▸",
+
+		KeyValueColumn: 32,
+	}
+}
+
+// Returns the style and the glyph of the messages of the given level.
+func (s LogStyle) level(level Level) (lipgloss.Style, string) {
+	switch level {
+	case LevelDebug:
+		return s.DebugStyle, s.DebugGlyph
+	case LevelSuccess:
+		return s.SuccessStyle, s.SuccessGlyph
+	case LevelWarn:
+		return s.WarnStyle, s.WarnGlyph
+	case LevelError:
+		return s.ErrorStyle, s.ErrorGlyph
+	}
+	return s.InfoStyle, s.InfoGlyph
+}
+
+// State shared by a Logger and its copies.
+type loggerState struct {
+	mu     sync.Mutex
+	groups []string
+}
+
+// Logger writes styled status messages to an io.Writer. The copies of a
+// Logger made by its builders share the same lock and Group indentation, so
+// they can write to the same io.Writer concurrently.
+type Logger struct {
+	shared *loggerState
+	out    io.Writer
+	colors bool
+	level  Level
+	style  LogStyle
+	prefix string
+	layout string
+}
+
+// Create a new Logger writing to os.Stderr messages of level Info and above.
+//
+//	log := elog.New().WithLevel(elog.LevelDebug)
+//	log.Debug("loaded configuration")
+func New() Logger {
+	return Logger{
+		shared: &loggerState{},
+		level:  LevelInfo,
+		style:  LogStyleDefault,
+	}.WithOutput(os.Stderr)
+}
+
+// Specify the io.Writer the Logger writes to. Colors are removed when it is
+// not a terminal. When it is, the messages written while a spinner, a
+// progress bar or a prompt is running are printed above it.
+//
+//	log := elog.New().WithOutput(os.Stdout)
+func (l Logger) WithOutput(w io.Writer) Logger {
+	l.out = w
+	f, ok := w.(*os.File)
+	l.colors = ok && eterm.IsTerminal(f)
+	return l
+}
+
+// Specify the minimum level of the messages written by the Logger.
+//
+//	log := elog.New().WithLevel(elog.LevelWarn)
+func (l Logger) WithLevel(level Level) Logger {
+	l.level = level
+	return l
+}
+
+// Set the minimum level of the messages written by the Logger from the
+// environment variable name, like MYAPP_LOG=debug, see ParseLevel. The level
+// is left unchanged when the variable is not set or is not a valid level.
+//
+//	log := elog.New().WithLevelFromEnv("MYAPP_LOG")
+func (l Logger) WithLevelFromEnv(name string) Logger {
+	if level, err := ParseLevel(os.Getenv(name)); err == nil {
+		l.level = level
+	}
+	return l
+}
+
+// Specify the style of the Logger.
+//
+//	log := elog.New().WithStyle(elog.LogStyleDefault)
+func (l Logger) WithStyle(s LogStyle) Logger {
+	l.style = s
+	return l
+}
+
+// Specify a prefix written before the messages of the Logger, like the name
+// of the component logging them.
+//
+//	log := elog.New().WithPrefix("api")
+func (l Logger) WithPrefix(prefix string) Logger {
+	l.prefix = prefix
+	return l
+}
+
+// Write the time of the messages at their start, formatted with layout, see
+// time.Layout. An empty layout disables the timestamps.
+//
+//	log := elog.New().WithTimestamp(time.TimeOnly)
+func (l Logger) WithTimestamp(layout string) Logger {
+	l.layout = layout
+	return l
+}
+
+// Minimum level of the messages written by the Logger.
+func (l Logger) Level() Level {
+	return l.level
+}
+
+// Reports whether messages of the given level are written by the Logger.
+func (l Logger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+// Write a message of the given level, followed by the key-value pairs in
+// keyvals rendered as key=value. Messages spanning several lines are aligned
+// after the glyph.
+//
+//	log.Log(elog.LevelInfo, "created", "id", id, "region", region)
+func (l Logger) Log(level Level, msg string, keyvals ...any) {
+	if !l.Enabled(level) {
+		return
+	}
+	if jsonMode.Load() {
+		l.writeJSON(level, msg, keyvals)
+		return
+	}
+	style, glyph := l.style.level(level)
+	msgStyle := l.style.MessageStyle
+	if level == LevelDebug {
+		msgStyle = style
+	}
+	l.write(style.Render(glyph), msgStyle, msg, keyvals)
+}
+
+// Write the lines of msg after the timestamp, the Group indentation, the
+// glyph and the prefix, followed by the key-value pairs.
+func (l Logger) write(glyph string, msgStyle lipgloss.Style, msg string, keyvals []any) {
+	l.shared.mu.Lock()
+	defer l.shared.mu.Unlock()
+
+	head := ""
+	if l.layout != "" {
+		head += l.style.TimeStyle.Render(time.Now().Format(l.layout)) + " "
+	}
+	head += strings.Repeat("  ", len(l.shared.groups)) + glyph + " "
+	if l.prefix != "" {
+		head += l.style.PrefixStyle.Render(l.prefix) + " "
+	}
+
+	indent := strings.Repeat(" ", ansi.StringWidth(head))
+	lines := strings.Split(msg, "\n")
+	for i, line := range lines {
+		prefix := indent
+		if i == 0 {
+			prefix = head
+		}
+		lines[i] = prefix + msgStyle.Render(line)
+	}
+	if kv := l.renderKeyValues(keyvals); kv != "" {
+		last := len(lines) - 1
+		pad := max(l.style.KeyValueColumn-ansi.StringWidth(lines[last])+len(indent), 2)
+		lines[last] += strings.Repeat(" ", pad) + kv
+	}
+	l.emit(strings.Join(lines, "\n"))
+}
+
+// Write s to the output of the Logger, without colors when it is not a
+// terminal. The lock of the Logger must be held.
+func (l Logger) emit(s string) {
+	if !l.colors {
+		_, _ = io.WriteString(l.out, ansi.Strip(s)+"\n")
+		return
+	}
+	// On a terminal the lines are printed above the live components, like
+	// spinners and progress bars, instead of corrupting them
+	if !live.Println(s) {
+		_, _ = io.WriteString(l.out, s+"\n")
+	}
+}
+
+// Returns the pairs of keyvals as key=value separated by spaces. A value
+// without a key is rendered with the key !BADKEY.
+func (l Logger) renderKeyValues(keyvals []any) string {
+	parts := []string{}
+	for i := 0; i < len(keyvals); i += 2 {
+		key, value := "!BADKEY", keyvals[i]
+		if i+1 < len(keyvals) {
+			key, value = fmt.Sprint(keyvals[i]), keyvals[i+1]
+		}
+		parts = append(parts, l.style.KeyStyle.Render(key+"=")+l.style.ValueStyle.Render(formatValue(value)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Format a value of a key-value pair, quoting it when it is empty or
+// contains spaces, quotes or equal signs.
+func formatValue(v any) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// Write title, then run fn indenting the messages written meanwhile by the
+// Logger and its copies, for readable nested output. In JSON mode the title
+// is not written, the messages have a group field instead.
+//
+//	log.Group("deploy", func() {
+//		log.Info("uploading artifacts")
+//		log.Success("deployed")
+//	})
+func (l Logger) Group(title string, fn func()) {
+	if l.Enabled(LevelInfo) && !jsonMode.Load() {
+		l.write(l.style.GroupStyle.Render(l.style.GroupGlyph), l.style.GroupStyle, title, nil)
+	}
+
+	l.shared.mu.Lock()
+	l.shared.groups = append(l.shared.groups, title)
+	l.shared.mu.Unlock()
+	defer func() {
+		l.shared.mu.Lock()
+		l.shared.groups = l.shared.groups[:len(l.shared.groups)-1]
+		l.shared.mu.Unlock()
+	}()
+	fn()
+}
+
+// Write a message of level Debug, followed by the key-value pairs in
+// keyvals, see Log.
+func (l Logger) Debug(msg string, keyvals ...any) {
+	l.Log(LevelDebug, msg, keyvals...)
+}
+
+// Write a message of level Info, see Log.
+func (l Logger) Info(msg string, keyvals ...any) {
+	l.Log(LevelInfo, msg, keyvals...)
+}
+
+// Write a message of level Success, reporting that an operation completed,
+// see Log.
+func (l Logger) Success(msg string, keyvals ...any) {
+	l.Log(LevelSuccess, msg, keyvals...)
+}
+
+// Write a message of level Warn, see Log.
+func (l Logger) Warn(msg string, keyvals ...any) {
+	l.Log(LevelWarn, msg, keyvals...)
+}
+
+// Write a message of level Error, see Log.
+func (l Logger) Error(msg string, keyvals ...any) {
+	l.Log(LevelError, msg, keyvals...)
+}
@@ -0,0 +1,113 @@
+package elog
+
+import "strings"
+
+// An error carrying a suggestion for the user, see Hint.
+type hintError struct {
+	err  error
+	hint string
+}
+
+func (e *hintError) Error() string {
+	return e.err.Error()
+}
+
+func (e *hintError) Unwrap() error {
+	return e.err
+}
+
+// Attach to err a suggestion for the user, rendered by RenderError after the
+// chain of causes. The message of err is left unchanged and errors.Is and
+// errors.As see through the returned error. Returns nil when err is nil.
+//
+//	if errors.Is(err, fs.ErrExist) {
+//		return elog.Hint(err, "use --force to overwrite it")
+//	}
+func Hint(err error, hint string) error {
+	if err == nil {
+		return nil
+	}
+	return &hintError{err: err, hint: hint}
+}
+
+// Returns the errors wrapped by err.
+func unwrapAll(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			return []error{inner}
+		}
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	}
+	return nil
+}
+
+// Returns the message err adds to the errors it wraps, empty when it only
+// wraps them, like the errors returned by Hint and errors.Join.
+func ownMessage(err error, wrapped []error) string {
+	msg := err.Error()
+	if len(wrapped) == 1 {
+		inner := wrapped[0].Error()
+		if msg == inner {
+			return ""
+		}
+		return strings.TrimSuffix(msg, ": "+inner)
+	}
+	if len(wrapped) > 1 {
+		messages := make([]string, len(wrapped))
+		for i, w := range wrapped {
+			messages[i] = w.Error()
+		}
+		if msg == strings.Join(messages, "\n") {
+			return ""
+		}
+	}
+	return msg
+}
+
+// Render err with the chain of the errors it wraps as indented "caused by"
+// lines, highlighting the root causes, followed by the hints attached with
+// Hint.
+//
+//	✗ deploy failed
+//	  caused by: upload artifacts
+//	    caused by: connection refused
+//	  hint: check your VPN connection
+func (l Logger) RenderError(err error) string {
+	if err == nil {
+		return ""
+	}
+	lines, hints := []string{}, []string{}
+	l.renderCauses(err, 0, &lines, &hints)
+	for _, hint := range hints {
+		lines = append(lines, "  "+l.style.HintStyle.Render("hint: "+hint))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (l Logger) renderCauses(err error, depth int, lines *[]string, hints *[]string) {
+	if h, ok := err.(*hintError); ok {
+		*hints = append(*hints, h.hint)
+	}
+
+	wrapped := unwrapAll(err)
+	if msg := ownMessage(err, wrapped); msg != "" {
+		style := l.style.CauseStyle
+		switch {
+		case len(wrapped) == 0:
+			style = l.style.ErrorStyle
+		case depth == 0:
+			style = l.style.MessageStyle
+		}
+		if depth == 0 {
+			*lines = append(*lines, l.style.ErrorStyle.Render(l.style.ErrorGlyph)+" "+style.Render(msg))
+		} else {
+			*lines = append(*lines, strings.Repeat("  ", depth)+style.Render("caused by: "+msg))
+		}
+		depth++
+	}
+	for _, w := range wrapped {
+		l.renderCauses(w, depth, lines, hints)
+	}
+}
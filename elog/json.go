@@ -0,0 +1,69 @@
+package elog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Whether the Loggers write JSON objects instead of styled text.
+var jsonMode atomic.Bool
+
+// Make every Logger write each message as a JSON object on its own line,
+// with the fields time, level and msg followed by the key-value pairs, for
+// log aggregators. The prefix and the Group of the messages are written in
+// the fields prefix and group.
+//
+//	if os.Getenv("MYAPP_LOG_FORMAT") == "json" {
+//		elog.SetJSON(true)
+//	}
+func SetJSON(enabled bool) {
+	jsonMode.Store(enabled)
+}
+
+// Write a message as a JSON object, keeping the order of its fields.
+func (l Logger) writeJSON(level Level, msg string, keyvals []any) {
+	l.shared.mu.Lock()
+	defer l.shared.mu.Unlock()
+
+	fields := []any{"time", time.Now(), "level", level.String(), "msg", msg}
+	if l.prefix != "" {
+		fields = append(fields, "prefix", l.prefix)
+	}
+	if len(l.shared.groups) > 0 {
+		fields = append(fields, "group", strings.Join(l.shared.groups, "/"))
+	}
+	fields = append(fields, keyvals...)
+
+	var b strings.Builder
+	b.WriteString("{")
+	for i := 0; i < len(fields); i += 2 {
+		key, value := "!BADKEY", fields[i]
+		if i+1 < len(fields) {
+			key, value = fmt.Sprint(fields[i]), fields[i+1]
+		}
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.Write(marshalJSON(key))
+		b.WriteString(":")
+		b.Write(marshalJSON(value))
+	}
+	b.WriteString("}")
+	l.emit(b.String())
+}
+
+// Encode a value of a message as JSON. Errors are encoded as their message
+// and values that cannot be encoded as their default format.
+func marshalJSON(v any) []byte {
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return data
+}
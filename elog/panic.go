@@ -0,0 +1,149 @@
+package elog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// A frame of a stack trace: a function and its location.
+type stackFrame struct {
+	function string
+	location string
+}
+
+// Reports whether the frame belongs to the machinery of the panic rather
+// than to the code that panicked.
+func (f stackFrame) internal() bool {
+	for _, prefix := range []string{"runtime/debug.Stack", "runtime.gopanic", "panic(", "runtime.panic", "github.com/ravvio/easycli-ui/elog."} {
+		if strings.HasPrefix(f.function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Shorten the function of a frame, removing the path of its package and its
+// arguments: github.com/acme/app/cmd.(*Deploy).Run(0xc0000a2000) becomes
+// cmd.(*Deploy).Run.
+func shortFunction(function string) string {
+	created := strings.HasPrefix(function, "created by ")
+	function = strings.TrimPrefix(function, "created by ")
+	function, _, _ = strings.Cut(function, " in goroutine")
+	if i := strings.LastIndex(function, "("); i > 0 && strings.HasSuffix(function, ")") {
+		function = function[:i]
+	}
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		function = function[i+1:]
+	}
+	if created {
+		return "created by " + function
+	}
+	return function
+}
+
+// Shorten the location of a frame to the file, its directory and the line,
+// removing the offset of the program counter.
+func shortLocation(location string) string {
+	location = strings.TrimSpace(location)
+	location, _, _ = strings.Cut(location, " +0x")
+	dir, file := filepath.Split(location)
+	return filepath.Join(filepath.Base(dir), file)
+}
+
+// Render a panic recovered by a top level recover handler, with its stack
+// trace as returned by debug.Stack. The frames of the panic machinery are
+// removed, the goroutine headers dimmed and the package paths shortened.
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			fmt.Fprintln(os.Stderr, elog.RenderPanic(r, debug.Stack()))
+//			os.Exit(2)
+//		}
+//	}()
+func (l Logger) RenderPanic(recovered any, stack []byte) string {
+	lines := []string{l.style.ErrorStyle.Render(l.style.ErrorGlyph + " panic: " + fmt.Sprint(recovered))}
+
+	raw := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	for i := 0; i < len(raw); i++ {
+		line := raw[i]
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "goroutine "):
+			lines = append(lines, "", "  "+l.style.CauseStyle.Render(line))
+		case !strings.HasPrefix(line, "\t"):
+			frame := stackFrame{function: line}
+			if i+1 < len(raw) && strings.HasPrefix(raw[i+1], "\t") {
+				frame.location = raw[i+1]
+				i++
+			}
+			if !frame.internal() {
+				lines = append(lines, l.renderFrame(frame)...)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Render a frame of a stack trace, the frames of the runtime are dimmed.
+func (l Logger) renderFrame(f stackFrame) []string {
+	function := shortFunction(f.function)
+	location := ""
+	if f.location != "" {
+		location = shortLocation(f.location)
+	}
+
+	if strings.HasPrefix(function, "runtime.") || strings.HasPrefix(function, "testing.") {
+		lines := []string{"  " + l.style.CauseStyle.Render(function)}
+		if location != "" {
+			lines = append(lines, "      "+l.style.CauseStyle.Render(location))
+		}
+		return lines
+	}
+
+	// The package is dimmed, the function highlighted
+	pkg, name, ok := strings.Cut(function, ".")
+	rendered := l.style.PrefixStyle.Render(function)
+	if ok {
+		rendered = l.style.CauseStyle.Render(pkg+".") + l.style.PrefixStyle.Render(name)
+	}
+	lines := []string{"  " + rendered}
+	if location != "" {
+		lines = append(lines, "      "+l.style.InfoStyle.UnsetBold().Render(location))
+	}
+	return lines
+}
+
+// Recover a panic, write it with its stack trace to the output of the
+// Logger and exit with status 2. It must be deferred directly.
+// In JSON mode the raw stack trace is written in the field stack.
+//
+//	func main() {
+//		defer elog.Default().Recover()
+//		...
+//	}
+func (l Logger) Recover() {
+	if r := recover(); r != nil {
+		l.exitPanic(r, debug.Stack())
+	}
+}
+
+// Write a recovered panic and exit with status 2.
+func (l Logger) exitPanic(recovered any, stack []byte) {
+	if jsonMode.Load() {
+		l.writeJSON(LevelError, fmt.Sprint("panic: ", recovered), []any{"stack", string(stack)})
+		os.Exit(2)
+	}
+	s := l.RenderPanic(recovered, stack)
+	if !l.colors {
+		s = ansi.Strip(s)
+	}
+	l.shared.mu.Lock()
+	fmt.Fprintln(l.out, s)
+	l.shared.mu.Unlock()
+	os.Exit(2)
+}
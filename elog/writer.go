@@ -0,0 +1,33 @@
+package elog
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// Writes lines above the running live component, if any.
+type liveWriter struct {
+	w io.Writer
+}
+
+// Returns an io.Writer printing the lines written to it above the spinner,
+// the progress bar or the prompt running at the time, instead of corrupting
+// their output. When none is running the lines are written to w.
+// It is the single integration point for the output of other libraries,
+// like the standard log package:
+//
+//	log.SetOutput(elog.LiveWriter(os.Stderr))
+func LiveWriter(w io.Writer) io.Writer {
+	return liveWriter{w: w}
+}
+
+// Each call to Write is printed as whole lines, a missing trailing new line
+// is added when a live component is running.
+func (w liveWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 || !live.Println(string(bytes.TrimSuffix(p, []byte("\n")))) {
+		return w.w.Write(p)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,117 @@
+package emarkdown
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Markdown style definition, see the glamour documentation for its fields.
+type MarkdownStyle = ansi.StyleConfig
+
+// Default MarkdownStyle used by Render. Uses the primary color of the
+// etheme.Theme for the headings and the links, like the other components.
+var MarkdownStyleDefault = markdownStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		MarkdownStyleDefault = markdownStyle(t)
+	})
+}
+
+// MarkdownStyle without colors nor unicode characters, for terminals and
+// logs with limited support.
+var MarkdownStyleASCII = withoutMargin(styles.ASCIIStyleConfig)
+
+// Returns MarkdownStyleDefault for the theme t, MarkdownStyleASCII when the
+// terminal cannot display unicode. The style is based on the dark glamour
+// style, so the dark variant of the colors is used.
+func markdownStyle(t etheme.Theme) MarkdownStyle {
+	if !eterm.Unicode() {
+		return MarkdownStyleASCII
+	}
+	s := withoutMargin(styles.DarkStyleConfig)
+	s.Document.Color = nil
+	s.BlockQuote.Faint = boolPtr(true)
+	s.Heading.Color = colorPtr(t.Primary)
+	s.H1.StylePrimitive = ansi.StylePrimitive{Prefix: "# "}
+	s.H6.Color = nil
+	s.HorizontalRule.Color = colorPtr(t.Muted)
+	s.HorizontalRule.Faint = boolPtr(true)
+	s.Link.Color = colorPtr(t.Primary)
+	s.LinkText.Color = colorPtr(t.Primary)
+	s.Image.Color = colorPtr(t.Primary)
+	s.ImageText.Color = colorPtr(t.Muted)
+	s.ImageText.Faint = boolPtr(true)
+	s.Code.StylePrimitive = ansi.StylePrimitive{Color: colorPtr(t.Warning)}
+	s.CodeBlock.Color = colorPtr(t.Muted)
+	return s
+}
+
+// Returns s without the margin and the empty lines around the documents.
+func withoutMargin(s MarkdownStyle) MarkdownStyle {
+	s.Document.Margin = nil
+	s.Document.BlockPrefix = ""
+	s.Document.BlockSuffix = ""
+	return s
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// Returns the dark variant of c, nil when it is not set.
+func colorPtr(c lipgloss.AdaptiveColor) *string {
+	if c.Dark == "" {
+		return nil
+	}
+	return stringPtr(c.Dark)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// Render the markdown src styled with MarkdownStyleDefault, wrapping the
+// paragraphs to width cells, for help texts, changelogs and README excerpts.
+// A width of 0 or less uses the width of the terminal.
+//
+//	out, err := emarkdown.Render(changelog, 0)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(out)
+func Render(src string, width int) (string, error) {
+	return RenderWithStyle(src, width, MarkdownStyleDefault)
+}
+
+// Render the markdown src like Render, styled with style.
+//
+//	out, err := emarkdown.RenderWithStyle(help, 80, emarkdown.MarkdownStyleASCII)
+func RenderWithStyle(src string, width int, style MarkdownStyle) (string, error) {
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return "", err
+	}
+	out, err := r.Render(src)
+	if err != nil {
+		return "", err
+	}
+	// The lines are padded to the width with spaces
+	lines := strings.Split(strings.Trim(out, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n"), nil
+}
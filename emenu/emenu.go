@@ -0,0 +1,390 @@
+// Package emenu presents a menu of actions with nested submenus, to launch
+// a CLI run without arguments into an interactive mode.
+package emenu
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etext"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// ErrInterrupted is returned when the user leaves the menu with Ctrl+C, or
+// with Esc from the top level menu.
+var ErrInterrupted = eterm.ErrInterrupted
+
+// ErrNonInteractive is returned when the menu cannot be displayed because
+// stdin is not a terminal.
+var ErrNonInteractive = errors.New("the terminal is not interactive")
+
+// Menu style definition.
+type MenuStyle struct {
+	TitleStyle       lipgloss.Style
+	PathStyle        lipgloss.Style
+	SelectedStyle    lipgloss.Style
+	KeyStyle         lipgloss.Style
+	DescriptionStyle lipgloss.Style
+	HelpStyle        lipgloss.Style
+	Cursor           string
+	// Rendered after the label of the items opening a submenu
+	SubmenuGlyph string
+	// Rendered between the title and the labels of the open submenus
+	PathSeparator string
+}
+
+// Default MenuStyle used by NewMenu. Uses the primary color of the
+// etheme.Theme for the title and the selected item, and the accent color
+// for the shortcut keys.
+var MenuStyleDefault = menuStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		MenuStyleDefault = menuStyle(t)
+	})
+}
+
+// Returns MenuStyleDefault for the theme t, MenuStyleASCII when the terminal
+// cannot display unicode.
+func menuStyle(t etheme.Theme) MenuStyle {
+	if !eterm.Unicode() {
+		return MenuStyleASCII
+	}
+	return MenuStyle{
+		TitleStyle:       lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		PathStyle:        lipgloss.NewStyle().Bold(true),
+		SelectedStyle:    lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		KeyStyle:         lipgloss.NewStyle().Foreground(t.Accent),
+		DescriptionStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		HelpStyle:        lipgloss.NewStyle().Faint(true),
+		Cursor:           ">",
+		SubmenuGlyph:     "›",
+		PathSeparator:    " › ",
+	}
+}
+
+// MenuStyle without colors nor unicode characters, for terminals with
+// limited support.
+var MenuStyleASCII = MenuStyle{
+	TitleStyle:       lipgloss.NewStyle().Bold(true),
+	PathStyle:        lipgloss.NewStyle().Bold(true),
+	SelectedStyle:    lipgloss.NewStyle().Reverse(true),
+	KeyStyle:         lipgloss.NewStyle(),
+	DescriptionStyle: lipgloss.NewStyle(),
+	HelpStyle:        lipgloss.NewStyle(),
+	Cursor:           ">",
+	SubmenuGlyph:     ">",
+	PathSeparator:    " > ",
+}
+
+// Item of a Menu. An Item with Items opens a submenu, the others are
+// actions returned by Menu.Run. Key is an optional shortcut choosing the
+// Item directly, like "d".
+type Item struct {
+	Label       string
+	Description string
+	Key         string
+	Value       string
+	Items       []Item
+}
+
+// Menu lets the user choose an action with the keyboard, among Items which
+// may open nested submenus.
+type Menu struct {
+	title      string
+	items      []Item
+	style      MenuStyle
+	fullscreen bool
+	height     int
+}
+
+// Create a new Menu of items, rendered under title.
+//
+//	m := emenu.NewMenu("myapp", []emenu.Item{
+//		{Label: "Deploy", Key: "d", Items: []emenu.Item{
+//			{Label: "Staging", Value: "deploy-staging"},
+//			{Label: "Production", Value: "deploy-prod", Description: "Live traffic"},
+//		}},
+//		{Label: "Logs", Key: "l", Value: "logs", Description: "Follow the logs"},
+//	})
+func NewMenu(title string, items []Item) Menu {
+	return Menu{
+		title:  title,
+		items:  items,
+		style:  MenuStyleDefault,
+		height: 10,
+	}
+}
+
+// Specify the style of the Menu.
+//
+//	m := emenu.NewMenu(title, items).WithStyle(emenu.MenuStyleASCII)
+func (m Menu) WithStyle(s MenuStyle) Menu {
+	m.style = s
+	return m
+}
+
+// Render the Menu on the whole terminal instead of below the cursor. The
+// terminal is restored when the Menu returns.
+//
+//	m := emenu.NewMenu(title, items).WithFullscreen(true)
+func (m Menu) WithFullscreen(f bool) Menu {
+	m.fullscreen = f
+	return m
+}
+
+// Specify the maximum number of items rendered at once, the Menu scrolls to
+// follow the cursor. A fullscreen Menu fills the height of the terminal.
+//
+//	m := emenu.NewMenu(title, items).WithHeight(20)
+func (m Menu) WithHeight(h int) Menu {
+	m.height = max(h, 1)
+	return m
+}
+
+// Run the Menu until the user chooses an action, which is returned. Enter or
+// the shortcut of an Item chooses it or opens its submenu, Esc, Left and
+// Backspace go back to the parent menu. Ctrl+C, or Esc from the top level
+// menu, return ErrInterrupted.
+//
+//	item, err := emenu.NewMenu("myapp", items).Run()
+//	if err != nil {
+//		return err
+//	}
+//	switch item.Value {
+//	case "logs":
+//		return followLogs()
+//	}
+func (m Menu) Run() (Item, error) {
+	if len(m.items) == 0 {
+		return Item{}, errors.New("no items in the menu")
+	}
+	if !eterm.IsTerminal(os.Stdin) {
+		return Item{}, ErrNonInteractive
+	}
+
+	opts := []tea.ProgramOption{}
+	if m.fullscreen {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	model := menuModel{
+		menu:   m,
+		stack:  []menuLevel{{items: m.items}},
+		height: m.height,
+	}
+	final, err := live.Run(tea.NewProgram(model, opts...))
+	if err != nil {
+		return Item{}, err
+	}
+	model = final.(menuModel)
+	if model.aborted {
+		return Item{}, ErrInterrupted
+	}
+	return model.chosen, nil
+}
+
+// A menu open in a running Menu, the top level one or a submenu.
+type menuLevel struct {
+	label  string
+	items  []Item
+	cursor int
+	offset int
+}
+
+// Bubbletea model of a running Menu.
+type menuModel struct {
+	menu  Menu
+	stack []menuLevel
+	// Number of items rendered at once
+	height  int
+	width   int
+	chosen  Item
+	done    bool
+	aborted bool
+}
+
+// Returns the menu open last.
+func (m *menuModel) level() *menuLevel {
+	return &m.stack[len(m.stack)-1]
+}
+
+// Move the cursor to the i-th item of the open menu, scrolling if needed.
+func (m *menuModel) moveTo(i int) {
+	l := m.level()
+	l.cursor = min(max(i, 0), len(l.items)-1)
+	if l.cursor < l.offset {
+		l.offset = l.cursor
+	}
+	if l.cursor >= l.offset+m.height {
+		l.offset = l.cursor - m.height + 1
+	}
+	l.offset = max(min(l.offset, len(l.items)-m.height), 0)
+}
+
+// Choose the item, opening its submenu or returning it.
+func (m *menuModel) choose(item Item) tea.Cmd {
+	if len(item.Items) > 0 {
+		m.stack = append(m.stack, menuLevel{label: item.Label, items: item.Items})
+		return nil
+	}
+	m.chosen, m.done = item, true
+	return tea.Quit
+}
+
+// Go back to the parent menu, or leave the top level menu.
+func (m *menuModel) back() tea.Cmd {
+	if len(m.stack) == 1 {
+		m.aborted = true
+		return tea.Quit
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	return nil
+}
+
+func (m menuModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m menuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		if m.menu.fullscreen {
+			// Below the title and above the help line
+			m.height = max(msg.Height-3, 1)
+		}
+		m.moveTo(m.level().cursor)
+	case tea.KeyMsg:
+		l := m.level()
+		switch msg.String() {
+		case "ctrl+c":
+			m.aborted = true
+			return m, tea.Quit
+		case "esc", "left", "backspace":
+			return m, m.back()
+		case "enter", "right":
+			return m, m.choose(l.items[l.cursor])
+		case "up", "shift+tab":
+			m.moveTo((l.cursor - 1 + len(l.items)) % len(l.items))
+		case "down", "tab":
+			m.moveTo((l.cursor + 1) % len(l.items))
+		case "pgup", "ctrl+b":
+			m.moveTo(l.cursor - m.height)
+		case "pgdown", "ctrl+f":
+			m.moveTo(l.cursor + m.height)
+		case "home":
+			m.moveTo(0)
+		case "end":
+			m.moveTo(len(l.items) - 1)
+		default:
+			for i, item := range l.items {
+				if item.Key != "" && item.Key == msg.String() {
+					m.moveTo(i)
+					return m, m.choose(item)
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// Render the title followed by the labels of the open submenus.
+func (m menuModel) renderPath() string {
+	style := m.menu.style
+	path := style.TitleStyle.Render(m.menu.title)
+	for _, l := range m.stack[1:] {
+		path += style.PathSeparator + style.PathStyle.Render(l.label)
+	}
+	if m.done {
+		path += style.PathSeparator + style.PathStyle.Render(m.chosen.Label)
+	}
+	return path
+}
+
+// Render the items of the open menu, with their shortcuts and their
+// descriptions aligned.
+func (m menuModel) renderItems() []string {
+	style := m.menu.style
+	l := m.stack[len(m.stack)-1]
+
+	keyWidth, labelWidth := 0, 0
+	for _, item := range l.items {
+		if item.Key != "" {
+			keyWidth = max(keyWidth, ansi.StringWidth(item.Key)+3)
+		}
+		labelWidth = max(labelWidth, ansi.StringWidth(m.label(item)))
+	}
+
+	end := min(l.offset+m.height, len(l.items))
+	lines := make([]string, 0, end-l.offset)
+	for i := l.offset; i < end; i++ {
+		item := l.items[i]
+		line := strings.Repeat(" ", ansi.StringWidth(style.Cursor)+1)
+		label := m.label(item)
+		if i == l.cursor {
+			line = style.SelectedStyle.Render(style.Cursor) + " "
+			label = style.SelectedStyle.Render(label)
+		}
+		if keyWidth > 0 {
+			key := ""
+			if item.Key != "" {
+				key = "[" + item.Key + "]"
+			}
+			line += etext.PadRight(style.KeyStyle.Render(key), keyWidth)
+		}
+		if item.Description != "" {
+			label = etext.PadRight(label, labelWidth+2) + style.DescriptionStyle.Render(item.Description)
+		}
+		line += label
+		if m.width > 0 {
+			line = etext.Truncate(line, m.width)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Returns the label of an item, with the glyph of the submenus.
+func (m menuModel) label(item Item) string {
+	if len(item.Items) > 0 && m.menu.style.SubmenuGlyph != "" {
+		return item.Label + " " + m.menu.style.SubmenuGlyph
+	}
+	return item.Label
+}
+
+func (m menuModel) View() string {
+	if m.done || m.aborted {
+		if m.menu.fullscreen {
+			return ""
+		}
+		return m.renderPath() + "\n"
+	}
+
+	style := m.menu.style
+	lines := append([]string{m.renderPath()}, m.renderItems()...)
+	if m.menu.fullscreen {
+		for len(lines) < m.height+1 {
+			lines = append(lines, "")
+		}
+	}
+
+	help := ekeys.Plain(
+		ekeys.Move,
+		ekeys.Bind("enter", "choose"),
+		ekeys.Bind("esc", "back").WithEnabled(len(m.stack) > 1),
+		ekeys.Bind("esc", "quit").WithEnabled(len(m.stack) == 1),
+	)
+	if l := m.stack[len(m.stack)-1]; len(l.items) > m.height {
+		help = fmt.Sprintf("%d/%d · %s", l.cursor+1, len(l.items), help)
+	}
+	lines = append(lines, style.HelpStyle.Render(help))
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,108 @@
+// Package enotify gets the attention of the user when a long task finishes
+// by ringing the bell of the terminal, setting its title and progress
+// indicator or sending a desktop notification through escape sequences.
+// Terminals ignore the sequences they do not support, and nothing is
+// written when neither stdout nor stderr is a terminal.
+package enotify
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+// State of the progress indicator of the terminal, the values are defined
+// by OSC 9;4.
+type ProgressState int
+
+const (
+	ProgressNone ProgressState = iota
+	ProgressNormal
+	ProgressError
+	ProgressIndeterminate
+	ProgressPaused
+)
+
+// Write seq to the terminal, if any.
+func write(seq string) {
+	if w := eterm.ControlWriter(); w != nil {
+		_, _ = io.WriteString(w, seq)
+	}
+}
+
+// Remove the control characters from s, which would end the sequence it is
+// written in.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// Ring the bell of the terminal.
+//
+//	enotify.Bell()
+func Bell() {
+	write("\a")
+}
+
+// Set the title of the window or tab of the terminal.
+//
+//	enotify.SetTitle("deploy: 3/5")
+func SetTitle(title string) {
+	write(ansi.SetWindowTitle(sanitize(title)))
+}
+
+// Set the progress indicator of the terminal, shown in the tab or taskbar by
+// Windows Terminal, ConEmu, Ghostty and others. percent is clamped between
+// 0 and 100, it is ignored by ProgressNone and ProgressIndeterminate.
+//
+//	enotify.SetProgress(enotify.ProgressNormal, 40)
+func SetProgress(state ProgressState, percent int) {
+	write(fmt.Sprintf("\x1b]9;4;%d;%d\a", state, min(max(percent, 0), 100)))
+}
+
+// Remove the progress indicator of the terminal.
+//
+//	defer enotify.ClearProgress()
+func ClearProgress() {
+	SetProgress(ProgressNone, 0)
+}
+
+// Send a desktop notification through the terminal. The sequence depends on
+// the terminal: OSC 99 for kitty, OSC 777 for foot and rxvt, OSC 9 for the
+// others, like iTerm2, WezTerm and Windows Terminal.
+//
+//	enotify.Notify("Build", "Done in 3m12s")
+func Notify(title string, body string) {
+	title, body = sanitize(title), sanitize(body)
+	termName := os.Getenv("TERM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "":
+		write("\x1b]99;i=1:d=0;" + title + "\x1b\\")
+		write("\x1b]99;i=1:d=1:p=body;" + body + "\x1b\\")
+	case strings.HasPrefix(termName, "foot"), strings.Contains(termName, "rxvt"):
+		write("\x1b]777;notify;" + title + ";" + body + "\a")
+	default:
+		write(ansi.Notify(title + ": " + body))
+	}
+}
+
+// Ring the bell and send a desktop notification of the outcome of the task
+// title, failed when err is not nil.
+//
+//	enotify.Finished("Build", err)
+func Finished(title string, err error) {
+	Bell()
+	if err != nil {
+		Notify(title, fmt.Sprintf("Failed: %v", err))
+		return
+	}
+	Notify(title, "Done")
+}
@@ -0,0 +1,317 @@
+package epager
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eclip"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+	"github.com/ravvio/easycli-ui/internal/search"
+)
+
+// Pager style definition.
+type PagerStyle struct {
+	TextStyle   lipgloss.Style
+	StatusStyle lipgloss.Style
+	TitleStyle  lipgloss.Style
+	MatchStyle  lipgloss.Style
+}
+
+// Default PagerStyle used by Pager, with the status line in reverse video.
+var PagerStyleDefault = pagerStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		PagerStyleDefault = pagerStyle(t)
+	})
+}
+
+// Returns PagerStyleDefault for the theme t.
+func pagerStyle(t etheme.Theme) PagerStyle {
+	return PagerStyle{
+		TextStyle:   lipgloss.NewStyle(),
+		StatusStyle: lipgloss.NewStyle().Reverse(true),
+		TitleStyle:  lipgloss.NewStyle().Reverse(true).Bold(true),
+		MatchStyle:  lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Warning),
+	}
+}
+
+// Pager shows a long text one screen at a time, like less.
+type Pager struct {
+	content string
+	title   string
+	style   PagerStyle
+}
+
+// Create a new Pager of content, which may be already styled.
+//
+//	err := epager.NewPager(logs).WithTitle("api-server").Run()
+func NewPager(content string) Pager {
+	return Pager{
+		content: content,
+		style:   PagerStyleDefault,
+	}
+}
+
+// Specify a title rendered in the status line.
+//
+//	p := epager.NewPager(logs).WithTitle("api-server")
+func (p Pager) WithTitle(title string) Pager {
+	p.title = title
+	return p
+}
+
+// Specify the style of the Pager.
+//
+//	p := epager.NewPager(logs).WithStyle(epager.PagerStyleDefault)
+func (p Pager) WithStyle(s PagerStyle) Pager {
+	p.style = s
+	return p
+}
+
+// Page content with the default Pager, see Pager.Run.
+//
+//	if err := epager.Page(changelog); err != nil {
+//		return err
+//	}
+func Page(content string) error {
+	return NewPager(content).Run()
+}
+
+// Read r until EOF and page its content with the default Pager, see
+// Pager.Run.
+//
+//	err := epager.PageReader(resp.Body)
+func PageReader(r io.Reader) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return Page(string(content))
+}
+
+// Show the content one screen at a time until the user quits with q, Esc or
+// Ctrl+C. Lines longer than the terminal are wrapped. / searches the
+// content, n and N move to the next and the previous match, Esc clears the
+// search, c copies the content to the clipboard.
+// The content is printed instead when it fits the terminal or when stdout is
+// not a terminal, so output piped to other commands is not paged.
+//
+//	err := epager.NewPager(logs).Run()
+func (p Pager) Run() error {
+	content := strings.TrimSuffix(p.content, "\n")
+	if !eterm.IsTerminal(os.Stdout) {
+		_, err := fmt.Println(content)
+		return err
+	}
+	width, height := eterm.Size()
+	if len(wrapLines(content, width)) < height {
+		_, err := fmt.Println(content)
+		return err
+	}
+
+	m := pagerModel{
+		pager: p,
+		// Tabs are expanded, their width depends on the terminal
+		lines: strings.Split(strings.ReplaceAll(content, "\t", "    "), "\n"),
+	}
+	_, err := live.Run(tea.NewProgram(m, tea.WithAltScreen()))
+	return err
+}
+
+// Returns the lines of content wrapped to width cells.
+func wrapLines(content string, width int) []string {
+	if width <= 0 {
+		return strings.Split(content, "\n")
+	}
+	return strings.Split(ansi.Hardwrap(content, width, true), "\n")
+}
+
+// Bubbletea model of a running Pager.
+type pagerModel struct {
+	pager Pager
+	lines []string
+	rows  []string
+	// Index of the line of each row
+	rowLines []int
+	width    int
+	height   int
+	offset   int
+	search   search.Search
+	message  string
+	// Sequence copying to the clipboard, rendered with the status line
+	// rather than written while the program renders
+	clipboard string
+}
+
+// Number of lines of content shown at once, above the status line.
+func (m pagerModel) page() int {
+	return max(m.height-1, 1)
+}
+
+// Scroll to the line at offset, keeping the last page full.
+func (m *pagerModel) scrollTo(offset int) {
+	m.offset = max(min(offset, len(m.rows)-m.page()), 0)
+}
+
+func (m pagerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m pagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Keep the first visible line in view after rewrapping
+		first := 0
+		if m.offset < len(m.rowLines) {
+			first = m.rowLines[m.offset]
+		}
+		m.width, m.height = msg.Width, msg.Height
+		m.wrap()
+		m.scrollTo(m.rowOf(first))
+	case tea.KeyMsg:
+		m.message, m.clipboard = "", ""
+		if m.search.Typing() {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			if m.search.Update(msg) {
+				m.wrap()
+				m.find(m.rowLines[m.offset]-1, false)
+			}
+			return m, nil
+		}
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			if !m.search.Active() {
+				return m, tea.Quit
+			}
+			m.search.Clear()
+			m.wrap()
+		case "/":
+			m.search.Start()
+		case "c":
+			if seq, err := eclip.Sequence(ansi.Strip(m.pager.content)); err != nil {
+				m.message = "copy failed: " + err.Error()
+			} else {
+				m.message, m.clipboard = "copied", seq
+			}
+		case "n":
+			m.find(m.rowLines[m.offset], false)
+		case "N":
+			m.find(m.rowLines[m.offset], true)
+		case "up", "k", "y":
+			m.scrollTo(m.offset - 1)
+		case "down", "j", "e", "enter":
+			m.scrollTo(m.offset + 1)
+		case "pgup", "b", "ctrl+b":
+			m.scrollTo(m.offset - m.page())
+		case "pgdown", " ", "f", "ctrl+f":
+			m.scrollTo(m.offset + m.page())
+		case "ctrl+u", "u":
+			m.scrollTo(m.offset - m.page()/2)
+		case "ctrl+d", "d":
+			m.scrollTo(m.offset + m.page()/2)
+		case "home", "g":
+			m.scrollTo(0)
+		case "end", "G":
+			m.scrollTo(len(m.rows))
+		}
+	}
+	return m, nil
+}
+
+// Scroll to the next line matching the search after the line at index
+// from, or the previous one when backward is set.
+func (m *pagerModel) find(from int, backward bool) {
+	if !m.search.Active() {
+		return
+	}
+	line, ok := search.Next(len(m.lines), from, backward, func(i int) bool {
+		return m.search.Match(m.lines[i])
+	})
+	if !ok {
+		m.message = "pattern not found: " + m.search.Query()
+		return
+	}
+	m.scrollTo(m.rowOf(line))
+}
+
+// Wrap the lines of content to the width of the terminal, highlighting the
+// matches of the search.
+func (m *pagerModel) wrap() {
+	m.rows, m.rowLines = nil, nil
+	for i, line := range m.lines {
+		line = m.search.Highlight(line, m.pager.style.MatchStyle)
+		for _, row := range wrapLines(line, m.width) {
+			m.rows = append(m.rows, row)
+			m.rowLines = append(m.rowLines, i)
+		}
+	}
+}
+
+// Returns the index of the first row of the line at index line.
+func (m pagerModel) rowOf(line int) int {
+	for i, l := range m.rowLines {
+		if l >= line {
+			return i
+		}
+	}
+	return len(m.rows)
+}
+
+// Render the status line, with the title, the position in the content and
+// the keys.
+func (m pagerModel) status() string {
+	style := m.pager.style
+	end := min(m.offset+m.page(), len(m.rows))
+	position := fmt.Sprintf(" lines %d-%d/%d ", m.offset+1, end, len(m.rows))
+	if end == len(m.rows) {
+		position += "(END) "
+	} else {
+		position += fmt.Sprintf("%d%% ", end*100/max(len(m.rows), 1))
+	}
+	position += "· " + ekeys.Plain(ekeys.Search, ekeys.Bind("n/N", "next/prev").WithEnabled(m.search.Active()), ekeys.Bind("c", "copy").WithEnabled(eclip.Supported()), ekeys.Quit) + " "
+
+	if m.search.Typing() {
+		prompt := ansi.Truncate(m.search.Prompt(), m.width, "…")
+		return style.StatusStyle.Render(prompt + strings.Repeat(" ", max(m.width-ansi.StringWidth(prompt), 0)))
+	}
+
+	title := ""
+	switch {
+	case m.message != "":
+		title = " " + m.message + " "
+	case m.pager.title != "":
+		title = " " + m.pager.title + " "
+	}
+	title = ansi.Truncate(title, max(m.width-ansi.StringWidth(position), 0), "…")
+	fill := strings.Repeat(" ", max(m.width-ansi.StringWidth(title)-ansi.StringWidth(position), 0))
+	return style.TitleStyle.Render(title) + style.StatusStyle.Render(fill+position)
+}
+
+func (m pagerModel) View() string {
+	if m.height == 0 {
+		return ""
+	}
+	var b strings.Builder
+	end := min(m.offset+m.page(), len(m.rows))
+	for i := m.offset; i < m.offset+m.page(); i++ {
+		if i < end {
+			b.WriteString(m.pager.style.TextStyle.Render(m.rows[i]))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.clipboard + m.status())
+	return b.String()
+}
@@ -0,0 +1,359 @@
+package epager
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// Number of bytes shown on each row of a hex dump.
+const hexRowBytes = 16
+
+// Hex dump style definition.
+type HexStyle struct {
+	OffsetStyle lipgloss.Style
+	ByteStyle   lipgloss.Style
+	// Style of the zero bytes, usually padding
+	ZeroStyle lipgloss.Style
+	// Style of the printable characters of the ASCII column
+	ASCIIStyle lipgloss.Style
+	// Style of the dots standing for the other bytes in the ASCII column
+	DotStyle    lipgloss.Style
+	MarkStyle   lipgloss.Style
+	StatusStyle lipgloss.Style
+	TitleStyle  lipgloss.Style
+}
+
+// Default HexStyle used by HexViewer.
+var HexStyleDefault = hexStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		HexStyleDefault = hexStyle(t)
+	})
+}
+
+// Returns HexStyleDefault for the theme t.
+func hexStyle(t etheme.Theme) HexStyle {
+	return HexStyle{
+		OffsetStyle: lipgloss.NewStyle().Foreground(t.Primary),
+		ByteStyle:   lipgloss.NewStyle(),
+		ZeroStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		ASCIIStyle:  lipgloss.NewStyle().Foreground(t.Accent),
+		DotStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		MarkStyle:   lipgloss.NewStyle().Reverse(true),
+		StatusStyle: lipgloss.NewStyle().Reverse(true),
+		TitleStyle:  lipgloss.NewStyle().Reverse(true).Bold(true),
+	}
+}
+
+// HexViewer shows binary content as a hex dump one screen at a time, with
+// the offset, the hexadecimal and the ASCII columns, like hexdump -C.
+// Only the rows on screen are read.
+type HexViewer struct {
+	r     io.ReaderAt
+	size  int64
+	title string
+	style HexStyle
+}
+
+// Create a new HexViewer of the first size bytes of r.
+//
+//	f, _ := os.Open("firmware.bin")
+//	info, _ := f.Stat()
+//	err := epager.NewHexViewer(f, info.Size()).WithTitle("firmware.bin").Run()
+func NewHexViewer(r io.ReaderAt, size int64) HexViewer {
+	return HexViewer{
+		r:     r,
+		size:  max(size, 0),
+		style: HexStyleDefault,
+	}
+}
+
+// Specify a title rendered in the status line.
+//
+//	v := epager.NewHexViewer(f, size).WithTitle("firmware.bin")
+func (v HexViewer) WithTitle(title string) HexViewer {
+	v.title = title
+	return v
+}
+
+// Specify the style of the HexViewer.
+//
+//	v := epager.NewHexViewer(f, size).WithStyle(epager.HexStyleDefault)
+func (v HexViewer) WithStyle(s HexStyle) HexViewer {
+	v.style = s
+	return v
+}
+
+// Show the first size bytes of r as a hex dump with the default HexViewer,
+// see HexViewer.Run.
+//
+//	if err := epager.HexDump(f, info.Size()); err != nil {
+//		return err
+//	}
+func HexDump(r io.ReaderAt, size int64) error {
+	return NewHexViewer(r, size).Run()
+}
+
+// Render data as a hex dump with the default HexStyle.
+//
+//	fmt.Println(epager.RenderHex(header))
+func RenderHex(data []byte) string {
+	rows := make([]string, 0, (len(data)+hexRowBytes-1)/hexRowBytes)
+	for off := 0; off < len(data); off += hexRowBytes {
+		rows = append(rows, HexStyleDefault.renderRow(int64(off), data[off:min(off+hexRowBytes, len(data))], -1))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// Show the dump one screen at a time until the user quits with q, Esc or
+// Ctrl+C. : prompts for an offset to go to, decimal or hexadecimal with the
+// 0x prefix, and marks the byte at that offset.
+// The dump is printed instead when it fits the terminal or when stdout is
+// not a terminal.
+//
+//	err := epager.NewHexViewer(f, size).Run()
+func (v HexViewer) Run() error {
+	rows := (v.size + hexRowBytes - 1) / hexRowBytes
+	if !eterm.IsTerminal(os.Stdout) {
+		return v.print(false)
+	}
+	if rows < int64(eterm.Height()) {
+		return v.print(true)
+	}
+
+	m := hexModel{viewer: v, rows: rows, mark: -1}
+	final, err := live.Run(tea.NewProgram(m, tea.WithAltScreen()))
+	if err != nil {
+		return err
+	}
+	return final.(hexModel).err
+}
+
+// Print the whole dump, styled when colors is set.
+func (v HexViewer) print(colors bool) error {
+	buf := make([]byte, hexRowBytes*256)
+	for off := int64(0); off < v.size; off += int64(len(buf)) {
+		data, err := v.read(off, buf)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < len(data); i += hexRowBytes {
+			row := v.style.renderRow(off+int64(i), data[i:min(i+hexRowBytes, len(data))], -1)
+			if !colors {
+				row = ansi.Strip(row)
+			}
+			if _, err := fmt.Println(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Read the bytes at offset off into buf, stopping at the size of the
+// content.
+func (v HexViewer) read(off int64, buf []byte) ([]byte, error) {
+	buf = buf[:min(int64(len(buf)), v.size-off)]
+	n, err := v.r.ReadAt(buf, off)
+	if err != nil && !(errors.Is(err, io.EOF) && n > 0) {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// Render a row of the dump starting at offset off. The byte at offset mark,
+// if on the row, is rendered with the MarkStyle.
+func (s HexStyle) renderRow(off int64, data []byte, mark int64) string {
+	var hex, text strings.Builder
+	for i := 0; i < hexRowBytes; i++ {
+		if i == hexRowBytes/2 {
+			hex.WriteString(" ")
+		}
+		if i >= len(data) {
+			hex.WriteString("   ")
+			continue
+		}
+		b := data[i]
+		byteStyle, charStyle, char := s.ByteStyle, s.ASCIIStyle, string(rune(b))
+		if b == 0 {
+			byteStyle = s.ZeroStyle
+		}
+		if b < 0x20 || b > 0x7e {
+			charStyle, char = s.DotStyle, "."
+		}
+		if off+int64(i) == mark {
+			byteStyle, charStyle = s.MarkStyle, s.MarkStyle
+		}
+		hex.WriteString(byteStyle.Render(fmt.Sprintf("%02x", b)) + " ")
+		text.WriteString(charStyle.Render(char))
+	}
+	return s.OffsetStyle.Render(fmt.Sprintf("%08x", off)) + "  " + hex.String() + " " +
+		s.DotStyle.Render("|") + text.String() + s.DotStyle.Render("|")
+}
+
+// Bubbletea model of a running HexViewer.
+type hexModel struct {
+	viewer HexViewer
+	// Number of rows of the dump
+	rows   int64
+	offset int64
+	// Bytes of the rows on screen
+	data   []byte
+	width  int
+	height int
+	// Offset of the byte marked by the last goto, -1 if none
+	mark int64
+	// Offset being typed after :
+	prompting bool
+	input     string
+	message   string
+	err       error
+}
+
+// Number of rows of the dump shown at once, above the status line.
+func (m hexModel) page() int64 {
+	return int64(max(m.height-1, 1))
+}
+
+// Scroll to the row at offset, keeping the last page full, and read the
+// rows on screen.
+func (m *hexModel) scrollTo(offset int64) {
+	m.offset = max(min(offset, m.rows-m.page()), 0)
+	data, err := m.viewer.read(m.offset*hexRowBytes, make([]byte, m.page()*hexRowBytes))
+	if err != nil {
+		// Shown in the status line, and returned by Run
+		m.err, m.message = err, "read error: "+err.Error()
+	}
+	m.data = data
+}
+
+// Go to the offset typed by the user, showing its row at the top.
+func (m *hexModel) jump() {
+	off, err := strconv.ParseInt(strings.TrimSpace(m.input), 0, 64)
+	switch {
+	case err != nil:
+		m.message = "invalid offset: " + m.input
+	case off < 0 || off >= m.viewer.size:
+		m.message = fmt.Sprintf("offset out of range: %#x", off)
+	default:
+		m.mark = off
+		m.scrollTo(off / hexRowBytes)
+	}
+}
+
+func (m hexModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m hexModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollTo(m.offset)
+	case tea.KeyMsg:
+		m.message = ""
+		if m.prompting {
+			switch msg.Type {
+			case tea.KeyCtrlC:
+				return m, tea.Quit
+			case tea.KeyEsc:
+				m.prompting = false
+			case tea.KeyEnter:
+				m.prompting = false
+				if m.input != "" {
+					m.jump()
+				}
+			case tea.KeyBackspace:
+				if len(m.input) > 0 {
+					m.input = m.input[:len(m.input)-1]
+				}
+			case tea.KeyRunes:
+				m.input += string(msg.Runes)
+			}
+			return m, nil
+		}
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case ":":
+			m.prompting, m.input = true, ""
+		case "up", "k", "y":
+			m.scrollTo(m.offset - 1)
+		case "down", "j", "e", "enter":
+			m.scrollTo(m.offset + 1)
+		case "pgup", "b", "ctrl+b":
+			m.scrollTo(m.offset - m.page())
+		case "pgdown", " ", "f", "ctrl+f":
+			m.scrollTo(m.offset + m.page())
+		case "ctrl+u", "u":
+			m.scrollTo(m.offset - m.page()/2)
+		case "ctrl+d", "d":
+			m.scrollTo(m.offset + m.page()/2)
+		case "home", "g":
+			m.scrollTo(0)
+		case "end", "G":
+			m.scrollTo(m.rows)
+		}
+	}
+	return m, nil
+}
+
+// Render the status line, with the title, the position in the content and
+// the keys.
+func (m hexModel) status() string {
+	style := m.viewer.style
+	if m.prompting {
+		prompt := ansi.Truncate(":"+m.input, m.width, "…")
+		return style.StatusStyle.Render(prompt + strings.Repeat(" ", max(m.width-ansi.StringWidth(prompt), 0)))
+	}
+
+	end := min((m.offset+m.page())*hexRowBytes, m.viewer.size)
+	position := fmt.Sprintf(" %#x-%#x/%#x ", m.offset*hexRowBytes, max(end-1, 0), m.viewer.size)
+	if end == m.viewer.size {
+		position += "(END) "
+	} else {
+		position += fmt.Sprintf("%d%% ", end*100/max(m.viewer.size, 1))
+	}
+	position += "· " + ekeys.Plain(ekeys.Bind(":", "goto"), ekeys.Quit) + " "
+
+	title := ""
+	switch {
+	case m.message != "":
+		title = " " + m.message + " "
+	case m.viewer.title != "":
+		title = " " + m.viewer.title + " "
+	}
+	title = ansi.Truncate(title, max(m.width-ansi.StringWidth(position), 0), "…")
+	fill := strings.Repeat(" ", max(m.width-ansi.StringWidth(title)-ansi.StringWidth(position), 0))
+	return style.TitleStyle.Render(title) + style.StatusStyle.Render(fill+position)
+}
+
+func (m hexModel) View() string {
+	if m.height == 0 {
+		return ""
+	}
+	start, data := m.offset*hexRowBytes, m.data
+	var b strings.Builder
+	for i := int64(0); i < m.page(); i++ {
+		if lo := i * hexRowBytes; lo < int64(len(data)) {
+			row := m.viewer.style.renderRow(start+lo, data[lo:min(lo+hexRowBytes, int64(len(data)))], m.mark)
+			b.WriteString(ansi.Truncate(row, m.width, ""))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.status())
+	return b.String()
+}
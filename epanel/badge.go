@@ -0,0 +1,109 @@
+package epanel
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Kind of a badge, selecting its colors.
+type BadgeKind int
+
+const (
+	BadgeNeutral BadgeKind = iota
+	BadgeInfo
+	BadgeSuccess
+	BadgeWarning
+	BadgeError
+)
+
+// Badge style definition, with the style of each kind. Left and Right are
+// rendered around the text, inside the style.
+type BadgeStyle struct {
+	NeutralStyle lipgloss.Style
+	InfoStyle    lipgloss.Style
+	SuccessStyle lipgloss.Style
+	WarningStyle lipgloss.Style
+	ErrorStyle   lipgloss.Style
+	Left         string
+	Right        string
+}
+
+// Default BadgeStyle used by Badge, with the contrast color of the
+// etheme.Theme on a colored background.
+var BadgeStyleDefault = badgeStyle(etheme.Current())
+
+// BadgeStyle with colored text in brackets, without background.
+var BadgeStyleOutline = badgeStyleOutline(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		BadgeStyleDefault = badgeStyle(t)
+		BadgeStyleOutline = badgeStyleOutline(t)
+	})
+}
+
+// Returns BadgeStyleDefault for the theme t.
+func badgeStyle(t etheme.Theme) BadgeStyle {
+	return BadgeStyle{
+		NeutralStyle: lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Surface).Bold(true).Padding(0, 1),
+		InfoStyle:    lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Primary).Bold(true).Padding(0, 1),
+		SuccessStyle: lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Success).Bold(true).Padding(0, 1),
+		WarningStyle: lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Warning).Bold(true).Padding(0, 1),
+		ErrorStyle:   lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Error).Bold(true).Padding(0, 1),
+	}
+}
+
+// Returns BadgeStyleOutline for the theme t.
+func badgeStyleOutline(t etheme.Theme) BadgeStyle {
+	return BadgeStyle{
+		NeutralStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		InfoStyle:    lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		SuccessStyle: lipgloss.NewStyle().Foreground(t.Success).Bold(true),
+		WarningStyle: lipgloss.NewStyle().Foreground(t.Warning).Bold(true),
+		ErrorStyle:   lipgloss.NewStyle().Foreground(t.Error).Bold(true),
+		Left:         "[",
+		Right:        "]",
+	}
+}
+
+// BadgeStyle without colors, with the text in brackets, for terminals and
+// logs with limited support.
+var BadgeStyleASCII = BadgeStyle{
+	NeutralStyle: lipgloss.NewStyle(),
+	InfoStyle:    lipgloss.NewStyle(),
+	SuccessStyle: lipgloss.NewStyle(),
+	WarningStyle: lipgloss.NewStyle(),
+	ErrorStyle:   lipgloss.NewStyle(),
+	Left:         "[",
+	Right:        "]",
+}
+
+// Returns the style of the badges of the given kind.
+func (s BadgeStyle) kind(kind BadgeKind) lipgloss.Style {
+	switch kind {
+	case BadgeInfo:
+		return s.InfoStyle
+	case BadgeSuccess:
+		return s.SuccessStyle
+	case BadgeWarning:
+		return s.WarningStyle
+	case BadgeError:
+		return s.ErrorStyle
+	}
+	return s.NeutralStyle
+}
+
+// Render text as a small colored label of the given kind, styled with
+// BadgeStyleDefault, to be used inline in table cells, prompts and panels.
+//
+//	fmt.Println("api-server", epanel.Badge("PROD", epanel.BadgeError))
+func Badge(text string, kind BadgeKind) string {
+	return BadgeWithStyle(text, kind, BadgeStyleDefault)
+}
+
+// Render text as a label of the given kind like Badge, styled with style.
+//
+//	epanel.BadgeWithStyle("BETA", epanel.BadgeWarning, epanel.BadgeStyleOutline)
+func BadgeWithStyle(text string, kind BadgeKind, style BadgeStyle) string {
+	return style.kind(kind).Render(style.Left + text + style.Right)
+}
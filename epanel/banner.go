@@ -0,0 +1,214 @@
+package epanel
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/ecolor"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Font of the banners rendered by Banner.
+type Font struct {
+	// Renders the pixels of the glyphs two rows per line, with half blocks
+	half bool
+	// Character of the set pixels, when not rendered with half blocks
+	fill string
+}
+
+// Font drawing each pixel with a full block, five lines high.
+var FontBlock = Font{fill: "█"}
+
+// Font drawing two pixels per cell with half blocks, three lines high.
+var FontCompact = Font{half: true}
+
+// Font drawing each pixel with #, five lines high, for terminals and logs
+// with limited support.
+var FontASCII = Font{fill: "#"}
+
+// Banner style definition. When both GradientFrom and GradientTo are set,
+// as hex colors, the text blends from one to the other from left to right
+// instead of using the color of Style.
+type BannerStyle struct {
+	Style        lipgloss.Style
+	GradientFrom lipgloss.Color
+	GradientTo   lipgloss.Color
+}
+
+// Default BannerStyle used by Banner. Uses the primary color of the
+// etheme.Theme.
+var BannerStyleDefault = bannerStyle(etheme.Current())
+
+// BannerStyle blending along the gradient of the etheme.Theme.
+var BannerStyleGradient = bannerStyleGradient(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		BannerStyleDefault = bannerStyle(t)
+		BannerStyleGradient = bannerStyleGradient(t)
+	})
+}
+
+// Returns BannerStyleDefault for the theme t.
+func bannerStyle(t etheme.Theme) BannerStyle {
+	return BannerStyle{
+		Style: lipgloss.NewStyle().Foreground(t.Primary),
+	}
+}
+
+// Returns BannerStyleGradient for the theme t.
+func bannerStyleGradient(t etheme.Theme) BannerStyle {
+	return BannerStyle{
+		Style:        lipgloss.NewStyle(),
+		GradientFrom: t.GradientFrom,
+		GradientTo:   t.GradientTo,
+	}
+}
+
+// Render text in large letters with font and BannerStyleDefault, for the
+// splash screens and the headers of a CLI. Letters, digits and the common
+// punctuation are supported, lowercase letters are rendered uppercase and
+// the other characters as ?. Each line of text renders a line of letters.
+//
+//	fmt.Println(epanel.Banner("myapp", epanel.FontBlock))
+func Banner(text string, font Font) string {
+	return BannerWithStyle(text, font, BannerStyleDefault)
+}
+
+// Render text in large letters like Banner, styled with style.
+//
+//	fmt.Println(epanel.BannerWithStyle("myapp", epanel.FontCompact, epanel.BannerStyleGradient))
+func BannerWithStyle(text string, font Font, style BannerStyle) string {
+	blocks := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		blocks = append(blocks, font.render(bannerPixels(line), style))
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// Returns the rows of pixels of a line of text, set pixels are #.
+func bannerPixels(text string) []string {
+	rows := make([]string, glyphHeight)
+	for i, r := range []rune(strings.ToUpper(text)) {
+		glyph, ok := glyphs[r]
+		if !ok {
+			glyph = glyphs['?']
+			if unicode.IsSpace(r) {
+				glyph = glyphs[' ']
+			}
+		}
+		for row := range rows {
+			if i > 0 {
+				rows[row] += " "
+			}
+			rows[row] += glyph[row]
+		}
+	}
+	return rows
+}
+
+// Render the rows of pixels of a line of text.
+func (f Font) render(pixels []string, style BannerStyle) string {
+	width := 0
+	for _, row := range pixels {
+		width = max(width, len(row))
+	}
+	set := func(row int, col int) bool {
+		return row < len(pixels) && col < len(pixels[row]) && pixels[row][col] == '#'
+	}
+
+	lines := []string{}
+	for row := 0; row < len(pixels); row++ {
+		var b strings.Builder
+		for col := range width {
+			cell := " "
+			switch {
+			case !f.half && set(row, col):
+				cell = f.fill
+			case f.half && set(row, col) && set(row+1, col):
+				cell = "█"
+			case f.half && set(row, col):
+				cell = "▀"
+			case f.half && set(row+1, col):
+				cell = "▄"
+			}
+			if cell == " " {
+				b.WriteString(cell)
+				continue
+			}
+			b.WriteString(style.column(col, width).Render(cell))
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+		if f.half {
+			row++
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the style of the cells of a column of a banner width cells wide.
+func (s BannerStyle) column(col int, width int) lipgloss.Style {
+	if s.GradientFrom == "" || s.GradientTo == "" {
+		return s.Style
+	}
+	color := ecolor.Blend(s.GradientFrom, s.GradientTo, float64(col)/float64(max(width-1, 1)))
+	return s.Style.Foreground(color)
+}
+
+// Height of the glyphs in pixels.
+const glyphHeight = 5
+
+// Pixels of the glyphs of the fonts, set pixels are #.
+var glyphs = map[rune][glyphHeight]string{
+	'A':  {" ### ", "#   #", "#####", "#   #", "#   #"},
+	'B':  {"#### ", "#   #", "#### ", "#   #", "#### "},
+	'C':  {" ####", "#    ", "#    ", "#    ", " ####"},
+	'D':  {"#### ", "#   #", "#   #", "#   #", "#### "},
+	'E':  {"#####", "#    ", "#### ", "#    ", "#####"},
+	'F':  {"#####", "#    ", "#### ", "#    ", "#    "},
+	'G':  {" ####", "#    ", "#  ##", "#   #", " ####"},
+	'H':  {"#   #", "#   #", "#####", "#   #", "#   #"},
+	'I':  {"###", " # ", " # ", " # ", "###"},
+	'J':  {"    #", "    #", "    #", "#   #", " ### "},
+	'K':  {"#   #", "#  # ", "###  ", "#  # ", "#   #"},
+	'L':  {"#    ", "#    ", "#    ", "#    ", "#####"},
+	'M':  {"#   #", "## ##", "# # #", "#   #", "#   #"},
+	'N':  {"#   #", "##  #", "# # #", "#  ##", "#   #"},
+	'O':  {" ### ", "#   #", "#   #", "#   #", " ### "},
+	'P':  {"#### ", "#   #", "#### ", "#    ", "#    "},
+	'Q':  {" ### ", "#   #", "# # #", "#  # ", " ## #"},
+	'R':  {"#### ", "#   #", "#### ", "#  # ", "#   #"},
+	'S':  {" ####", "#    ", " ### ", "    #", "#### "},
+	'T':  {"#####", "  #  ", "  #  ", "  #  ", "  #  "},
+	'U':  {"#   #", "#   #", "#   #", "#   #", " ### "},
+	'V':  {"#   #", "#   #", "#   #", " # # ", "  #  "},
+	'W':  {"#   #", "#   #", "# # #", "## ##", "#   #"},
+	'X':  {"#   #", " # # ", "  #  ", " # # ", "#   #"},
+	'Y':  {"#   #", " # # ", "  #  ", "  #  ", "  #  "},
+	'Z':  {"#####", "   # ", "  #  ", " #   ", "#####"},
+	'0':  {" ### ", "#  ##", "# # #", "##  #", " ### "},
+	'1':  {" # ", "## ", " # ", " # ", "###"},
+	'2':  {" ### ", "#   #", "  ## ", " #   ", "#####"},
+	'3':  {"#### ", "    #", " ### ", "    #", "#### "},
+	'4':  {"#   #", "#   #", "#####", "    #", "    #"},
+	'5':  {"#####", "#    ", "#### ", "    #", "#### "},
+	'6':  {" ### ", "#    ", "#### ", "#   #", " ### "},
+	'7':  {"#####", "    #", "   # ", "  #  ", "  #  "},
+	'8':  {" ### ", "#   #", " ### ", "#   #", " ### "},
+	'9':  {" ### ", "#   #", " ####", "    #", " ### "},
+	' ':  {"   ", "   ", "   ", "   ", "   "},
+	'!':  {"#", "#", "#", " ", "#"},
+	'?':  {" ### ", "#   #", "  ## ", "     ", "  #  "},
+	'.':  {" ", " ", " ", " ", "#"},
+	',':  {"  ", "  ", "  ", " #", "# "},
+	':':  {" ", "#", " ", "#", " "},
+	'\'': {"#", "#", " ", " ", " "},
+	'-':  {"    ", "    ", "####", "    ", "    "},
+	'_':  {"    ", "    ", "    ", "    ", "####"},
+	'+':  {"   ", " # ", "###", " # ", "   "},
+	'=':  {"    ", "####", "    ", "####", "    "},
+	'/':  {"    #", "   # ", "  #  ", " #   ", "#    "},
+	'(':  {" #", "# ", "# ", "# ", " #"},
+	')':  {"# ", " #", " #", " #", "# "},
+}
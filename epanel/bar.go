@@ -0,0 +1,119 @@
+package epanel
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Bar style definition. Style sets the colors of the whole bar, the styles
+// of the sections inherit them. Width is the width of the bar in cells, when
+// 0 it is as wide as the terminal: interactive views should set it from the
+// size of their window.
+type BarStyle struct {
+	Style       lipgloss.Style
+	LeftStyle   lipgloss.Style
+	CenterStyle lipgloss.Style
+	RightStyle  lipgloss.Style
+	Width       int
+}
+
+// BarStyle used by HeaderBar, with the contrast color of the etheme.Theme on
+// a background of its primary color.
+var BarStyleHeader = barStyleHeader(etheme.Current())
+
+// BarStyle used by FooterBar, on the surface color of the etheme.Theme.
+var BarStyleFooter = barStyleFooter(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		BarStyleHeader = barStyleHeader(t)
+		BarStyleFooter = barStyleFooter(t)
+	})
+}
+
+// Returns BarStyleHeader for the theme t.
+func barStyleHeader(t etheme.Theme) BarStyle {
+	return BarStyle{
+		Style:       lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Primary),
+		LeftStyle:   lipgloss.NewStyle().Bold(true),
+		CenterStyle: lipgloss.NewStyle(),
+		RightStyle:  lipgloss.NewStyle(),
+	}
+}
+
+// Returns BarStyleFooter for the theme t.
+func barStyleFooter(t etheme.Theme) BarStyle {
+	return BarStyle{
+		Style:       lipgloss.NewStyle().Background(t.Surface),
+		LeftStyle:   lipgloss.NewStyle(),
+		CenterStyle: lipgloss.NewStyle(),
+		RightStyle:  lipgloss.NewStyle(),
+	}
+}
+
+// Render a bar as wide as the terminal with left, center and right aligned
+// in its three sections, styled with BarStyleHeader, for the context lines
+// at the top of an output or a view. Empty sections are left blank.
+//
+//	fmt.Println(epanel.HeaderBar("myapp", "production", "eu-west-1"))
+func HeaderBar(left string, center string, right string) string {
+	return BarWithStyle(left, center, right, BarStyleHeader)
+}
+
+// Render a bar like HeaderBar, styled with BarStyleFooter, for the status
+// lines at the bottom of a view.
+//
+//	fmt.Println(epanel.FooterBar("3 selected", "", "q quit"))
+func FooterBar(left string, center string, right string) string {
+	return BarWithStyle(left, center, right, BarStyleFooter)
+}
+
+// Render a bar with three sections like HeaderBar, styled with style. When
+// the bar is too narrow the center is truncated first, then the right and
+// the left sections.
+//
+//	style := epanel.BarStyleHeader
+//	style.Width = m.width
+//	header := epanel.BarWithStyle(profile, "", region, style)
+func BarWithStyle(left string, center string, right string, style BarStyle) string {
+	width := style.Width
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	// Cells inside the margin of one cell on each side
+	inner := max(width-2, 0)
+
+	lw, cw, rw := ansi.StringWidth(left), ansi.StringWidth(center), ansi.StringWidth(right)
+	if cw > 0 && lw+cw+rw+2 > inner {
+		center = ansi.Truncate(center, max(inner-lw-rw-2, 0), "…")
+		cw = ansi.StringWidth(center)
+	}
+	if rw > 0 && lw+rw+1 > inner {
+		right = ansi.Truncate(right, max(inner-lw-1, 0), "…")
+		rw = ansi.StringWidth(right)
+	}
+	if lw > inner {
+		left = ansi.Truncate(left, inner, "…")
+		lw = ansi.StringWidth(left)
+	}
+
+	// The center is centered on the bar, moved aside by the other sections
+	start := lw
+	if cw > 0 {
+		start = min(max((inner-cw)/2, lw+1), inner-rw-1-cw)
+	}
+	space := func(n int) string {
+		return style.Style.Render(strings.Repeat(" ", max(n, 0)))
+	}
+	return space(1) +
+		style.LeftStyle.Inherit(style.Style).Render(left) +
+		space(start-lw) +
+		style.CenterStyle.Inherit(style.Style).Render(center) +
+		space(inner-rw-start-cw) +
+		style.RightStyle.Inherit(style.Style).Render(right) +
+		space(width-inner-1)
+}
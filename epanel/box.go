@@ -0,0 +1,154 @@
+package epanel
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etext"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Box style definition. Padding is the number of cells between the border
+// and the content on the left and on the right, VerticalPadding the number
+// of empty lines above and below it. Width is the width of the box in cells,
+// including the border: when 0 the box fits its content, up to the width of
+// the terminal. Wider content is wrapped.
+type BoxStyle struct {
+	Border          lipgloss.Border
+	BorderStyle     lipgloss.Style
+	TitleStyle      lipgloss.Style
+	Padding         int
+	VerticalPadding int
+	Width           int
+}
+
+// Default BoxStyle, with a rounded border. Uses the primary color of the
+// etheme.Theme for the title.
+var BoxStyleDefault = boxStyle(etheme.Current())
+
+// BoxStyle with a rounded border.
+var BoxStyleRounded = boxStyleRounded(etheme.Current())
+
+// BoxStyle with a square border.
+var BoxStyleSquare = boxStyleSquare(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		BoxStyleRounded = boxStyleRounded(t)
+		BoxStyleDefault = boxStyle(t)
+		BoxStyleSquare = boxStyleSquare(t)
+	})
+}
+
+// Returns BoxStyleDefault for the theme t, BoxStyleASCII when the terminal
+// cannot display unicode.
+func boxStyle(t etheme.Theme) BoxStyle {
+	if !eterm.Unicode() {
+		return BoxStyleASCII
+	}
+	return boxStyleRounded(t)
+}
+
+// Returns BoxStyleRounded for the theme t.
+func boxStyleRounded(t etheme.Theme) BoxStyle {
+	return BoxStyle{
+		Border:      lipgloss.RoundedBorder(),
+		BorderStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		TitleStyle:  lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		Padding:     1,
+	}
+}
+
+// Returns BoxStyleSquare for the theme t.
+func boxStyleSquare(t etheme.Theme) BoxStyle {
+	return BoxStyle{
+		Border:      lipgloss.NormalBorder(),
+		BorderStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		TitleStyle:  lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		Padding:     1,
+	}
+}
+
+// BoxStyle without colors nor unicode characters, for terminals and logs
+// with limited support.
+var BoxStyleASCII = BoxStyle{
+	Border: lipgloss.Border{
+		Top:         "-",
+		Bottom:      "-",
+		Left:        "|",
+		Right:       "|",
+		TopLeft:     "+",
+		TopRight:    "+",
+		BottomLeft:  "+",
+		BottomRight: "+",
+	},
+	BorderStyle: lipgloss.NewStyle(),
+	TitleStyle:  lipgloss.NewStyle(),
+	Padding:     1,
+}
+
+// Render content, which may span several lines and be already styled, in a
+// box with title embedded in its top border. An empty title renders a plain
+// border.
+//
+//	fmt.Println(epanel.Box("Summary", report, epanel.BoxStyleDefault))
+//
+//	╭─ Summary ──────────╮
+//	│ 3 services updated │
+//	╰────────────────────╯
+func Box(title string, content string, style BoxStyle) string {
+	padding := max(style.Padding, 0)
+	maxWidth := style.Width
+	if maxWidth <= 0 {
+		maxWidth = eterm.Width()
+	}
+	// Cells between the borders
+	maxInner := max(maxWidth-2, 1)
+	maxContent := max(maxInner-2*padding, 1)
+
+	lines := strings.Split(etext.Wrap(content, maxContent), "\n")
+	contentWidth := 0
+	for _, line := range lines {
+		contentWidth = max(contentWidth, ansi.StringWidth(line))
+	}
+
+	title = etext.Truncate(title, max(maxInner-4, 1))
+	titleWidth := 0
+	if title != "" {
+		titleWidth = ansi.StringWidth(title) + 3
+	}
+
+	inner := max(contentWidth+2*padding, titleWidth, 1)
+	if style.Width > 0 {
+		inner = maxInner
+	}
+	contentWidth = inner - 2*padding
+
+	b := style.Border
+	border := style.BorderStyle.Render
+	rows := []string{}
+
+	top := border(b.TopLeft)
+	if title != "" {
+		top += border(b.Top) + " " + style.TitleStyle.Render(title) + " "
+	}
+	top += border(strings.Repeat(b.Top, inner-titleWidth) + b.TopRight)
+	rows = append(rows, top)
+
+	pad := strings.Repeat(" ", padding)
+	empty := border(b.Left) + strings.Repeat(" ", inner) + border(b.Right)
+	for range style.VerticalPadding {
+		rows = append(rows, empty)
+	}
+	for _, line := range lines {
+		rows = append(rows, border(b.Left)+pad+etext.PadRight(line, contentWidth)+pad+border(b.Right))
+	}
+	for range style.VerticalPadding {
+		rows = append(rows, empty)
+	}
+
+	rows = append(rows, border(b.BottomLeft+strings.Repeat(b.Bottom, inner)+b.BottomRight))
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,141 @@
+package epanel
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etext"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Kind of a callout, selecting its color and glyph.
+type CalloutKind int
+
+const (
+	CalloutInfo CalloutKind = iota
+	CalloutWarning
+	CalloutError
+	CalloutSuccess
+)
+
+// Callout style definition. The style of each kind colors the border and
+// the glyph of the callouts. Width is the width of the callouts in cells,
+// including the border: when 0 they fit their message, up to the width of
+// the terminal.
+type CalloutStyle struct {
+	Border       lipgloss.Border
+	InfoStyle    lipgloss.Style
+	WarningStyle lipgloss.Style
+	ErrorStyle   lipgloss.Style
+	SuccessStyle lipgloss.Style
+	MessageStyle lipgloss.Style
+
+	InfoGlyph    string
+	WarningGlyph string
+	ErrorGlyph   string
+	SuccessGlyph string
+
+	Width int
+}
+
+// Default CalloutStyle used by Info, Warning, Error and Success.
+var CalloutStyleDefault = calloutStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		CalloutStyleDefault = calloutStyle(t)
+	})
+}
+
+// Returns CalloutStyleDefault for the theme t.
+func calloutStyle(t etheme.Theme) CalloutStyle {
+	return CalloutStyle{
+		Border:       lipgloss.RoundedBorder(),
+		InfoStyle:    lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		WarningStyle: lipgloss.NewStyle().Foreground(t.Warning).Bold(true),
+		ErrorStyle:   lipgloss.NewStyle().Foreground(t.Error).Bold(true),
+		SuccessStyle: lipgloss.NewStyle().Foreground(t.Success).Bold(true),
+		MessageStyle: lipgloss.NewStyle(),
+
+		InfoGlyph:    "i",
+		WarningGlyph: "!",
+		ErrorGlyph:   "✗",
+		SuccessGlyph: "✓",
+	}
+}
+
+// Returns the style and the glyph of the callouts of the given kind.
+func (s CalloutStyle) kind(kind CalloutKind) (lipgloss.Style, string) {
+	switch kind {
+	case CalloutWarning:
+		return s.WarningStyle, s.WarningGlyph
+	case CalloutError:
+		return s.ErrorStyle, s.ErrorGlyph
+	case CalloutSuccess:
+		return s.SuccessStyle, s.SuccessGlyph
+	}
+	return s.InfoStyle, s.InfoGlyph
+}
+
+// Render msg in a box colored by kind, after its glyph. The message is
+// wrapped to the width of the callout, aligned after the glyph.
+//
+//	fmt.Println(epanel.Callout(epanel.CalloutWarning, "config.yaml is deprecated", style))
+func Callout(kind CalloutKind, msg string, style CalloutStyle) string {
+	kindStyle, glyph := style.kind(kind)
+	box := BoxStyle{
+		Border:      style.Border,
+		BorderStyle: kindStyle.UnsetBold(),
+		Padding:     1,
+		Width:       style.Width,
+	}
+
+	width := style.Width
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	// Cells left for the message after the border, the padding and the glyph
+	indent := ansi.StringWidth(glyph) + 1
+	msgWidth := max(width-4-indent, 1)
+
+	lines := strings.Split(etext.Wrap(msg, msgWidth), "\n")
+	for i, line := range lines {
+		prefix := strings.Repeat(" ", indent)
+		if i == 0 {
+			prefix = kindStyle.Render(glyph) + " "
+		}
+		lines[i] = prefix + style.MessageStyle.Render(line)
+	}
+	return Box("", strings.Join(lines, "\n"), box)
+}
+
+// Render an informative callout with CalloutStyleDefault, for notices that
+// plain log lines can't convey.
+//
+//	fmt.Println(epanel.Info("A new version is available: run brew upgrade myapp"))
+func Info(msg string) string {
+	return Callout(CalloutInfo, msg, CalloutStyleDefault)
+}
+
+// Render a warning callout with CalloutStyleDefault.
+//
+//	fmt.Println(epanel.Warning("The staging cluster is read-only until Monday"))
+func Warning(msg string) string {
+	return Callout(CalloutWarning, msg, CalloutStyleDefault)
+}
+
+// Render an error callout with CalloutStyleDefault.
+//
+//	fmt.Println(epanel.Error("Your license expired on 2024-01-31"))
+func Error(msg string) string {
+	return Callout(CalloutError, msg, CalloutStyleDefault)
+}
+
+// Render a success callout with CalloutStyleDefault.
+//
+//	fmt.Println(epanel.Success("Deployed to production"))
+func Success(msg string) string {
+	return Callout(CalloutSuccess, msg, CalloutStyleDefault)
+}
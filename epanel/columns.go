@@ -0,0 +1,102 @@
+package epanel
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/etext"
+)
+
+// ColumnLayout renders blocks of text, like tables, panels and lists, side
+// by side.
+type ColumnLayout struct {
+	blocks []string
+	gap    int
+	widths []int
+	align  lipgloss.Position
+}
+
+// Create a new ColumnLayout of the given rendered blocks, from left to
+// right, separated by a gap of 2 cells. Each column is as wide as its block
+// and the shorter blocks are aligned at the top.
+//
+//	fmt.Println(epanel.Columns(
+//		epanel.Box("Nodes", nodes.Render(), epanel.BoxStyleDefault),
+//		epanel.Box("Pods", pods.Render(), epanel.BoxStyleDefault),
+//	).Render())
+func Columns(blocks ...string) ColumnLayout {
+	return ColumnLayout{
+		blocks: blocks,
+		gap:    2,
+		align:  lipgloss.Top,
+	}
+}
+
+// Specify the number of cells between the columns.
+//
+//	c := epanel.Columns(left, right).WithGap(4)
+func (c ColumnLayout) WithGap(gap int) ColumnLayout {
+	c.gap = max(gap, 0)
+	return c
+}
+
+// Specify the width of the columns in cells, in order. The lines of the
+// blocks wider than their column are truncated. A width of 0 or less, or a
+// missing one, fits the column to its block.
+//
+//	c := epanel.Columns(menu, content).WithWidths(20, 60)
+func (c ColumnLayout) WithWidths(widths ...int) ColumnLayout {
+	c.widths = widths
+	return c
+}
+
+// Specify the vertical position of the blocks shorter than the tallest one:
+// lipgloss.Top, lipgloss.Center or lipgloss.Bottom.
+//
+//	c := epanel.Columns(logo, details).WithVerticalAlign(lipgloss.Center)
+func (c ColumnLayout) WithVerticalAlign(pos lipgloss.Position) ColumnLayout {
+	c.align = pos
+	return c
+}
+
+// Render the columns.
+func (c ColumnLayout) Render() string {
+	columns := make([][]string, len(c.blocks))
+	widths := make([]int, len(c.blocks))
+	height := 0
+	for i, block := range c.blocks {
+		columns[i] = strings.Split(block, "\n")
+		if i < len(c.widths) && c.widths[i] > 0 {
+			widths[i] = c.widths[i]
+		} else {
+			for _, line := range columns[i] {
+				widths[i] = max(widths[i], ansi.StringWidth(line))
+			}
+		}
+		height = max(height, len(columns[i]))
+	}
+
+	rows := make([]string, height)
+	gap := strings.Repeat(" ", c.gap)
+	for i, lines := range columns {
+		top := int(float64(height-len(lines)) * float64(c.align))
+		for row := range rows {
+			line := ""
+			if row >= top && row-top < len(lines) {
+				line = etext.Truncate(lines[row-top], widths[i])
+			}
+			if i > 0 {
+				rows[row] += gap
+			}
+			rows[row] += line
+			if i < len(columns)-1 {
+				rows[row] += strings.Repeat(" ", widths[i]-etext.Width(line))
+			}
+		}
+	}
+	for row := range rows {
+		rows[row] = strings.TrimRight(rows[row], " ")
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,114 @@
+package epanel
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Divider style definition. Align is the position of the label along the
+// rule: lipgloss.Left, lipgloss.Center or lipgloss.Right. Width is the width
+// of the rule in cells, when 0 it is as wide as the terminal.
+type DividerStyle struct {
+	RuleStyle  lipgloss.Style
+	LabelStyle lipgloss.Style
+	Rule       string
+	Align      lipgloss.Position
+	Width      int
+}
+
+// Default DividerStyle used by Divider, a thin rule with the label on the
+// left. Uses the primary color of the etheme.Theme for the label.
+var DividerStyleDefault = dividerStyle(etheme.Current())
+
+// DividerStyle with a double rule and the label centered.
+var DividerStyleDouble = dividerStyleDouble(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		DividerStyleDefault = dividerStyle(t)
+		DividerStyleDouble = dividerStyleDouble(t)
+	})
+}
+
+// Returns DividerStyleDefault for the theme t, DividerStyleASCII when the
+// terminal cannot display unicode.
+func dividerStyle(t etheme.Theme) DividerStyle {
+	if !eterm.Unicode() {
+		return DividerStyleASCII
+	}
+	return DividerStyle{
+		RuleStyle:  lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		LabelStyle: lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		Rule:       "─",
+		Align:      lipgloss.Left,
+	}
+}
+
+// Returns DividerStyleDouble for the theme t.
+func dividerStyleDouble(t etheme.Theme) DividerStyle {
+	return DividerStyle{
+		RuleStyle:  lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		LabelStyle: lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		Rule:       "═",
+		Align:      lipgloss.Center,
+	}
+}
+
+// DividerStyle without colors nor unicode characters, for terminals and
+// logs with limited support.
+var DividerStyleASCII = DividerStyle{
+	RuleStyle:  lipgloss.NewStyle(),
+	LabelStyle: lipgloss.NewStyle(),
+	Rule:       "-",
+	Align:      lipgloss.Left,
+}
+
+// Render a rule as wide as the terminal with label on its left, styled with
+// DividerStyleDefault, to separate the sections of a long output. An empty
+// label renders a plain rule.
+//
+//	fmt.Println(epanel.Divider("Results"))
+//
+//	── Results ─────────────────────────────
+func Divider(label string) string {
+	return DividerWithStyle(label, DividerStyleDefault)
+}
+
+// Render a rule with label like Divider, styled with style.
+//
+//	fmt.Println(epanel.DividerWithStyle("Summary", epanel.DividerStyleDouble))
+func DividerWithStyle(label string, style DividerStyle) string {
+	width := style.Width
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	rule := style.Rule
+	if rule == "" {
+		rule = "─"
+	}
+	ruleWidth := max(ansi.StringWidth(rule), 1)
+	fill := func(cells int) string {
+		return style.RuleStyle.Render(strings.Repeat(rule, max(cells, 0)/ruleWidth))
+	}
+
+	if label == "" {
+		return fill(width)
+	}
+	// The label keeps at least two rule cells on each side
+	label = ansi.Truncate(label, max(width-6, 1), "…")
+	rest := width - ansi.StringWidth(label) - 2
+
+	left := 2
+	switch {
+	case style.Align == lipgloss.Right:
+		left = rest - 2
+	case style.Align != lipgloss.Left:
+		left = int(float64(rest) * float64(style.Align))
+	}
+	left = min(max(left, 0), max(rest, 0))
+	return fill(left) + " " + style.LabelStyle.Render(label) + " " + fill(rest-left)
+}
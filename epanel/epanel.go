@@ -0,0 +1,3 @@
+// Package epanel renders static layouts, like boxes, banners and columns,
+// composing the output of the other components.
+package epanel
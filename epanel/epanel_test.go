@@ -0,0 +1,39 @@
+package epanel_test
+
+import (
+	"testing"
+
+	"github.com/ravvio/easycli-ui/epanel"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etest"
+)
+
+func TestBox(t *testing.T) {
+	etest.Setup(t)
+	style := epanel.BoxStyleDefault
+	style.Width = 30
+	etest.Golden(t, epanel.Box("Summary", "3 services updated\n1 service failed to start in time", style))
+}
+
+func TestBoxASCII(t *testing.T) {
+	term := etest.TerminalDefault
+	term.Unicode = false
+	etest.SetupTerminal(t, term)
+	etest.Golden(t, epanel.Box("Summary", "3 services updated", epanel.BoxStyleDefault))
+}
+
+func TestDivider(t *testing.T) {
+	term := etest.TerminalDefault
+	term.Width = 40
+	term.Colors = eterm.Colors16
+	etest.SetupTerminal(t, term)
+	etest.Golden(t, epanel.Divider("Results"))
+}
+
+func TestBar(t *testing.T) {
+	term := etest.TerminalDefault
+	term.Width = 40
+	term.Colors = eterm.Colors16
+	etest.SetupTerminal(t, term)
+	etest.Golden(t, epanel.HeaderBar("myapp", "production", "eu-west-1"))
+}
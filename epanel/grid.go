@@ -0,0 +1,198 @@
+package epanel
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+// A cell of a GridLayout, spanning rowSpan rows and colSpan columns from
+// row and col.
+type gridCell struct {
+	row     int
+	col     int
+	rowSpan int
+	colSpan int
+	lines   []string
+}
+
+// GridLayout places rendered blocks, like tables, panels and charts, in the
+// cells of a grid of rows and columns. The columns share the width of the
+// grid evenly and each row is as tall as its tallest cell.
+type GridLayout struct {
+	rows   int
+	cols   int
+	cells  []gridCell
+	width  int
+	height int
+	hgap   int
+	vgap   int
+}
+
+// Create a new GridLayout of rows by cols cells, as wide as the terminal,
+// with a gap of 2 cells between the columns and no gap between the rows.
+//
+//	g := epanel.Grid(2, 2).
+//		WithCellSpan(0, 0, 1, 2, epanel.Banner("status", epanel.FontCompact)).
+//		WithCell(1, 0, nodes).
+//		WithCell(1, 1, pods)
+//	fmt.Println(g.Render())
+func Grid(rows int, cols int) GridLayout {
+	return GridLayout{
+		rows: max(rows, 1),
+		cols: max(cols, 1),
+		hgap: 2,
+	}
+}
+
+// Place a rendered block in the cell at row and col, counting from 0. The
+// cells must not overlap, cells outside of the grid are ignored.
+//
+//	g := epanel.Grid(1, 2).WithCell(0, 1, table.Render())
+func (g GridLayout) WithCell(row int, col int, content string) GridLayout {
+	return g.WithCellSpan(row, col, 1, 1, content)
+}
+
+// Place a rendered block in a cell spanning rowSpan rows and colSpan
+// columns from row and col, see WithCell. The spans are limited to the
+// size of the grid.
+//
+//	g := epanel.Grid(2, 3).WithCellSpan(0, 0, 2, 1, menu)
+func (g GridLayout) WithCellSpan(row int, col int, rowSpan int, colSpan int, content string) GridLayout {
+	if row < 0 || col < 0 || row >= g.rows || col >= g.cols {
+		return g
+	}
+	g.cells = append(g.cells[:len(g.cells):len(g.cells)], gridCell{
+		row:     row,
+		col:     col,
+		rowSpan: min(max(rowSpan, 1), g.rows-row),
+		colSpan: min(max(colSpan, 1), g.cols-col),
+		lines:   strings.Split(content, "\n"),
+	})
+	return g
+}
+
+// Specify the size of the grid in cells. A width of 0 or less uses the
+// width of the terminal. When height is greater than 0 the rows are
+// stretched evenly to fill it, they are never shrunk.
+//
+//	g := epanel.Grid(2, 2).WithSize(120, 0)
+func (g GridLayout) WithSize(width int, height int) GridLayout {
+	g.width = width
+	g.height = height
+	return g
+}
+
+// Specify the number of cells between the columns and the number of lines
+// between the rows.
+//
+//	g := epanel.Grid(2, 2).WithGap(4, 1)
+func (g GridLayout) WithGap(horizontal int, vertical int) GridLayout {
+	g.hgap = max(horizontal, 0)
+	g.vgap = max(vertical, 0)
+	return g
+}
+
+// Width in cells of the columns of the grid, in order, to render blocks
+// fitting their cells.
+//
+//	box := epanel.BoxStyleDefault
+//	box.Width = g.ColumnWidths()[0]
+func (g GridLayout) ColumnWidths() []int {
+	width := g.width
+	if width <= 0 {
+		width = eterm.Width()
+	}
+	available := max(width-g.hgap*(g.cols-1), g.cols)
+	widths := make([]int, g.cols)
+	for i := range widths {
+		widths[i] = available / g.cols
+		if i < available%g.cols {
+			widths[i]++
+		}
+	}
+	return widths
+}
+
+// Returns the sum of the sizes from start to end, with the gaps between.
+func spanSize(sizes []int, start int, end int, gap int) int {
+	size := gap * (end - start - 1)
+	for _, s := range sizes[start:end] {
+		size += s
+	}
+	return size
+}
+
+// Height in lines of the rows of the grid, in order.
+func (g GridLayout) rowHeights() []int {
+	heights := make([]int, g.rows)
+	for _, cell := range g.cells {
+		if cell.rowSpan == 1 {
+			heights[cell.row] = max(heights[cell.row], len(cell.lines))
+		}
+	}
+	// The last row spanned by a cell grows to fit it
+	for _, cell := range g.cells {
+		end := cell.row + cell.rowSpan
+		if missing := len(cell.lines) - spanSize(heights, cell.row, end, g.vgap); missing > 0 {
+			heights[end-1] += missing
+		}
+	}
+
+	total := spanSize(heights, 0, g.rows, g.vgap)
+	for i := 0; total < g.height; i = (i + 1) % g.rows {
+		heights[i]++
+		total++
+	}
+	return heights
+}
+
+// Render the grid.
+func (g GridLayout) Render() string {
+	widths := g.ColumnWidths()
+	heights := g.rowHeights()
+
+	// Offset of the rows and the columns
+	tops := make([]int, g.rows)
+	for i := 1; i < g.rows; i++ {
+		tops[i] = tops[i-1] + heights[i-1] + g.vgap
+	}
+	lefts := make([]int, g.cols)
+	for i := 1; i < g.cols; i++ {
+		lefts[i] = lefts[i-1] + widths[i-1] + g.hgap
+	}
+
+	lines := make([]string, spanSize(heights, 0, g.rows, g.vgap))
+	for y := range lines {
+		var b strings.Builder
+		x := 0
+		for col := 0; col < g.cols; col++ {
+			cell, ok := g.cellAt(y, col, tops, heights)
+			if !ok {
+				continue
+			}
+			line := ""
+			if y-tops[cell.row] < len(cell.lines) {
+				line = ansi.Truncate(cell.lines[y-tops[cell.row]], spanSize(widths, col, col+cell.colSpan, g.hgap), "…")
+			}
+			b.WriteString(strings.Repeat(" ", lefts[col]-x))
+			b.WriteString(line)
+			x = lefts[col] + ansi.StringWidth(line)
+			col += cell.colSpan - 1
+		}
+		lines[y] = strings.TrimRight(b.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the cell starting at column col drawn on line y of the grid.
+func (g GridLayout) cellAt(y int, col int, tops []int, heights []int) (gridCell, bool) {
+	for _, cell := range g.cells {
+		top := tops[cell.row]
+		if cell.col == col && y >= top && y < top+spanSize(heights, cell.row, cell.row+cell.rowSpan, g.vgap) {
+			return cell, true
+		}
+	}
+	return gridCell{}, false
+}
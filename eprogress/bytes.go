@@ -0,0 +1,338 @@
+package eprogress
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/internal/live"
+	"github.com/ravvio/easycli-ui/internal/units"
+)
+
+// Tracks the bytes flowing through a ByteReader or a ByteWriter and renders
+// them on a single line, or forwards them to a ReportFunc.
+type byteMeter struct {
+	title    string
+	style    ProgressStyle
+	interval time.Duration
+	events   io.Writer
+	unwatch  func(finished []barSnapshot)
+	report   ReportFunc
+	counter  counter
+	frame    int
+	once     sync.Once
+	start    time.Time
+	stop     chan error
+	stopped  chan struct{}
+	closed   sync.Once
+}
+
+func newByteMeter(size int64) *byteMeter {
+	m := &byteMeter{
+		title:    "Transferring",
+		style:    ProgressStyleDefault,
+		interval: plainIntervalDefault,
+		stop:     make(chan error, 1),
+		stopped:  make(chan struct{}),
+	}
+	m.counter.total.Store(size)
+	return m
+}
+
+// Count n more bytes, starting the rendering on the first call.
+func (m *byteMeter) add(n int) {
+	m.once.Do(m.begin)
+	done := m.counter.done.Add(int64(n))
+	if m.report != nil {
+		m.report(done, m.counter.total.Load())
+	}
+}
+
+func (m *byteMeter) begin() {
+	m.start = time.Now()
+	m.unwatch = watchEvents(m.events, func() []barSnapshot {
+		return []barSnapshot{m.sample(false, nil)}
+	})
+	if m.report != nil {
+		close(m.stopped)
+		return
+	}
+	go m.draw()
+}
+
+// Mark the transfer as finished, err is nil when it completed successfully.
+func (m *byteMeter) finish(err error) {
+	m.once.Do(m.begin)
+	m.closed.Do(func() {
+		m.stop <- err
+		<-m.stopped
+		m.unwatch([]barSnapshot{m.sample(true, err)})
+	})
+}
+
+// Returns the state of the transfer.
+func (m *byteMeter) sample(finished bool, err error) barSnapshot {
+	return barSnapshot{
+		label:    m.title,
+		done:     m.counter.done.Load(),
+		total:    m.counter.total.Load(),
+		finished: finished,
+		err:      err,
+	}
+}
+
+// Redraw the line of the transfer until it finishes. When the output is not
+// a terminal a plain line is printed every interval instead.
+func (m *byteMeter) draw() {
+	defer close(m.stopped)
+
+	if !eterm.IsTerminal(os.Stdout) {
+		err := runPlain(m.interval, m.renderPlain, m.stop)
+		fmt.Println(m.renderResult(err))
+		return
+	}
+
+	// Lines logged meanwhile are printed above the bar, which is redrawn
+	var mu sync.Mutex
+	line := ""
+	defer live.Push(func(l string) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Print("\r" + ansi.EraseEntireLine + l + "\n" + line)
+	})()
+
+	ticker := time.NewTicker(progressRefresh)
+	defer ticker.Stop()
+	for {
+		mu.Lock()
+		line = m.render()
+		fmt.Print("\r" + ansi.EraseEntireLine + line)
+		mu.Unlock()
+		m.frame++
+		select {
+		case err := <-m.stop:
+			mu.Lock()
+			defer mu.Unlock()
+			line = m.renderResult(err) + "\n"
+			fmt.Print("\r" + ansi.EraseEntireLine + line)
+			line = ""
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Render the progress of the transfer as a plain line.
+func (m *byteMeter) renderPlain() string {
+	done, total := m.counter.done.Load(), m.counter.total.Load()
+	rate := units.Bytes(m.rate(done)) + "/s"
+	if total <= 0 {
+		return fmt.Sprintf("%s %s · %s", m.title, units.Bytes(done), rate)
+	}
+	return fmt.Sprintf("%s %.0f%% (%s/%s) · %s", m.title, ratio(done, total)*100, units.Bytes(done), units.Bytes(total), rate)
+}
+
+// Render the bar of the transfer, followed by its size, rate and ETA.
+func (m *byteMeter) render() string {
+	done, total := m.counter.done.Load(), m.counter.total.Load()
+	rate := m.rate(done)
+	bar := renderBar(m.style, m.style.TitleStyle.Render(m.title), done, total, m.frame)
+	if total <= 0 {
+		return fmt.Sprintf("%s  %s · %s/s", bar, units.Bytes(done), units.Bytes(rate))
+	}
+
+	eta := "--"
+	if rate > 0 {
+		eta = (time.Duration(max(total-done, 0)/rate) * time.Second).String()
+	}
+	return fmt.Sprintf("%s  %s/%s · %s/s · ETA %s", bar, units.Bytes(done), units.Bytes(total), units.Bytes(rate), eta)
+}
+
+// Render the final line of the transfer.
+func (m *byteMeter) renderResult(err error) string {
+	if err != nil {
+		return renderResult(m.style, m.title, err)
+	}
+	done := m.counter.done.Load()
+	elapsed := time.Since(m.start).Round(time.Second / 10)
+	return renderResult(m.style, fmt.Sprintf("%s (%s in %s)", m.title, units.Bytes(done), elapsed), nil)
+}
+
+// Average number of bytes transferred per second.
+func (m *byteMeter) rate(done int64) int64 {
+	elapsed := time.Since(m.start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return int64(float64(done) / elapsed)
+}
+
+// ByteReader wraps an io.Reader, rendering the progress of the bytes read
+// from it. The rendering starts with the first read and stops at the end of
+// the stream, on a read error or when the ByteReader is closed.
+type ByteReader struct {
+	r     io.Reader
+	meter *byteMeter
+}
+
+// Wrap r, of size bytes, to render the progress of reading it. A size of 0
+// or less means the size is unknown.
+//
+//	resp, err := http.Get(url)
+//	...
+//	body := eprogress.Reader(resp.Body, resp.ContentLength).WithTitle("Downloading")
+//	defer body.Close()
+//	_, err = io.Copy(f, body)
+func Reader(r io.Reader, size int64) *ByteReader {
+	return &ByteReader{r: r, meter: newByteMeter(size)}
+}
+
+func (r *ByteReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.meter.add(n)
+	switch {
+	case errors.Is(err, io.EOF):
+		r.meter.finish(nil)
+	case err != nil:
+		r.meter.finish(err)
+	}
+	return n, err
+}
+
+// Stop the rendering and close the wrapped reader, if it is an io.Closer.
+func (r *ByteReader) Close() error {
+	r.meter.finish(nil)
+	if c, ok := r.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Specify the title rendered next to the bar of the ByteReader.
+//
+//	body := eprogress.Reader(resp.Body, resp.ContentLength).WithTitle("Downloading")
+func (r *ByteReader) WithTitle(title string) *ByteReader {
+	r.meter.title = title
+	return r
+}
+
+// Specify the interval between two lines reporting the progress of the
+// ByteReader when the output is not a terminal.
+//
+//	body := eprogress.Reader(...).WithInterval(30 * time.Second)
+func (r *ByteReader) WithInterval(d time.Duration) *ByteReader {
+	r.meter.interval = d
+	return r
+}
+
+// Write the progress of the ByteReader as JSON lines to w, see
+// ProgressModel.WithEvents.
+func (r *ByteReader) WithEvents(w io.Writer) *ByteReader {
+	r.meter.events = w
+	return r
+}
+
+// Specify the style of the ByteReader.
+//
+//	body := eprogress.Reader(...).WithStyle(eprogress.ProgressStyleDefault)
+func (r *ByteReader) WithStyle(s ProgressStyle) *ByteReader {
+	r.meter.style = s
+	return r
+}
+
+// Forward the progress of the ByteReader to report instead of rendering it,
+// to update the bar of Run or a Bar of a MultiProgress.
+//
+//	err := eprogress.Run("Downloading", func(report eprogress.ReportFunc) error {
+//		_, err := io.Copy(f, eprogress.Reader(resp.Body, resp.ContentLength).WithReport(report))
+//		return err
+//	})
+func (r *ByteReader) WithReport(report ReportFunc) *ByteReader {
+	r.meter.report = report
+	return r
+}
+
+// ByteWriter wraps an io.Writer, rendering the progress of the bytes
+// written to it. The rendering starts with the first write and stops when
+// size bytes have been written, on a write error or when the ByteWriter is
+// closed.
+type ByteWriter struct {
+	w     io.Writer
+	meter *byteMeter
+}
+
+// Wrap w, expecting size bytes, to render the progress of writing them. A
+// size of 0 or less means the size is unknown.
+//
+//	w := eprogress.Writer(conn, info.Size()).WithTitle("Uploading")
+//	defer w.Close()
+//	_, err = io.Copy(w, f)
+func Writer(w io.Writer, size int64) *ByteWriter {
+	return &ByteWriter{w: w, meter: newByteMeter(size)}
+}
+
+func (w *ByteWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.meter.add(n)
+	total := w.meter.counter.total.Load()
+	switch {
+	case err != nil:
+		w.meter.finish(err)
+	case total > 0 && w.meter.counter.done.Load() >= total:
+		w.meter.finish(nil)
+	}
+	return n, err
+}
+
+// Stop the rendering and close the wrapped writer, if it is an io.Closer.
+func (w *ByteWriter) Close() error {
+	w.meter.finish(nil)
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Specify the title rendered next to the bar of the ByteWriter.
+//
+//	w := eprogress.Writer(conn, size).WithTitle("Uploading")
+func (w *ByteWriter) WithTitle(title string) *ByteWriter {
+	w.meter.title = title
+	return w
+}
+
+// Specify the interval between two lines reporting the progress of the
+// ByteWriter when the output is not a terminal.
+//
+//	w := eprogress.Writer(...).WithInterval(30 * time.Second)
+func (w *ByteWriter) WithInterval(d time.Duration) *ByteWriter {
+	w.meter.interval = d
+	return w
+}
+
+// Write the progress of the ByteWriter as JSON lines to w, see
+// ProgressModel.WithEvents.
+func (w *ByteWriter) WithEvents(out io.Writer) *ByteWriter {
+	w.meter.events = out
+	return w
+}
+
+// Specify the style of the ByteWriter.
+//
+//	w := eprogress.Writer(...).WithStyle(eprogress.ProgressStyleDefault)
+func (w *ByteWriter) WithStyle(s ProgressStyle) *ByteWriter {
+	w.meter.style = s
+	return w
+}
+
+// Forward the progress of the ByteWriter to report instead of rendering it,
+// see ByteReader.WithReport.
+func (w *ByteWriter) WithReport(report ReportFunc) *ByteWriter {
+	w.meter.report = report
+	return w
+}
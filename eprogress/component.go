@@ -0,0 +1,126 @@
+package eprogress
+
+import (
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Identifiers of the BarModels, to route their messages.
+var lastBarID atomic.Int64
+
+// ProgressMsg updates the progress of the BarModel with the same ID.
+type ProgressMsg struct {
+	ID    int64
+	Done  int64
+	Total int64
+}
+
+// FrameMsg advances the animation of the indeterminate BarModel with the
+// same ID.
+type FrameMsg struct {
+	ID  int64
+	tag int
+}
+
+// BarModel is a progress bar to embed in an existing bubbletea program,
+// forward it the messages of the program and render its View.
+//
+//	type model struct {
+//		bar eprogress.BarModel
+//	}
+//
+//	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+//		var cmd tea.Cmd
+//		m.bar, cmd = m.bar.Update(msg)
+//		return m, cmd
+//	}
+type BarModel struct {
+	id    int64
+	title string
+	style ProgressStyle
+	done  int64
+	total int64
+	frame int
+	tag   int
+}
+
+// Create a new BarModel.
+func NewBar(title string) BarModel {
+	return BarModel{
+		id:    lastBarID.Add(1),
+		title: title,
+		style: ProgressStyleDefault,
+	}
+}
+
+// Specify the style of the BarModel.
+//
+//	bar := eprogress.NewBar("Uploading").WithStyle(eprogress.ProgressStyleGradient)
+func (m BarModel) WithStyle(s ProgressStyle) BarModel {
+	m.style = s
+	return m
+}
+
+// Identifier of the BarModel, set in its messages.
+func (m BarModel) ID() int64 {
+	return m.id
+}
+
+// Returns the message updating the BarModel to done out of total units of
+// work, to send to the program from any goroutine.
+//
+//	program.Send(m.bar.Report(written, size))
+func (m BarModel) Report(done int64, total int64) ProgressMsg {
+	return ProgressMsg{ID: m.id, Done: done, Total: total}
+}
+
+// Returns the completed fraction of the BarModel, between 0 and 1.
+func (m BarModel) Percent() float64 {
+	return ratio(m.done, m.total)
+}
+
+// Start the animation of the BarModel while its total is unknown.
+func (m BarModel) Init() tea.Cmd {
+	if m.total > 0 {
+		return nil
+	}
+	return m.nextFrame()
+}
+
+func (m BarModel) nextFrame() tea.Cmd {
+	id, tag := m.id, m.tag
+	return tea.Tick(progressRefresh, func(time.Time) tea.Msg {
+		return FrameMsg{ID: id, tag: tag}
+	})
+}
+
+func (m BarModel) Update(msg tea.Msg) (BarModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ProgressMsg:
+		if msg.ID != m.id {
+			return m, nil
+		}
+		// Restart the animation when the total becomes unknown again, the
+		// messages of the previous one are ignored thanks to the tag
+		restart := m.total > 0 && msg.Total <= 0
+		m.done = msg.Done
+		m.total = msg.Total
+		if restart {
+			m.tag++
+			return m, m.nextFrame()
+		}
+	case FrameMsg:
+		if msg.ID != m.id || msg.tag != m.tag || m.total > 0 {
+			return m, nil
+		}
+		m.frame++
+		return m, m.nextFrame()
+	}
+	return m, nil
+}
+
+func (m BarModel) View() string {
+	return renderBar(m.style, m.style.TitleStyle.Render(m.title), m.done, m.total, m.frame)
+}
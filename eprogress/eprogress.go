@@ -0,0 +1,288 @@
+package eprogress
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// ErrInterrupted is returned when a progress bar is stopped with Ctrl+C,
+// SIGINT or SIGTERM.
+var ErrInterrupted = eterm.ErrInterrupted
+
+// Interval between two redraws of a progress bar.
+const progressRefresh = 100 * time.Millisecond
+
+// The bubbletea.Msg sent when the progress bar should be redrawn
+type progressMsgTick struct{}
+
+// The bubbletea.Msg sent when the task of the progress bar returns
+type progressMsgStop struct {
+	err error
+}
+
+// Report the progress of a task, done out of total units of work.
+type ReportFunc = func(done int64, total int64)
+
+type ProgressTask = func(report ReportFunc) error
+
+// Progress reported by a task, safe to update from any goroutine.
+type counter struct {
+	done  atomic.Int64
+	total atomic.Int64
+}
+
+func (c *counter) report(done int64, total int64) {
+	c.done.Store(done)
+	c.total.Store(total)
+}
+
+// Bubbletea model of the progress bar, contains the task to execute
+type ProgressModel struct {
+	title    string
+	task     ProgressTask
+	counter  *counter
+	style    ProgressStyle
+	interval time.Duration
+	events   io.Writer
+	notify   bool
+	done     int64
+	total    int64
+	frame    int
+	finished bool
+	err      error
+}
+
+// Create a new ProgressModel.
+func NewProgress(title string, task ProgressTask) ProgressModel {
+	return ProgressModel{
+		title:    title,
+		task:     task,
+		counter:  &counter{},
+		style:    ProgressStyleDefault,
+		interval: plainIntervalDefault,
+		err:      nil,
+	}
+}
+
+func (m ProgressModel) Init() tea.Cmd {
+	return tea.Batch(
+		tick(),
+		func() tea.Msg {
+			return progressMsgStop{err: m.task(m.counter.report)}
+		},
+	)
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(progressRefresh, func(time.Time) tea.Msg {
+		return progressMsgTick{}
+	})
+}
+
+func (m ProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.finished = true
+			m.err = ErrInterrupted
+			return m, tea.Quit
+		}
+	case progressMsgTick:
+		if m.finished {
+			return m, nil
+		}
+		m.sync()
+		m.frame++
+		return m, tick()
+	case progressMsgStop:
+		m.sync()
+		m.finished = true
+		m.err = msg.err
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// Copy the progress reported by the task into the model.
+func (m *ProgressModel) sync() {
+	m.done = m.counter.done.Load()
+	m.total = m.counter.total.Load()
+}
+
+func (m ProgressModel) View() string {
+	return m.render() + "\n"
+}
+
+// Render the line of the ProgressModel.
+func (m ProgressModel) render() string {
+	if !m.finished {
+		return renderBar(m.style, m.style.TitleStyle.Render(m.title), m.done, m.total, m.frame)
+	}
+	return renderResult(m.style, m.title, m.err)
+}
+
+// Render the final line of a task given its error.
+func renderResult(style ProgressStyle, title string, err error) string {
+	switch {
+	case errors.Is(err, ErrInterrupted):
+		return style.FailureStyle.Render(fmt.Sprintf("* %s ... Cancelled", title))
+	case err != nil:
+		return style.FailureStyle.Render(fmt.Sprintf("* %s ... Failed: %v", title, err))
+	}
+	return style.SuccessStyle.Render(fmt.Sprintf("* %s ... Done", title))
+}
+
+// Returns the completed fraction of a task, between 0 and 1.
+func ratio(done int64, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return min(max(float64(done)/float64(total), 0), 1)
+}
+
+func (m ProgressModel) Err() error {
+	return m.err
+}
+
+// Specify the style of the ProgressModel.
+//
+//	p := eprogress.NewProgress(...).WithStyle(eprogress.ProgressStyleDefault)
+func (m ProgressModel) WithStyle(s ProgressStyle) ProgressModel {
+	m.style = s
+	return m
+}
+
+// Specify the width of the bar of the ProgressModel, in cells.
+//
+//	p := eprogress.NewProgress(...).WithWidth(40)
+func (m ProgressModel) WithWidth(w int) ProgressModel {
+	m.style.Width = w
+	return m
+}
+
+// Specify the interval between two lines reporting the progress when the
+// output is not a terminal.
+//
+//	p := eprogress.NewProgress(...).WithInterval(30 * time.Second)
+func (m ProgressModel) WithInterval(d time.Duration) ProgressModel {
+	m.interval = d
+	return m
+}
+
+// Write the progress of the ProgressModel as JSON lines to w, one Event
+// per change, while the bar is drawn. Useful to let wrappers and GUIs track
+// the task.
+//
+//	p := eprogress.NewProgress(...).WithEvents(os.Stderr)
+func (m ProgressModel) WithEvents(w io.Writer) ProgressModel {
+	m.events = w
+	return m
+}
+
+// Notify the user when the task of the ProgressModel finishes: the bell
+// rings and a desktop notification reports the outcome, while the task runs
+// its progress is shown in the progress indicator of the terminal, see
+// enotify. Nothing is notified when the task is interrupted.
+//
+//	p := eprogress.NewProgress(...).WithNotify(true)
+func (m ProgressModel) WithNotify(n bool) ProgressModel {
+	m.notify = n
+	return m
+}
+
+// Run the ProgressModel until its task returns.
+// When the output is not a terminal, like a CI log, the progress is printed
+// as a plain line every interval, see WithInterval, followed by the outcome.
+func (p *ProgressModel) Run() error {
+	counter, title := p.counter, p.title
+	stopEvents := watchEvents(p.events, func() []barSnapshot {
+		return []barSnapshot{{label: title, done: counter.done.Load(), total: counter.total.Load()}}
+	})
+	stopNotify := watchNotify(p.notify, title, func() (int64, int64) {
+		return counter.done.Load(), counter.total.Load()
+	})
+	err := p.run()
+	stopNotify(err)
+	stopEvents([]barSnapshot{{label: title, done: p.done, total: p.total, finished: true, err: p.err}})
+	return err
+}
+
+func (p *ProgressModel) run() error {
+	if !eterm.IsTerminal(os.Stdout) {
+		done := make(chan error, 1)
+		go func() {
+			done <- p.task(p.counter.report)
+		}()
+		p.err = runPlain(p.interval, func() string {
+			return formatPlain(p.title, p.counter.done.Load(), p.counter.total.Load())
+		}, done)
+		p.sync()
+		p.finished = true
+		fmt.Print(p.View())
+		return p.err
+	}
+
+	final, err := runLive(*p)
+	if err != nil {
+		return err
+	}
+	*p = final.(ProgressModel)
+	return p.err
+}
+
+// Catch SIGINT and SIGTERM until the returned function is called, so that
+// the bars can render their final state when interrupted.
+func catchInterrupts() (<-chan os.Signal, func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	return sig, func() {
+		signal.Stop(sig)
+	}
+}
+
+// Run the model m until it quits. SIGINT and SIGTERM are forwarded to it as
+// Ctrl+C, which stops it as cancelled.
+func runLive(m tea.Model) (tea.Model, error) {
+	sig, stop := catchInterrupts()
+	defer stop()
+
+	tp := tea.NewProgram(m, tea.WithoutSignalHandler())
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-sig:
+			tp.Send(tea.KeyMsg{Type: tea.KeyCtrlC})
+		case <-finished:
+		}
+	}()
+	return live.Run(tp)
+}
+
+// Run task displaying a progress bar, task calls report to update it.
+// While the reported total is 0 or less the bar is indeterminate, a block
+// bounces across it until the total is known.
+// On SIGINT, SIGTERM or Ctrl+C the bar is marked as cancelled and
+// ErrInterrupted is returned.
+//
+//	err := eprogress.Run("Downloading", func(report eprogress.ReportFunc) error {
+//		for i := range 100 {
+//			// ...
+//			report(int64(i+1), 100)
+//		}
+//		return nil
+//	})
+func Run(title string, task ProgressTask) error {
+	p := NewProgress(title, task)
+	return p.Run()
+}
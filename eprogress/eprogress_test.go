@@ -0,0 +1,67 @@
+package eprogress
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderBar(t *testing.T) {
+	style := ProgressStyleASCII
+	style.Width = 10
+	tests := []struct {
+		name    string
+		percent PercentPlacement
+		done    int64
+		total   int64
+		frame   int
+		want    string
+	}{
+		{"empty", PercentRight, 0, 100, 0, "t ----------   0%"},
+		{"half", PercentRight, 50, 100, 0, "t #####-----  50%"},
+		{"over", PercentRight, 150, 100, 0, "t ########## 100%"},
+		{"left", PercentLeft, 30, 100, 0, "t  30% ###-------"},
+		{"hidden", PercentHidden, 30, 100, 0, "t ###-------"},
+		{"unknown", PercentRight, 5, 0, 0, "t ##--------     "},
+		{"bounce", PercentRight, 5, 0, 3, "t ---##-----     "},
+		{"bounce back", PercentRight, 5, 0, 10, "t ------##--     "},
+	}
+	for _, tt := range tests {
+		style.Percent = tt.percent
+		if got := renderBar(style, "t", tt.done, tt.total, tt.frame); got != tt.want {
+			t.Errorf("%s: renderBar = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderResult(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "* t ... Done"},
+		{errors.New("boom"), "* t ... Failed: boom"},
+		{ErrInterrupted, "* t ... Cancelled"},
+	}
+	for _, tt := range tests {
+		if got := renderResult(ProgressStyleASCII, "t", tt.err); got != tt.want {
+			t.Errorf("renderResult(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestProgressInterrupt(t *testing.T) {
+	m := NewProgress("t", nil).WithStyle(ProgressStyleASCII)
+	// runLive forwards SIGINT and SIGTERM as Ctrl+C
+	final, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Error("Ctrl+C did not quit")
+	}
+	if got := final.(ProgressModel).View(); got != "* t ... Cancelled\n" {
+		t.Errorf("View() after Ctrl+C = %q", got)
+	}
+	if err := final.(ProgressModel).Err(); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Err() after Ctrl+C = %v, want ErrInterrupted", err)
+	}
+}
@@ -0,0 +1,115 @@
+package eprogress
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// Event describes the progress of a task, it is written as a JSON line to
+// the writer given to WithEvents. Done and Total are bytes for the
+// ByteReader and the ByteWriter, Rate is in units per second and ETA in
+// seconds. Status is one of running, done, failed and cancelled.
+type Event struct {
+	Label   string  `json:"label"`
+	Status  string  `json:"status"`
+	Done    int64   `json:"done"`
+	Total   int64   `json:"total"`
+	Percent float64 `json:"percent"`
+	Rate    float64 `json:"rate"`
+	ETA     float64 `json:"eta"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// Writes the Events of the tasks sampled by a progress display.
+type eventWriter struct {
+	enc    *json.Encoder
+	starts map[string]time.Time
+	last   map[string]Event
+}
+
+func newEventWriter(w io.Writer) *eventWriter {
+	return &eventWriter{
+		enc:    json.NewEncoder(w),
+		starts: make(map[string]time.Time),
+		last:   make(map[string]Event),
+	}
+}
+
+// Write the Event of a sampled task, unless it did not change since the
+// previous one.
+func (w *eventWriter) write(b barSnapshot) {
+	now := time.Now()
+	start, ok := w.starts[b.label]
+	if !ok {
+		start = now
+		w.starts[b.label] = now
+	}
+
+	e := Event{
+		Label:   b.label,
+		Status:  "running",
+		Done:    b.done,
+		Total:   b.total,
+		Percent: ratio(b.done, b.total) * 100,
+	}
+	switch {
+	case errors.Is(b.err, ErrInterrupted):
+		e.Status = "cancelled"
+	case b.err != nil:
+		e.Status = "failed"
+		e.Error = b.err.Error()
+	case b.finished:
+		e.Status = "done"
+	}
+
+	last, ok := w.last[b.label]
+	if ok && last.Done == e.Done && last.Total == e.Total && last.Status == e.Status {
+		return
+	}
+	if elapsed := now.Sub(start).Seconds(); elapsed > 0 {
+		e.Rate = float64(b.done) / elapsed
+	}
+	if e.Rate > 0 && b.total > 0 {
+		e.ETA = float64(max(b.total-b.done, 0)) / e.Rate
+	}
+	w.last[b.label] = e
+	_ = w.enc.Encode(e)
+}
+
+// Write the Events of the tasks returned by sample every refresh, in the
+// background, until the returned function is called with the result of the
+// run. Returns a no-op when w is nil.
+func watchEvents(w io.Writer, sample func() []barSnapshot) func(finished []barSnapshot) {
+	if w == nil {
+		return func([]barSnapshot) {}
+	}
+
+	events := newEventWriter(w)
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(progressRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				for _, b := range sample() {
+					events.write(b)
+				}
+			}
+		}
+	}()
+
+	return func(finished []barSnapshot) {
+		close(stop)
+		<-stopped
+		for _, b := range finished {
+			events.write(b)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package eprogress
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+// The bubbletea.Msg sent when the task of a MultiProgress returns
+type multiMsgStop struct {
+	err error
+}
+
+// A bar of a MultiProgress, tracking a single unit of work.
+type Bar struct {
+	manager  *Manager
+	label    string
+	counter  counter
+	finished bool
+	err      error
+}
+
+// Report the progress of the work tracked by the Bar. It can be passed
+// wherever a ReportFunc is expected.
+func (b *Bar) Report(done int64, total int64) {
+	b.counter.report(done, total)
+}
+
+// Mark the work tracked by the Bar as finished, removing it from the display.
+// A non nil err is reported when the MultiProgress completes.
+func (b *Bar) Done(err error) {
+	if total := b.counter.total.Load(); err == nil && total > 0 {
+		b.counter.done.Store(total)
+	}
+
+	b.manager.mu.Lock()
+	defer b.manager.mu.Unlock()
+	b.err = err
+	b.finished = true
+}
+
+// Manager gives the task of a MultiProgress access to its bars.
+type Manager struct {
+	mu   sync.Mutex
+	bars []*Bar
+}
+
+// Add a bar with the given label and total, displayed until Bar.Done is
+// called. Safe to call from any goroutine.
+//
+//	bar := m.Add("image.iso", size)
+//	defer bar.Done(nil)
+func (m *Manager) Add(label string, total int64) *Bar {
+	b := &Bar{manager: m, label: label}
+	b.counter.total.Store(total)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bars = append(m.bars, b)
+	return b
+}
+
+// State of a Bar at a given time.
+type barSnapshot struct {
+	label    string
+	done     int64
+	total    int64
+	finished bool
+	err      error
+}
+
+// Returns the state of all the bars added so far.
+func (m *Manager) snapshot() []barSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	bars := make([]barSnapshot, len(m.bars))
+	for i, b := range m.bars {
+		bars[i] = barSnapshot{
+			label:    b.label,
+			done:     b.counter.done.Load(),
+			total:    b.counter.total.Load(),
+			finished: b.finished,
+			err:      b.err,
+		}
+	}
+	return bars
+}
+
+// MultiProgress renders a bar for every unit of work in progress, like the
+// workers of a pool or parallel downloads, below an aggregate bar.
+type MultiProgress struct {
+	title    string
+	task     func(m *Manager) error
+	style    ProgressStyle
+	interval time.Duration
+	events   io.Writer
+}
+
+// Create a new MultiProgress, task adds bars to the Manager as work starts.
+//
+//	p := eprogress.NewMultiProgress("Downloading", func(m *eprogress.Manager) error {
+//		var wg sync.WaitGroup
+//		for _, f := range files {
+//			wg.Go(func() {
+//				bar := m.Add(f.Name, f.Size)
+//				bar.Done(download(f, bar.Report))
+//			})
+//		}
+//		wg.Wait()
+//		return nil
+//	})
+func NewMultiProgress(title string, task func(m *Manager) error) MultiProgress {
+	return MultiProgress{
+		title:    title,
+		task:     task,
+		style:    ProgressStyleDefault,
+		interval: plainIntervalDefault,
+	}
+}
+
+// Specify the style of the MultiProgress.
+//
+//	p := eprogress.NewMultiProgress(...).WithStyle(eprogress.ProgressStyleDefault)
+func (p MultiProgress) WithStyle(s ProgressStyle) MultiProgress {
+	p.style = s
+	return p
+}
+
+// Specify the width of the bars of the MultiProgress, in cells.
+//
+//	p := eprogress.NewMultiProgress(...).WithWidth(40)
+func (p MultiProgress) WithWidth(w int) MultiProgress {
+	p.style.Width = w
+	return p
+}
+
+// Specify the interval between two lines reporting the aggregate progress
+// when the output is not a terminal.
+//
+//	p := eprogress.NewMultiProgress(...).WithInterval(30 * time.Second)
+func (p MultiProgress) WithInterval(d time.Duration) MultiProgress {
+	p.interval = d
+	return p
+}
+
+// Write the aggregate progress of the MultiProgress and the one of its
+// bars as JSON lines to w, see ProgressModel.WithEvents.
+//
+//	p := eprogress.NewMultiProgress(...).WithEvents(os.Stderr)
+func (p MultiProgress) WithEvents(w io.Writer) MultiProgress {
+	p.events = w
+	return p
+}
+
+// Run the task of the MultiProgress until it returns. The returned error
+// joins the error of the task and the ones the bars were marked done with.
+// When the output is not a terminal the aggregate progress is printed as a
+// plain line every interval, see WithInterval.
+// On SIGINT, SIGTERM or Ctrl+C the display is stopped and ErrInterrupted
+// is returned.
+func (p MultiProgress) Run() error {
+	m := multiModel{progress: p, manager: &Manager{}}
+	manager := m.manager
+	stopEvents := watchEvents(p.events, func() []barSnapshot {
+		return multiModel{progress: p, bars: manager.snapshot()}.samples()
+	})
+
+	if !eterm.IsTerminal(os.Stdout) {
+		done := make(chan error, 1)
+		go func() {
+			done <- p.task(m.manager)
+		}()
+		m.err = runPlain(p.interval, func() string {
+			m.bars = m.manager.snapshot()
+			n, total, finished := m.aggregate()
+			return formatPlain(fmt.Sprintf("%s %d/%d", p.title, finished, len(m.bars)), n, total)
+		}, done)
+		m.finished = true
+	} else {
+		final, err := runLive(m)
+		if err != nil {
+			return err
+		}
+		m = final.(multiModel)
+	}
+
+	m.bars = m.manager.snapshot()
+	stopEvents(m.samples())
+	fmt.Print(m.summary())
+
+	errs := []error{m.err}
+	for _, b := range m.bars {
+		if b.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", b.label, b.err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Bubbletea model of a running MultiProgress.
+type multiModel struct {
+	progress MultiProgress
+	manager  *Manager
+	bars     []barSnapshot
+	frame    int
+	finished bool
+	err      error
+}
+
+func (m multiModel) Init() tea.Cmd {
+	return tea.Batch(
+		tick(),
+		func() tea.Msg {
+			return multiMsgStop{err: m.progress.task(m.manager)}
+		},
+	)
+}
+
+func (m multiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.finished = true
+			m.err = ErrInterrupted
+			return m, tea.Quit
+		}
+	case progressMsgTick:
+		if m.finished {
+			return m, nil
+		}
+		m.bars = m.manager.snapshot()
+		m.frame++
+		return m, tick()
+	case multiMsgStop:
+		m.finished = true
+		m.err = msg.err
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m multiModel) View() string {
+	// The final state is printed by Run, after the latest snapshot
+	if m.finished {
+		return ""
+	}
+
+	style := m.progress.style
+	done, total, finished := m.aggregate()
+	active := make([]barSnapshot, 0)
+	for _, b := range m.bars {
+		if !b.finished {
+			active = append(active, b)
+		}
+	}
+
+	title := style.TitleStyle.Render(fmt.Sprintf("%s %d/%d", m.progress.title, finished, len(m.bars)))
+	lines := []string{renderBar(style, title, done, total, m.frame)}
+
+	width := 0
+	for _, b := range active {
+		width = max(width, lipgloss.Width(b.label))
+	}
+	for _, b := range active {
+		label := "  " + b.label + strings.Repeat(" ", width-lipgloss.Width(b.label))
+		lines = append(lines, renderBar(style, label, b.done, b.total, m.frame))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Returns the aggregate progress followed by the one of every bar, as
+// written by WithEvents.
+func (m multiModel) samples() []barSnapshot {
+	done, total, _ := m.aggregate()
+	aggregate := barSnapshot{label: m.progress.title, done: done, total: total, finished: m.finished, err: m.err}
+	return append([]barSnapshot{aggregate}, m.bars...)
+}
+
+// Returns the progress of all the bars and the number of finished ones.
+func (m multiModel) aggregate() (done int64, total int64, finished int) {
+	for _, b := range m.bars {
+		done += b.done
+		total += b.total
+		if b.finished {
+			finished++
+		}
+	}
+	return done, total, finished
+}
+
+// Render the final state of a MultiProgress: its outcome and the failed bars.
+func (m multiModel) summary() string {
+	failed := 0
+	for _, b := range m.bars {
+		if b.err != nil {
+			failed++
+		}
+	}
+	err := m.err
+	if err == nil && failed > 0 {
+		err = fmt.Errorf("%d of %d failed", failed, len(m.bars))
+	}
+
+	lines := []string{renderResult(m.progress.style, m.progress.title, err)}
+	for _, b := range m.bars {
+		if b.err != nil {
+			lines = append(lines, "  "+renderResult(m.progress.style, b.label, b.err))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
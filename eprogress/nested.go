@@ -0,0 +1,283 @@
+package eprogress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/internal/units"
+)
+
+// The bubbletea.Msg sent when the task of a NestedProgress returns
+type nestedMsgStop struct {
+	err error
+}
+
+// NestedReporter lets the task of a NestedProgress report which item it is
+// processing and the progress of that item.
+type NestedReporter struct {
+	mu    sync.Mutex
+	item  int
+	label string
+	child counter
+}
+
+// Start processing the next item, of size bytes. A size of 0 or less means
+// the size is unknown.
+func (r *NestedReporter) Item(label string, size int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.item++
+	r.label = label
+	r.child.report(0, size)
+}
+
+// Report the progress of the current item, in bytes. It can be passed
+// wherever a ReportFunc is expected.
+func (r *NestedReporter) Report(done int64, total int64) {
+	r.child.report(done, total)
+}
+
+// State of a NestedReporter at a given time.
+type nestedSnapshot struct {
+	item  int
+	label string
+	done  int64
+	total int64
+}
+
+func (r *NestedReporter) snapshot() nestedSnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return nestedSnapshot{
+		item:  r.item,
+		label: r.label,
+		done:  r.child.done.Load(),
+		total: r.child.total.Load(),
+	}
+}
+
+// NestedProgress renders an overall bar counting the items processed by a
+// task, above a bar for the bytes of the current item.
+type NestedProgress struct {
+	title    string
+	items    int
+	task     func(r *NestedReporter) error
+	style    ProgressStyle
+	interval time.Duration
+	events   io.Writer
+}
+
+// Create a new NestedProgress for a task processing items items.
+//
+//	p := eprogress.NewNestedProgress("Uploading", len(files), func(r *eprogress.NestedReporter) error {
+//		for _, f := range files {
+//			r.Item(f.Name, f.Size)
+//			if err := upload(f, r.Report); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	})
+func NewNestedProgress(title string, items int, task func(r *NestedReporter) error) NestedProgress {
+	return NestedProgress{
+		title:    title,
+		items:    items,
+		task:     task,
+		style:    ProgressStyleDefault,
+		interval: plainIntervalDefault,
+	}
+}
+
+// Specify the style of the NestedProgress.
+//
+//	p := eprogress.NewNestedProgress(...).WithStyle(eprogress.ProgressStyleDefault)
+func (p NestedProgress) WithStyle(s ProgressStyle) NestedProgress {
+	p.style = s
+	return p
+}
+
+// Specify the width of the bars of the NestedProgress, in cells.
+//
+//	p := eprogress.NewNestedProgress(...).WithWidth(40)
+func (p NestedProgress) WithWidth(w int) NestedProgress {
+	p.style.Width = w
+	return p
+}
+
+// Specify the interval between two lines reporting the progress when the
+// output is not a terminal.
+//
+//	p := eprogress.NewNestedProgress(...).WithInterval(30 * time.Second)
+func (p NestedProgress) WithInterval(d time.Duration) NestedProgress {
+	p.interval = d
+	return p
+}
+
+// Write the progress of the items and of the current item as JSON lines to
+// w, see ProgressModel.WithEvents.
+//
+//	p := eprogress.NewNestedProgress(...).WithEvents(os.Stderr)
+func (p NestedProgress) WithEvents(w io.Writer) NestedProgress {
+	p.events = w
+	return p
+}
+
+// Run the task of the NestedProgress until it returns.
+// When the output is not a terminal the progress is printed as a plain line
+// every interval, see WithInterval.
+// On SIGINT, SIGTERM or Ctrl+C the display is stopped and ErrInterrupted
+// is returned.
+func (p NestedProgress) Run() error {
+	m := nestedModel{progress: p, reporter: &NestedReporter{}}
+	reporter := m.reporter
+	stopEvents := watchEvents(p.events, func() []barSnapshot {
+		return nestedModel{progress: p, state: reporter.snapshot()}.samples()
+	})
+
+	if !eterm.IsTerminal(os.Stdout) {
+		done := make(chan error, 1)
+		go func() {
+			done <- p.task(m.reporter)
+		}()
+		m.err = runPlain(p.interval, func() string {
+			m.state = m.reporter.snapshot()
+			return m.renderPlain()
+		}, done)
+		m.finished = true
+	} else {
+		final, err := runLive(m)
+		if err != nil {
+			return err
+		}
+		m = final.(nestedModel)
+	}
+
+	m.state = m.reporter.snapshot()
+	stopEvents(m.samples())
+	fmt.Println(renderResult(p.style, fmt.Sprintf("%s %d/%d", p.title, m.completed(), p.items), m.err))
+	return m.err
+}
+
+// Bubbletea model of a running NestedProgress.
+type nestedModel struct {
+	progress NestedProgress
+	reporter *NestedReporter
+	state    nestedSnapshot
+	frame    int
+	finished bool
+	err      error
+}
+
+func (m nestedModel) Init() tea.Cmd {
+	return tea.Batch(
+		tick(),
+		func() tea.Msg {
+			return nestedMsgStop{err: m.progress.task(m.reporter)}
+		},
+	)
+}
+
+func (m nestedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.finished = true
+			m.err = ErrInterrupted
+			return m, tea.Quit
+		}
+	case progressMsgTick:
+		if m.finished {
+			return m, nil
+		}
+		m.state = m.reporter.snapshot()
+		m.frame++
+		return m, tick()
+	case nestedMsgStop:
+		m.finished = true
+		m.err = msg.err
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// Number of items completed so far.
+func (m nestedModel) completed() int {
+	if m.finished && m.err == nil {
+		return m.progress.items
+	}
+	return max(m.state.item-1, 0)
+}
+
+// Returns the overall progress, counting the current item for its fraction,
+// in thousandths of items.
+func (m nestedModel) overall() (int64, int64) {
+	done := int64(m.completed()) * 1000
+	if m.completed() < m.progress.items {
+		done += int64(ratio(m.state.done, m.state.total) * 1000)
+	}
+	return done, int64(m.progress.items) * 1000
+}
+
+func (m nestedModel) View() string {
+	// The final state is printed by Run, after the latest snapshot
+	if m.finished {
+		return ""
+	}
+
+	style := m.progress.style
+	done, total := m.overall()
+	title := style.TitleStyle.Render(fmt.Sprintf("%s %d/%d", m.progress.title, max(m.state.item, 1), m.progress.items))
+	s := renderBar(style, title, done, total, m.frame) + "\n"
+	if m.state.item > 0 {
+		s += renderBar(style, "  "+m.state.label, m.state.done, m.state.total, m.frame) + "  " + m.renderBytes() + "\n"
+	}
+	return s
+}
+
+// Render the size of the current item and how much of it was processed.
+func (m nestedModel) renderBytes() string {
+	if m.state.total <= 0 {
+		return units.Bytes(m.state.done)
+	}
+	return units.Bytes(m.state.done) + "/" + units.Bytes(m.state.total)
+}
+
+// Render the progress as a plain line.
+func (m nestedModel) renderPlain() string {
+	s := fmt.Sprintf("%s %d/%d", m.progress.title, max(m.state.item, 1), m.progress.items)
+	if m.state.item == 0 {
+		return s
+	}
+	if m.state.total <= 0 {
+		return fmt.Sprintf("%s %s %s", s, m.state.label, m.renderBytes())
+	}
+	return fmt.Sprintf("%s %s %.0f%% (%s)", s, m.state.label, ratio(m.state.done, m.state.total)*100, m.renderBytes())
+}
+
+// Returns the progress of the items followed by the one of the current item,
+// as written by WithEvents.
+func (m nestedModel) samples() []barSnapshot {
+	items := barSnapshot{
+		label:    m.progress.title,
+		done:     int64(m.completed()),
+		total:    int64(m.progress.items),
+		finished: m.finished,
+		err:      m.err,
+	}
+	if m.state.item == 0 {
+		return []barSnapshot{items}
+	}
+	item := barSnapshot{
+		label:    m.state.label,
+		done:     m.state.done,
+		total:    m.state.total,
+		finished: m.finished,
+		err:      m.err,
+	}
+	return []barSnapshot{items, item}
+}
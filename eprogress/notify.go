@@ -0,0 +1,53 @@
+package eprogress
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ravvio/easycli-ui/enotify"
+)
+
+// Show the progress returned by sample in the progress indicator of the
+// terminal every refresh, in the background, until the returned function is
+// called with the result of the run. The function then clears the
+// indicator and notifies the outcome of the task title, unless it was
+// interrupted. Returns a no-op when enabled is false.
+func watchNotify(enabled bool, title string, sample func() (done int64, total int64)) func(err error) {
+	if !enabled {
+		return func(error) {}
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(progressRefresh)
+		defer ticker.Stop()
+		// The indicator is written again only when it changes
+		state, percent := enotify.ProgressNone, 0
+		for {
+			s, p := enotify.ProgressIndeterminate, 0
+			if done, total := sample(); total > 0 {
+				s, p = enotify.ProgressNormal, int(ratio(done, total)*100)
+			}
+			if s != state || p != percent {
+				state, percent = s, p
+				enotify.SetProgress(s, p)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func(err error) {
+		close(stop)
+		<-stopped
+		enotify.ClearProgress()
+		if !errors.Is(err, ErrInterrupted) {
+			enotify.Finished(title, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package eprogress
+
+import (
+	"fmt"
+	"time"
+)
+
+// Default interval between two lines printed when the output is not a
+// terminal.
+const plainIntervalDefault = 5 * time.Second
+
+// Format the progress of a task as a plain line, like "Copying 45% (450/1000)".
+func formatPlain(title string, done int64, total int64) string {
+	if total <= 0 {
+		return fmt.Sprintf("%s %d", title, done)
+	}
+	return fmt.Sprintf("%s %.0f%% (%d/%d)", title, ratio(done, total)*100, done, total)
+}
+
+// Print the line returned by line every interval, if it changed since the
+// last one, until done receives the result of the task.
+func runPlain(interval time.Duration, line func() string, done <-chan error) error {
+	if interval <= 0 {
+		interval = plainIntervalDefault
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := ""
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-ticker.C:
+			if l := line(); l != last {
+				fmt.Println(l)
+				last = l
+			}
+		}
+	}
+}
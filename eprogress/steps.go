@@ -0,0 +1,278 @@
+package eprogress
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// The bubbletea.Msg sent when the task of a StepsModel returns
+type stepsMsgStop struct {
+	err error
+}
+
+// Steps style definition
+type StepsStyle struct {
+	CompletedStyle lipgloss.Style
+	CurrentStyle   lipgloss.Style
+	PendingStyle   lipgloss.Style
+	FailedStyle    lipgloss.Style
+	SeparatorStyle lipgloss.Style
+	// Markers of the steps in the vertical layout
+	CompletedMarker string
+	CurrentMarker   string
+	PendingMarker   string
+	FailedMarker    string
+	// Separator of the steps in the horizontal layout
+	Separator string
+	// Number the steps of the horizontal layout with circled digits, like
+	// ①, instead of (1)
+	Circled bool
+}
+
+var StepsStyleDefault = stepsStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		StepsStyleDefault = stepsStyle(t)
+		StepsStyleASCII = stepsStyleASCII(t)
+	})
+}
+
+// Returns StepsStyleDefault for the theme t, marking the steps with ASCII
+// characters when the terminal cannot display unicode.
+func stepsStyle(t etheme.Theme) StepsStyle {
+	s := StepsStyle{
+		CompletedStyle:  lipgloss.NewStyle().Foreground(t.Success),
+		CurrentStyle:    lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		PendingStyle:    lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		FailedStyle:     lipgloss.NewStyle().Foreground(t.Error).Bold(true),
+		SeparatorStyle:  lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		CompletedMarker: "✓",
+		CurrentMarker:   "▸",
+		PendingMarker:   "○",
+		FailedMarker:    "✗",
+		Separator:       " ─ ",
+		Circled:         true,
+	}
+	if !eterm.Unicode() {
+		s.CompletedMarker, s.CurrentMarker, s.PendingMarker, s.FailedMarker = "+", ">", "-", "x"
+		s.Separator, s.Circled = " - ", false
+	}
+	return s
+}
+
+// StepsStyle marking the steps with ASCII characters.
+var StepsStyleASCII = stepsStyleASCII(etheme.Current())
+
+// Returns StepsStyleASCII for the theme t.
+func stepsStyleASCII(t etheme.Theme) StepsStyle {
+	s := stepsStyle(t)
+	s.CompletedMarker, s.CurrentMarker, s.PendingMarker, s.FailedMarker = "+", ">", "-", "x"
+	s.Separator, s.Circled = " - ", false
+	return s
+}
+
+// StepHandle lets the task of a StepsModel advance through its steps.
+type StepHandle struct {
+	current atomic.Int64
+}
+
+// Mark the current step as completed and start the next one.
+func (h *StepHandle) Next() {
+	h.current.Add(1)
+}
+
+// Index of the current step, starting from 0.
+func (h *StepHandle) Current() int {
+	return int(h.current.Load())
+}
+
+// StepsModel renders the steps of a pipeline, marking the completed, the
+// current and the pending ones.
+type StepsModel struct {
+	steps    []string
+	task     func(h *StepHandle) error
+	handle   *StepHandle
+	style    StepsStyle
+	vertical bool
+	current  int
+	finished bool
+	err      error
+}
+
+// Create a new StepsModel with the given step labels.
+//
+//	s := eprogress.Steps([]string{"Build", "Test", "Deploy"})
+func Steps(steps []string) StepsModel {
+	return StepsModel{
+		steps:  steps,
+		handle: &StepHandle{},
+		style:  StepsStyleDefault,
+	}
+}
+
+// Specify the style of the StepsModel.
+//
+//	s := eprogress.Steps(...).WithStyle(eprogress.StepsStyleDefault)
+func (m StepsModel) WithStyle(s StepsStyle) StepsModel {
+	m.style = s
+	return m
+}
+
+// Render the steps as a vertical checklist instead of a single line.
+//
+//	s := eprogress.Steps(...).WithVertical(true)
+func (m StepsModel) WithVertical(v bool) StepsModel {
+	m.vertical = v
+	return m
+}
+
+func (m StepsModel) Init() tea.Cmd {
+	return tea.Batch(
+		tick(),
+		func() tea.Msg {
+			return stepsMsgStop{err: m.task(m.handle)}
+		},
+	)
+}
+
+func (m StepsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.finished = true
+			m.err = ErrInterrupted
+			return m, tea.Quit
+		}
+	case progressMsgTick:
+		if m.finished {
+			return m, nil
+		}
+		m.current = m.handle.Current()
+		return m, tick()
+	case stepsMsgStop:
+		m.current = m.handle.Current()
+		m.finished = true
+		m.err = msg.err
+		if msg.err == nil {
+			m.current = len(m.steps)
+		}
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// Returns the number of the i-th step, circled when possible.
+func (s StepsStyle) number(i int) string {
+	if s.Circled && i < 20 {
+		return string(rune('①' + i))
+	}
+	return fmt.Sprintf("(%d)", i+1)
+}
+
+// Returns the style and the marker of the i-th step given the current one.
+func (m StepsModel) state(i int) (lipgloss.Style, string) {
+	switch {
+	case i < m.current:
+		return m.style.CompletedStyle, m.style.CompletedMarker
+	case i == m.current && m.err != nil:
+		return m.style.FailedStyle, m.style.FailedMarker
+	case i == m.current:
+		return m.style.CurrentStyle, m.style.CurrentMarker
+	}
+	return m.style.PendingStyle, m.style.PendingMarker
+}
+
+func (m StepsModel) View() string {
+	if m.vertical {
+		lines := make([]string, len(m.steps))
+		for i, step := range m.steps {
+			style, marker := m.state(i)
+			lines[i] = style.Render(marker + " " + step)
+		}
+		if m.err != nil {
+			lines = append(lines, m.style.FailedStyle.Render(fmt.Sprintf("  %v", m.err)))
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	parts := make([]string, len(m.steps))
+	for i := range m.steps {
+		style, _ := m.state(i)
+		parts[i] = style.Render(m.style.number(i))
+	}
+	s := strings.Join(parts, m.style.SeparatorStyle.Render(m.style.Separator))
+	switch {
+	case m.err != nil && m.current < len(m.steps):
+		s += "  " + m.style.FailedStyle.Render(fmt.Sprintf("%s failed: %v", m.steps[m.current], m.err))
+	case m.current < len(m.steps):
+		s += "  " + m.style.CurrentStyle.Render(m.steps[m.current])
+	default:
+		s += "  " + m.style.CompletedStyle.Render("Done")
+	}
+	return s + "\n"
+}
+
+func (m StepsModel) Err() error {
+	return m.err
+}
+
+// Run task, which calls StepHandle.Next when it completes a step. When task
+// returns nil all the steps are marked as completed, otherwise the current
+// step is marked as failed.
+// When the output is not a terminal a line is printed when a step starts.
+//
+//	err := eprogress.Steps([]string{"Build", "Test", "Deploy"}).Run(func(h *eprogress.StepHandle) error {
+//		if err := build(); err != nil {
+//			return err
+//		}
+//		h.Next()
+//		...
+//	})
+func (m StepsModel) Run(task func(h *StepHandle) error) error {
+	m.task = task
+	if !eterm.IsTerminal(os.Stdout) {
+		return m.runPlain()
+	}
+
+	final, err := runLive(m)
+	if err != nil {
+		return err
+	}
+	return final.(StepsModel).err
+}
+
+// Run the StepsModel printing a plain line when a step starts, for outputs
+// that are not a terminal.
+func (m StepsModel) runPlain() error {
+	done := make(chan error, 1)
+	go func() {
+		done <- m.task(m.handle)
+	}()
+
+	ticker := time.NewTicker(progressRefresh)
+	defer ticker.Stop()
+
+	printed := 0
+	for {
+		for ; printed <= min(m.handle.Current(), len(m.steps)-1); printed++ {
+			fmt.Printf("[%d/%d] %s\n", printed+1, len(m.steps), m.steps[printed])
+		}
+		select {
+		case err := <-done:
+			if c := m.handle.Current(); err != nil && c < len(m.steps) {
+				fmt.Println(m.style.FailedStyle.Render(fmt.Sprintf("%s failed: %v", m.steps[c], err)))
+			}
+			return err
+		case <-ticker.C:
+		}
+	}
+}
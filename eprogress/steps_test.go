@@ -0,0 +1,42 @@
+package eprogress
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestStepsView(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	unicode := StepsStyleASCII
+	unicode.CompletedMarker, unicode.CurrentMarker, unicode.PendingMarker, unicode.FailedMarker = "✓", "▸", "○", "✗"
+	unicode.Separator, unicode.Circled = " ─ ", true
+
+	tests := []struct {
+		name     string
+		style    StepsStyle
+		vertical bool
+		current  int
+		err      error
+		want     string
+	}{
+		{"unicode", unicode, false, 1, nil, "① ─ ② ─ ③  Test\n"},
+		{"ascii", StepsStyleASCII, false, 1, nil, "(1) - (2) - (3)  Test\n"},
+		{"ascii done", StepsStyleASCII, false, 3, nil, "(1) - (2) - (3)  Done\n"},
+		{"ascii failed", StepsStyleASCII, false, 2, errors.New("timeout"), "(1) - (2) - (3)  Deploy failed: timeout\n"},
+		{"unicode vertical", unicode, true, 1, nil, "✓ Build\n▸ Test\n○ Deploy\n"},
+		{"ascii vertical", StepsStyleASCII, true, 1, errors.New("timeout"), "+ Build\nx Test\n- Deploy\n  timeout\n"},
+	}
+	for _, tt := range tests {
+		m := Steps([]string{"Build", "Test", "Deploy"}).WithStyle(tt.style).WithVertical(tt.vertical)
+		m.current, m.err = tt.current, tt.err
+		if got := m.View(); got != tt.want {
+			t.Errorf("%s: View() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package eprogress
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/ecolor"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Placement of the percentage relative to a progress bar.
+type PercentPlacement int
+
+const (
+	PercentRight PercentPlacement = iota
+	PercentLeft
+	PercentHidden
+)
+
+// Progress bar style definition. When both GradientFrom and GradientTo are
+// set, as hex colors, the filled cells blend from one to the other along
+// the bar instead of using the color of FilledStyle.
+type ProgressStyle struct {
+	TitleStyle   lipgloss.Style
+	FilledStyle  lipgloss.Style
+	EmptyStyle   lipgloss.Style
+	PercentStyle lipgloss.Style
+	SuccessStyle lipgloss.Style
+	FailureStyle lipgloss.Style
+	Filled       rune
+	Empty        rune
+	Width        int
+	GradientFrom lipgloss.Color
+	GradientTo   lipgloss.Color
+	Percent      PercentPlacement
+}
+
+// Default ProgressStyle, a solid bar using the primary color of the
+// etheme.Theme.
+var ProgressStyleDefault = progressStyle(etheme.Current())
+
+// ProgressStyle with a solid bar using the primary color of the
+// etheme.Theme.
+var ProgressStyleSolid = progressStyleSolid(etheme.Current())
+
+// ProgressStyle with a bar blending along the gradient of the etheme.Theme.
+var ProgressStyleGradient = progressStyleGradient(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		ProgressStyleDefault = progressStyle(t)
+		ProgressStyleSolid = progressStyleSolid(t)
+		ProgressStyleGradient = progressStyleGradient(t)
+	})
+}
+
+// Returns ProgressStyleDefault for the theme t, ProgressStyleASCII when the
+// terminal cannot display unicode.
+func progressStyle(t etheme.Theme) ProgressStyle {
+	if !eterm.Unicode() {
+		return ProgressStyleASCII
+	}
+	return progressStyleSolid(t)
+}
+
+// Returns ProgressStyleSolid for the theme t.
+func progressStyleSolid(t etheme.Theme) ProgressStyle {
+	return ProgressStyle{
+		TitleStyle:   lipgloss.NewStyle(),
+		FilledStyle:  lipgloss.NewStyle().Foreground(t.Primary),
+		EmptyStyle:   lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		PercentStyle: lipgloss.NewStyle().Bold(true),
+		SuccessStyle: lipgloss.NewStyle().Foreground(t.Success),
+		FailureStyle: lipgloss.NewStyle().Foreground(t.Error).Bold(true),
+		Filled:       '█',
+		Empty:        '░',
+		Width:        30,
+		Percent:      PercentRight,
+	}
+}
+
+// Returns ProgressStyleGradient for the theme t.
+func progressStyleGradient(t etheme.Theme) ProgressStyle {
+	s := progressStyleSolid(t)
+	s.FilledStyle = lipgloss.NewStyle()
+	s.GradientFrom = t.GradientFrom
+	s.GradientTo = t.GradientTo
+	return s
+}
+
+// ProgressStyle without colors nor unicode characters, for terminals and
+// logs with limited support.
+var ProgressStyleASCII = ProgressStyle{
+	TitleStyle:   lipgloss.NewStyle(),
+	FilledStyle:  lipgloss.NewStyle(),
+	EmptyStyle:   lipgloss.NewStyle(),
+	PercentStyle: lipgloss.NewStyle(),
+	SuccessStyle: lipgloss.NewStyle(),
+	FailureStyle: lipgloss.NewStyle(),
+	Filled:       '#',
+	Empty:        '-',
+	Width:        30,
+	Percent:      PercentRight,
+}
+
+// Width of the bar, in cells.
+func (s ProgressStyle) width() int {
+	return max(s.Width, 1)
+}
+
+// Render n filled cells starting from the cell at position from.
+func (s ProgressStyle) renderFilled(from int, n int) string {
+	cells := strings.Repeat(string(s.Filled), n)
+	if s.GradientFrom == "" || s.GradientTo == "" {
+		return s.FilledStyle.Render(cells)
+	}
+
+	var b strings.Builder
+	last := float64(max(s.width()-1, 1))
+	for i := from; i < from+n; i++ {
+		color := ecolor.Blend(s.GradientFrom, s.GradientTo, float64(i)/last)
+		b.WriteString(s.FilledStyle.Foreground(color).Render(string(s.Filled)))
+	}
+	return b.String()
+}
+
+// Render n empty cells.
+func (s ProgressStyle) renderEmpty(n int) string {
+	return s.EmptyStyle.Render(strings.Repeat(string(s.Empty), n))
+}
+
+// Render a bar followed by its percentage, prefixed by title. When the
+// total is unknown a block bounces across the bar, moved by frame.
+func renderBar(style ProgressStyle, title string, done int64, total int64, frame int) string {
+	width := style.width()
+	if total <= 0 {
+		return placePercent(style, title, renderBounce(style, width, frame), "    ")
+	}
+
+	r := ratio(done, total)
+	filled := int(r * float64(width))
+	bar := style.renderFilled(0, filled) + style.renderEmpty(width-filled)
+	percent := style.PercentStyle.Render(fmt.Sprintf("%3.0f%%", r*100))
+	return placePercent(style, title, bar, percent)
+}
+
+// Join the title, the bar and the percentage according to the style.
+func placePercent(style ProgressStyle, title string, bar string, percent string) string {
+	switch style.Percent {
+	case PercentLeft:
+		return title + " " + percent + " " + bar
+	case PercentHidden:
+		return title + " " + bar
+	}
+	return title + " " + bar + " " + percent
+}
+
+// Render a bar of unknown completion, with a block bouncing between its ends.
+func renderBounce(style ProgressStyle, width int, frame int) string {
+	block := min(max(width/5, 1), width)
+	span := width - block
+	pos := 0
+	if span > 0 {
+		pos = frame % (2 * span)
+		if pos > span {
+			pos = 2*span - pos
+		}
+	}
+	return style.renderEmpty(pos) + style.renderFilled(pos, block) + style.renderEmpty(span-pos)
+}
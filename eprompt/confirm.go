@@ -0,0 +1,139 @@
+package eprompt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Bubbletea model of a yes/no prompt.
+type confirmModel struct {
+	title   string
+	def     bool
+	value   bool
+	done    bool
+	aborted bool
+	theme   Theme
+}
+
+func (m confirmModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m confirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case "enter":
+			m.value = m.def
+			m.done = true
+			return m, tea.Quit
+		case "y", "Y":
+			m.value = true
+			m.done = true
+			return m, tea.Quit
+		case "n", "N":
+			m.value = false
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m confirmModel) View() string {
+	s := m.theme.renderTitle(m.title) + " "
+	switch {
+	case m.done && m.value:
+		s += m.theme.AnswerStyle.Render("Yes")
+	case m.done:
+		s += m.theme.AnswerStyle.Render("No")
+	case !m.aborted && m.def:
+		s += m.theme.HelpStyle.Render("(Y/n)")
+	case !m.aborted:
+		s += m.theme.HelpStyle.Render("(y/N)")
+	}
+	return s + "\n"
+}
+
+// Parse a yes/no answer.
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "y", "yes", "true", "1":
+		return true, true
+	case "n", "no", "false", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// Ask the user a yes/no question. Pressing Enter selects def, which is
+// also used when the terminal is not interactive.
+//
+//	ok, err := eprompt.Confirm("Overwrite the existing file?", false)
+func Confirm(title string, def bool, opts ...PromptOption) (bool, error) {
+	c := newPromptConfig(opts)
+	if b, ok := parseBool(c.defaultValue()); ok {
+		def = b
+	}
+	if !interactive() && len(c.defaults) == 0 {
+		c.defaults = []string{strconv.FormatBool(def)}
+	}
+	if values, ok, err := c.supplied(); ok {
+		if err != nil {
+			return false, err
+		}
+		b, ok := parseBool(strings.Join(values, ","))
+		if !ok {
+			return false, fmt.Errorf("invalid value %q: expected yes or no", strings.Join(values, ","))
+		}
+		return b, nil
+	}
+
+	m, err := run(confirmModel{title: c.title(title), def: def, theme: c.theme}, c)
+	if err != nil {
+		return false, err
+	}
+	if m.aborted {
+		return false, ErrAborted
+	}
+	return m.value, nil
+}
+
+// Ask the user to confirm an irreversible action by typing phrase exactly,
+// like the name of the resource being deleted. Submitting an empty value
+// declines the action, any other value must match phrase.
+//
+//	ok, err := eprompt.ConfirmDanger("Delete the repository?", "ravvio/easycli-ui")
+func ConfirmDanger(title string, phrase string, opts ...PromptOption) (bool, error) {
+	c := newPromptConfig(opts)
+	c.validate = func(value string) error {
+		if value != "" && value != phrase {
+			return fmt.Errorf("type %q to confirm, or nothing to cancel", phrase)
+		}
+		return nil
+	}
+	if value, ok, err := c.suppliedText(); ok {
+		return err == nil && value == phrase, err
+	}
+
+	m := newInputModel(title, c)
+	m.hint = m.theme.HelpStyle.Render("Type") + " " +
+		m.theme.ErrorStyle.Bold(true).Render(phrase) + " " +
+		m.theme.HelpStyle.Render("to confirm:")
+	m.input.Placeholder = ""
+
+	m, err := run(m, c)
+	if err != nil {
+		return false, err
+	}
+	if m.aborted {
+		return false, ErrAborted
+	}
+	return m.value() == phrase, nil
+}
@@ -0,0 +1,235 @@
+package eprompt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/ekeys"
+)
+
+// Layouts accepted by the free form Date prompt and by WithDefault.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006",
+	"Jan 2 2006 15:04",
+	"Jan 2 2006",
+}
+
+// Include hours and minutes in a Date prompt.
+//
+//	eprompt.Date("Run at", eprompt.WithTime(true))
+func WithTime(t bool) PromptOption {
+	return func(c *promptConfig) {
+		c.time = t
+	}
+}
+
+// Let the user type the date of a Date prompt instead of picking it.
+// Dates like 2006-01-02, 2006-01-02 15:04, 2 Jan 2006 and the words now,
+// today, tomorrow and yesterday are accepted.
+//
+//	eprompt.Date("Run at", eprompt.WithFreeForm(true))
+func WithFreeForm(f bool) PromptOption {
+	return func(c *promptConfig) {
+		c.freeForm = f
+	}
+}
+
+// Parse a date typed by the user.
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	switch strings.ToLower(value) {
+	case "now":
+		return now.Truncate(time.Minute), nil
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("not a valid date, use YYYY-MM-DD or YYYY-MM-DD HH:MM")
+}
+
+// Segments of the date edited by a date picker.
+const (
+	dateSegmentYear = iota
+	dateSegmentMonth
+	dateSegmentDay
+	dateSegmentHour
+	dateSegmentMinute
+)
+
+// Bubbletea model of a date picker.
+type dateModel struct {
+	title    string
+	value    time.Time
+	time     bool
+	segment  int
+	segments int
+	done     bool
+	aborted  bool
+	theme    Theme
+}
+
+func (m dateModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m dateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		case "left", "h", "shift+tab":
+			m.segment = (m.segment - 1 + m.segments) % m.segments
+		case "right", "l", "tab":
+			m.segment = (m.segment + 1) % m.segments
+		case "up", "k", "+":
+			m.value = m.shift(1)
+		case "down", "j", "-":
+			m.value = m.shift(-1)
+		case "pgup":
+			m.value = m.shift(10)
+		case "pgdown":
+			m.value = m.shift(-10)
+		}
+	}
+	return m, nil
+}
+
+// Returns the value of the picker with the current segment shifted by n,
+// clamping the day to the length of the month.
+func (m dateModel) shift(n int) time.Time {
+	v := m.value
+	year, month, day := v.Date()
+	switch m.segment {
+	case dateSegmentYear:
+		year += n
+	case dateSegmentMonth:
+		month += time.Month(n)
+	case dateSegmentDay:
+		return v.AddDate(0, 0, n)
+	case dateSegmentHour:
+		return v.Add(time.Duration(n) * time.Hour)
+	case dateSegmentMinute:
+		return v.Add(time.Duration(n) * time.Minute)
+	}
+
+	first := time.Date(year, month, 1, v.Hour(), v.Minute(), 0, 0, v.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
+
+func (m dateModel) View() string {
+	s := m.theme.renderTitle(m.title) + " "
+	if m.done {
+		return s + m.theme.AnswerStyle.Render(m.format()) + "\n"
+	}
+	if m.aborted {
+		return s + "\n"
+	}
+
+	parts := []string{
+		fmt.Sprintf("%04d", m.value.Year()),
+		fmt.Sprintf("%02d", int(m.value.Month())),
+		fmt.Sprintf("%02d", m.value.Day()),
+		fmt.Sprintf("%02d", m.value.Hour()),
+		fmt.Sprintf("%02d", m.value.Minute()),
+	}
+	for i := range parts {
+		if i == m.segment {
+			parts[i] = m.theme.SelectedStyle.Underline(true).Render(parts[i])
+		}
+	}
+
+	s += parts[0] + "-" + parts[1] + "-" + parts[2]
+	if m.time {
+		s += " " + parts[3] + ":" + parts[4]
+	}
+	s += " " + m.theme.HelpStyle.Render(m.value.Weekday().String()) + "\n"
+	s += m.theme.HelpStyle.Render(ekeys.Plain(ekeys.Bind("←/→", "segment"), ekeys.Bind("↑/↓", "change"), ekeys.Bind("enter", "submit"))) + "\n"
+	return s
+}
+
+// Format the value of the picker.
+func (m dateModel) format() string {
+	if m.time {
+		return m.value.Format("2006-01-02 15:04")
+	}
+	return m.value.Format("2006-01-02")
+}
+
+// Prompt the user for a date, picking year, month and day with the arrow
+// keys. Use WithTime to also pick hours and minutes, WithFreeForm to let
+// the user type the date and WithDefault to choose the initial value.
+//
+//	when, err := eprompt.Date("Schedule for", eprompt.WithTime(true))
+func Date(title string, opts ...PromptOption) (time.Time, error) {
+	c := newPromptConfig(opts)
+	if values, ok, err := c.supplied(); ok {
+		if err != nil {
+			return time.Time{}, err
+		}
+		return parseDate(values[0])
+	}
+
+	if c.freeForm {
+		value, err := Input(title, append([]PromptOption{WithValidate(func(value string) error {
+			_, err := parseDate(value)
+			return err
+		})}, opts...)...)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return parseDate(value)
+	}
+
+	value := time.Now().Truncate(time.Minute)
+	if def := c.defaultValue(); def != "" {
+		t, err := parseDate(def)
+		if err != nil {
+			return time.Time{}, err
+		}
+		value = t
+	}
+
+	m := dateModel{title: c.title(title), value: value, time: c.time, segments: 3, theme: c.theme}
+	if c.time {
+		m.segments = 5
+	} else {
+		y, mo, d := value.Date()
+		m.value = time.Date(y, mo, d, 0, 0, 0, 0, value.Location())
+	}
+
+	m, err := run(m, c)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if m.aborted {
+		return time.Time{}, ErrAborted
+	}
+	return m.value, nil
+}
@@ -0,0 +1,59 @@
+package eprompt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		value string
+		want  time.Time
+		err   bool
+	}{
+		{"2024-03-05", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local), false},
+		{" 2024-03-05 14:30 ", time.Date(2024, 3, 5, 14, 30, 0, 0, time.Local), false},
+		{"2024-03-05T14:30", time.Date(2024, 3, 5, 14, 30, 0, 0, time.Local), false},
+		{"2024-03-05 14:30:15", time.Date(2024, 3, 5, 14, 30, 15, 0, time.Local), false},
+		{"2024-03-05T14:30:00Z", time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC), false},
+		{"5 Mar 2024", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local), false},
+		{"5 Mar 2024 09:15", time.Date(2024, 3, 5, 9, 15, 0, 0, time.Local), false},
+		{"Mar 5 2024", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local), false},
+		{"Today", today, false},
+		{"tomorrow", today.AddDate(0, 0, 1), false},
+		{"yesterday", today.AddDate(0, 0, -1), false},
+		{"2024-02-30", time.Time{}, true},
+		{"05/03/2024", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseDate(tt.value)
+		if tt.err {
+			if err == nil {
+				t.Errorf("parseDate(%q) = %v, want an error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDate(%q): %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseDateNow(t *testing.T) {
+	before := time.Now().Truncate(time.Minute)
+	got, err := parseDate("now")
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().Truncate(time.Minute)
+	if got.Before(before) || got.After(after) || got.Second() != 0 {
+		t.Errorf("parseDate(%q) = %v, want the current minute", "now", got)
+	}
+}
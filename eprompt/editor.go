@@ -0,0 +1,92 @@
+package eprompt
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Editors looked up in PATH when neither $VISUAL nor $EDITOR are set.
+var fallbackEditors = []string{"nano", "vim", "vi"}
+
+// Specify the extension of the temporary file opened by an Editor prompt,
+// so that editors can enable syntax highlighting.
+//
+//	eprompt.Editor("Manifest", manifest, eprompt.WithExtension(".yaml"))
+func WithExtension(ext string) PromptOption {
+	return func(c *promptConfig) {
+		c.extension = ext
+	}
+}
+
+// Returns the command line of the editor of the user, or nil if none is
+// available.
+func findEditor() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+
+	editors := fallbackEditors
+	if runtime.GOOS == "windows" {
+		editors = []string{"notepad"}
+	}
+	for _, e := range editors {
+		if path, err := exec.LookPath(e); err == nil {
+			return []string{path}
+		}
+	}
+	return nil
+}
+
+// Prompt the user for a text by opening their editor ($VISUAL or $EDITOR)
+// on a temporary file containing initial, and return the saved content.
+// When no editor is available a TextArea is displayed instead.
+//
+//	message, err := eprompt.Editor("Commit message", "", eprompt.WithExtension(".md"))
+func Editor(title string, initial string, opts ...PromptOption) (string, error) {
+	c := newPromptConfig(append([]PromptOption{WithDefault(initial)}, opts...))
+	if value, ok, err := c.suppliedText(); ok {
+		return value, err
+	}
+
+	title = c.title(title)
+	editor := findEditor()
+	if editor == nil {
+		return runTextArea(title, initial, c)
+	}
+
+	f, err := os.CreateTemp("", "eprompt-*"+c.extension)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+	if _, err := f.WriteString(initial); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	fmt.Println(c.theme.renderTitle(title) + " " + c.theme.HelpStyle.Render("Waiting for the editor to close..."))
+	cmd := exec.Command(editor[0], append(editor[1:], f.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running editor: %w", err)
+	}
+
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	fmt.Println(c.theme.renderTitle(title) + " " + c.theme.AnswerStyle.Render(summarizeText(string(b))))
+	return string(b), nil
+}
@@ -0,0 +1,209 @@
+package eprompt
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// ErrAborted is returned when the user aborts a prompt with Ctrl+C or Esc.
+var ErrAborted = errors.New("prompt aborted")
+
+// ErrNoOptions is returned when a selection prompt is given no options.
+var ErrNoOptions = errors.New("no options to select from")
+
+// Returned by the prompts of a Wizard when the user asks to go back.
+var errBack = errors.New("back to the previous step")
+
+// PromptOption configures a prompt.
+//
+//	name, err := eprompt.Input("Name", eprompt.WithCharLimit(32))
+//
+// Every prompt accepts every option and ignores the ones that do not apply
+// to it. WithDefault, WithValue, WithEnvFallback and WithTheme apply to all
+// the prompts. The text prompts are Input, Int, Float, Path, Password and
+// TextArea, the selection prompts Select, SelectPaged, MultiSelect, Search
+// and SearchAsync, a Date with WithFreeForm is an Input. The other options
+// apply to:
+//
+//   - WithValidate: the text prompts
+//   - WithCharLimit, WithWidth: the text prompts
+//   - WithPlaceholder: the text prompts, Search and SearchAsync
+//   - WithHistory: the text prompts but Password and TextArea
+//   - WithHeight: the selection prompts and TextArea
+//   - WithMinSelected, WithMaxSelected: MultiSelect
+//   - WithMask, WithRepeat: Password
+//   - WithMustExist, WithPathKind: Path
+//   - WithTime, WithFreeForm: Date
+//   - WithExtension: Editor
+type PromptOption func(c *promptConfig)
+
+type promptConfig struct {
+	// All the prompts
+	defaults []string
+	env      string
+	values   []string
+	theme    Theme
+	step     string
+	back     bool
+
+	// Text prompts
+	charLimit   int
+	width       int
+	placeholder string
+	validate    Validator
+	history     string
+	historyMax  int
+	mask        rune
+	repeat      string
+	mustExist   bool
+	pathKind    PathKind
+
+	// Selection prompts and TextArea
+	height int
+	min    int
+	max    int
+
+	// Date and Editor
+	time      bool
+	freeForm  bool
+	extension string
+}
+
+func newPromptConfig(opts []PromptOption) promptConfig {
+	c := promptConfig{
+		defaults:    nil,
+		env:         "",
+		values:      nil,
+		theme:       ThemeDefault,
+		step:        "",
+		back:        false,
+		charLimit:   0,
+		width:       0,
+		placeholder: "",
+		validate:    nil,
+		history:     "",
+		historyMax:  0,
+		mask:        '*',
+		repeat:      "",
+		mustExist:   false,
+		pathKind:    PathAny,
+		height:      10,
+		min:         0,
+		max:         0,
+		time:        false,
+		freeForm:    false,
+		extension:   "",
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Limit the number of characters accepted by a text prompt, 0 means no
+// limit.
+//
+//	eprompt.Input("Name", eprompt.WithCharLimit(32))
+func WithCharLimit(n int) PromptOption {
+	return func(c *promptConfig) {
+		c.charLimit = n
+	}
+}
+
+// Set the width of the input field of a text prompt, 0 means no limit.
+//
+//	eprompt.Input("Name", eprompt.WithWidth(40))
+func WithWidth(w int) PromptOption {
+	return func(c *promptConfig) {
+		c.width = w
+	}
+}
+
+// Set the maximum number of options displayed at once by a selection prompt,
+// or the number of lines of a TextArea.
+//
+//	eprompt.Search("Package", packages, eprompt.WithHeight(15))
+func WithHeight(h int) PromptOption {
+	return func(c *promptConfig) {
+		c.height = h
+	}
+}
+
+// Specify the default value of the prompt. Text prompts return it when the
+// user submits an empty value, selection prompts start with the options
+// whose Value matches selected, Confirm accepts "yes" or "no".
+//
+//	eprompt.Input("Region", eprompt.WithDefault("eu-west-1"))
+//	eprompt.MultiSelect("Regions", regions, eprompt.WithDefault("eu-west-1", "us-east-1"))
+func WithDefault(values ...string) PromptOption {
+	return func(c *promptConfig) {
+		c.defaults = values
+	}
+}
+
+// Specify a hint displayed in the empty input field of a text prompt or a
+// Search. Defaults to the default value, if any.
+//
+//	eprompt.Input("Name", eprompt.WithPlaceholder("John Doe"))
+func WithPlaceholder(hint string) PromptOption {
+	return func(c *promptConfig) {
+		c.placeholder = hint
+	}
+}
+
+// Returns the first default value of the prompt, or an empty string.
+func (c promptConfig) defaultValue() string {
+	if len(c.defaults) == 0 {
+		return ""
+	}
+	return c.defaults[0]
+}
+
+// Returns the index of the option whose value is the default of the
+// prompt, or 0.
+func (c promptConfig) defaultIndex(options []Option) int {
+	if len(c.defaults) == 0 {
+		return 0
+	}
+	for i, o := range options {
+		if o.Value == c.defaults[0] {
+			return i
+		}
+	}
+	return 0
+}
+
+// Returns the title of a prompt prefixed by its step, if any.
+func (c promptConfig) title(title string) string {
+	if c.step == "" {
+		return title
+	}
+	return c.step + " " + title
+}
+
+// Run the prompt model until the user submits or aborts it.
+// When the prompt allows to go back, Esc makes it return errBack.
+func run[M tea.Model](m M, c promptConfig) (M, error) {
+	back := false
+	opts := []tea.ProgramOption{}
+	if c.back {
+		opts = append(opts, tea.WithFilter(func(_ tea.Model, msg tea.Msg) tea.Msg {
+			if k, ok := msg.(tea.KeyMsg); ok && k.Type == tea.KeyEsc {
+				back = true
+				return tea.KeyMsg{Type: tea.KeyCtrlC}
+			}
+			return msg
+		}))
+	}
+
+	final, err := live.Run(tea.NewProgram(m, opts...))
+	if err != nil {
+		return m, err
+	}
+	if back {
+		return final.(M), errBack
+	}
+	return final.(M), nil
+}
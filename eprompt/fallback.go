@@ -0,0 +1,116 @@
+package eprompt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+// ErrNonInteractive is returned when a prompt cannot be displayed because
+// stdin is not a terminal, and no value or default was provided.
+var ErrNonInteractive = errors.New("no value provided and the terminal is not interactive")
+
+// Use the value of the environment variable, if set and not empty, instead of
+// prompting the user.
+//
+//	region, err := eprompt.Input("Region", eprompt.WithEnvFallback("MYCLI_REGION"))
+func WithEnvFallback(name string) PromptOption {
+	return func(c *promptConfig) {
+		c.env = name
+	}
+}
+
+// Use the given values instead of prompting the user, typically the values
+// of command line flags. Selection prompts match them against the Value of
+// their options, MultiSelect accepts several values. Empty values are
+// ignored, so the user is prompted when a flag was not set.
+//
+//	region, err := eprompt.Input("Region", eprompt.WithValue(flagRegion))
+func WithValue(values ...string) PromptOption {
+	return func(c *promptConfig) {
+		c.values = nil
+		for _, v := range values {
+			if v != "" {
+				c.values = append(c.values, v)
+			}
+		}
+	}
+}
+
+// Report whether the prompts can interact with the user.
+func interactive() bool {
+	return eterm.IsTerminal(os.Stdin)
+}
+
+// Returns the values supplied to the prompt without interaction: the ones
+// provided with WithValue, the ones of the environment variable, or the
+// defaults when the terminal is not interactive. ok is false when the user
+// should be prompted.
+func (c promptConfig) supplied() (values []string, ok bool, err error) {
+	if len(c.values) > 0 {
+		return c.values, true, nil
+	}
+	if c.env != "" {
+		if v := os.Getenv(c.env); v != "" {
+			return strings.Split(v, ","), true, nil
+		}
+	}
+	if interactive() {
+		return nil, false, nil
+	}
+	if len(c.defaults) > 0 {
+		return c.defaults, true, nil
+	}
+	return nil, true, ErrNonInteractive
+}
+
+// Returns the single value supplied to a text prompt, validated.
+func (c promptConfig) suppliedText() (string, bool, error) {
+	values, ok, err := c.supplied()
+	if !ok || err != nil {
+		return "", ok, err
+	}
+
+	value := strings.Join(values, ",")
+	if c.validate != nil {
+		if err := c.validate(value); err != nil {
+			return "", true, fmt.Errorf("invalid value %q: %w", value, err)
+		}
+	}
+	return value, true, nil
+}
+
+// Returns the options whose Value matches the values supplied to a
+// selection prompt.
+func (c promptConfig) suppliedOptions(options []Option) ([]Option, bool, error) {
+	return c.suppliedMatching(func(value string) (Option, bool) {
+		for _, o := range options {
+			if o.Value == value {
+				return o, true
+			}
+		}
+		return Option{}, false
+	})
+}
+
+// Returns the options found by find for the values supplied to a selection
+// prompt.
+func (c promptConfig) suppliedMatching(find func(value string) (Option, bool)) ([]Option, bool, error) {
+	values, ok, err := c.supplied()
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+
+	chosen := make([]Option, 0, len(values))
+	for _, v := range values {
+		o, found := find(strings.TrimSpace(v))
+		if !found {
+			return nil, true, fmt.Errorf("invalid value %q: not one of the options", v)
+		}
+		chosen = append(chosen, o)
+	}
+	return chosen, true, nil
+}
@@ -0,0 +1,263 @@
+package eprompt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Rules of the validate tag of a Form field.
+type formRules struct {
+	required bool
+	integer  bool
+	url      bool
+	min      *float64
+	max      *float64
+}
+
+// Parse a validate tag like "required,min=3,max=20".
+func parseFormRules(tag string) (formRules, error) {
+	r := formRules{}
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "":
+		case "required":
+			r.required = true
+		case "int":
+			r.integer = true
+		case "url":
+			r.url = true
+		case "min", "max":
+			n, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return r, fmt.Errorf("invalid %s rule %q", name, rule)
+			}
+			if name == "min" {
+				r.min = &n
+			} else {
+				r.max = &n
+			}
+		default:
+			return r, fmt.Errorf("unknown validate rule %q", rule)
+		}
+	}
+	return r, nil
+}
+
+// Validators of text fields, min and max limit the length of the value.
+func (r formRules) validators() []Validator {
+	v := make([]Validator, 0)
+	if r.required {
+		v = append(v, Required())
+	}
+	if r.integer {
+		v = append(v, Integer())
+	}
+	if r.url {
+		v = append(v, URL())
+	}
+	if r.min != nil {
+		v = append(v, MinLength(int(*r.min)))
+	}
+	if r.max != nil {
+		v = append(v, MaxLength(int(*r.max)))
+	}
+	return v
+}
+
+// Bounds of integer fields, the rules narrow the range lo to hi of the type
+// of the field.
+func (r formRules) intBounds(lo int, hi int) (int, int) {
+	if r.min != nil && *r.min > float64(lo) {
+		lo = hi
+		if *r.min < float64(hi) {
+			lo = int(math.Ceil(*r.min))
+		}
+	}
+	if r.max != nil && *r.max < float64(hi) {
+		hi = lo
+		if *r.max > float64(lo) {
+			hi = int(math.Floor(*r.max))
+		}
+	}
+	return lo, hi
+}
+
+// Bounds of float fields, the rules narrow the range lo to hi of the type of
+// the field.
+func (r formRules) bounds(lo float64, hi float64) (float64, float64) {
+	if r.min != nil {
+		lo = max(lo, *r.min)
+	}
+	if r.max != nil {
+		hi = min(hi, *r.max)
+	}
+	return lo, hi
+}
+
+// Returns the range of the integers of the given size in bits that fit in
+// an int.
+func intRange(bits int, unsigned bool) (int, int) {
+	switch {
+	case unsigned && bits >= strconv.IntSize:
+		return 0, math.MaxInt
+	case unsigned:
+		return 0, 1<<bits - 1
+	case bits >= strconv.IntSize:
+		return math.MinInt, math.MaxInt
+	}
+	return -1 << (bits - 1), 1<<(bits-1) - 1
+}
+
+// Fill the fields of the struct pointed by v by prompting the user.
+// Only the exported fields with a prompt tag are filled, in order:
+//
+//   - string fields use Input, or Password with secret:"true", or Select
+//     when options:"a,b,c" lists the allowed values
+//   - []string fields with options use MultiSelect
+//   - integer and float fields use Int and Float
+//   - bool fields use Confirm
+//
+// The validate tag accepts a comma separated list of rules: required, int,
+// url, min=N and max=N. For numeric fields min and max are bounds, within
+// the range of the type of the field, for text fields they limit the length.
+// Non zero fields are used as defaults. The env tag names an environment
+// variable that provides the value instead of the prompt, see
+// WithEnvFallback.
+//
+//	type Config struct {
+//		Name     string `prompt:"Project name" validate:"required,max=40"`
+//		Env      string `prompt:"Environment" options:"dev,staging,prod" env:"MYCLI_ENV"`
+//		Replicas int    `prompt:"Replicas" validate:"min=1,max=10"`
+//		Public   bool   `prompt:"Expose publicly?"`
+//	}
+//	cfg := Config{Replicas: 2}
+//	err := eprompt.Form(&cfg)
+func Form(v any, opts ...PromptOption) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("eprompt: Form requires a pointer to a struct")
+	}
+	rv = rv.Elem()
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		title, ok := field.Tag.Lookup("prompt")
+		if !ok || !field.IsExported() {
+			continue
+		}
+		if err := promptField(title, field, rv.Field(i), opts); err != nil {
+			return fmt.Errorf("%s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// Prompt the user for the value of a single field of a Form.
+func promptField(title string, field reflect.StructField, fv reflect.Value, opts []PromptOption) error {
+	rules, err := parseFormRules(field.Tag.Get("validate"))
+	if err != nil {
+		return err
+	}
+
+	options := make([]Option, 0)
+	if tag := field.Tag.Get("options"); tag != "" {
+		for _, o := range strings.Split(tag, ",") {
+			o = strings.TrimSpace(o)
+			options = append(options, Option{Label: o, Value: o})
+		}
+	}
+
+	opts = append([]PromptOption{}, opts...)
+	if !fv.IsZero() && fv.Kind() != reflect.Slice {
+		opts = append(opts, WithDefault(fmt.Sprint(fv.Interface())))
+	}
+	if env := field.Tag.Get("env"); env != "" {
+		opts = append(opts, WithEnvFallback(env))
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		var value string
+		switch {
+		case len(options) > 0:
+			var o Option
+			o, err = Select(title, options, opts...)
+			value = o.Value
+		case field.Tag.Get("secret") == "true":
+			value, err = Password(title, append(opts, WithValidate(rules.validators()...))...)
+		default:
+			value, err = Input(title, append(opts, WithValidate(rules.validators()...))...)
+		}
+		if err == nil {
+			fv.SetString(value)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lo, hi := rules.intBounds(intRange(fv.Type().Bits(), false))
+		var value int
+		value, err = Int(title, lo, hi, opts...)
+		if err == nil {
+			fv.SetInt(int64(value))
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		lo, hi := rules.intBounds(intRange(fv.Type().Bits(), true))
+		var value int
+		value, err = Int(title, lo, hi, opts...)
+		if err == nil {
+			fv.SetUint(uint64(value))
+		}
+
+	case reflect.Float32, reflect.Float64:
+		limit := math.MaxFloat64
+		if fv.Kind() == reflect.Float32 {
+			limit = math.MaxFloat32
+		}
+		lo, hi := rules.bounds(-limit, limit)
+		var value float64
+		value, err = Float(title, lo, hi, opts...)
+		if err == nil {
+			fv.SetFloat(value)
+		}
+
+	case reflect.Bool:
+		var value bool
+		value, err = Confirm(title, fv.Bool(), opts...)
+		if err == nil {
+			fv.SetBool(value)
+		}
+
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String || len(options) == 0 {
+			return fmt.Errorf("unsupported field type %s", fv.Type())
+		}
+		defaults := make([]string, fv.Len())
+		for i := range defaults {
+			defaults[i] = fv.Index(i).String()
+		}
+		if rules.min != nil {
+			opts = append(opts, WithMinSelected(int(*rules.min)))
+		}
+		if rules.max != nil {
+			opts = append(opts, WithMaxSelected(int(*rules.max)))
+		}
+		var chosen []Option
+		chosen, err = MultiSelect(title, options, append(opts, WithDefault(defaults...))...)
+		if err == nil {
+			values := reflect.MakeSlice(fv.Type(), len(chosen), len(chosen))
+			for i, o := range chosen {
+				values.Index(i).SetString(o.Value)
+			}
+			fv.Set(values)
+		}
+
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package eprompt
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFormCoercion(t *testing.T) {
+	type fields struct {
+		Int     int      `prompt:"Int"`
+		Int8    int8     `prompt:"Int8"`
+		Uint8   uint8    `prompt:"Uint8"`
+		Uint16  uint16   `prompt:"Uint16" validate:"max=100000"`
+		Bounded int      `prompt:"Bounded" validate:"min=1,max=10"`
+		Float32 float32  `prompt:"Float32"`
+		Float64 float64  `prompt:"Float64"`
+		Bool    bool     `prompt:"Bool"`
+		Text    string   `prompt:"Text"`
+		List    []string `prompt:"List" options:"a,b,c"`
+	}
+	tests := []struct {
+		field string
+		value string
+		want  any
+		err   bool
+	}{
+		{"Int", "-42", -42, false},
+		{"Int", "4.2", nil, true},
+		{"Int8", "127", int8(127), false},
+		{"Int8", "-128", int8(-128), false},
+		{"Int8", "300", nil, true},
+		{"Int8", "-129", nil, true},
+		{"Uint8", "255", uint8(255), false},
+		{"Uint8", "256", nil, true},
+		{"Uint8", "-1", nil, true},
+		{"Uint16", "65536", nil, true},
+		{"Bounded", "10", 10, false},
+		{"Bounded", "0", nil, true},
+		{"Float32", "1.5", float32(1.5), false},
+		{"Float32", "1e39", nil, true},
+		{"Float64", "1e39", 1e39, false},
+		{"Bool", "yes", true, false},
+		{"Text", "value", "value", false},
+		{"List", "c", []string{"c"}, false},
+		{"List", "d", nil, true},
+	}
+	for _, tt := range tests {
+		var v fields
+		field, _ := reflect.TypeOf(v).FieldByName(tt.field)
+		fv := reflect.ValueOf(&v).Elem().FieldByName(tt.field)
+		err := promptField(tt.field, field, fv, []PromptOption{WithValue(tt.value)})
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s = %q: stored %v, want an error", tt.field, tt.value, fv.Interface())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s = %q: %v", tt.field, tt.value, err)
+			continue
+		}
+		if got := fv.Interface(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %q: stored %v, want %v", tt.field, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormRulesBounds(t *testing.T) {
+	ptr := func(f float64) *float64 { return &f }
+	tests := []struct {
+		rules  formRules
+		lo, hi int
+		wantLo int
+		wantHi int
+	}{
+		{formRules{}, -128, 127, -128, 127},
+		{formRules{min: ptr(1), max: ptr(10)}, -128, 127, 1, 10},
+		{formRules{min: ptr(-1000), max: ptr(1000)}, -128, 127, -128, 127},
+		{formRules{min: ptr(1.5), max: ptr(9.5)}, -128, 127, 2, 9},
+		{formRules{min: ptr(1e30)}, math.MinInt, math.MaxInt, math.MaxInt, math.MaxInt},
+		{formRules{max: ptr(-1e30)}, math.MinInt, math.MaxInt, math.MinInt, math.MinInt},
+	}
+	for _, tt := range tests {
+		lo, hi := tt.rules.intBounds(tt.lo, tt.hi)
+		if lo != tt.wantLo || hi != tt.wantHi {
+			t.Errorf("intBounds(%d, %d) = %d, %d, want %d, %d", tt.lo, tt.hi, lo, hi, tt.wantLo, tt.wantHi)
+		}
+	}
+}
+
+func TestIntRange(t *testing.T) {
+	tests := []struct {
+		bits     int
+		unsigned bool
+		lo, hi   int
+	}{
+		{8, false, math.MinInt8, math.MaxInt8},
+		{16, false, math.MinInt16, math.MaxInt16},
+		{32, false, math.MinInt32, math.MaxInt32},
+		{64, false, math.MinInt, math.MaxInt},
+		{8, true, 0, math.MaxUint8},
+		{32, true, 0, math.MaxUint32},
+		{64, true, 0, math.MaxInt},
+	}
+	for _, tt := range tests {
+		if lo, hi := intRange(tt.bits, tt.unsigned); lo != tt.lo || hi != tt.hi {
+			t.Errorf("intRange(%d, %v) = %d, %d, want %d, %d", tt.bits, tt.unsigned, lo, hi, tt.lo, tt.hi)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package eprompt
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Keep the values submitted to a text prompt in file, at most max of them,
+// and recall them with the up and down arrows. The file is created when
+// needed, failing to read or write it does not make the prompt fail.
+//
+//	host, err := eprompt.Input("Host", eprompt.WithHistory("~/.mycli/history/host", 50))
+func WithHistory(file string, max int) PromptOption {
+	return func(c *promptConfig) {
+		c.history = file
+		c.historyMax = max
+	}
+}
+
+// Read the history file of a prompt, oldest value first.
+func loadHistory(file string) []string {
+	if file == "" {
+		return nil
+	}
+	f, err := os.Open(expandHome(file))
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	history := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			history = append(history, line)
+		}
+	}
+	return history
+}
+
+// Add value to the history file of the prompt, if any.
+func (c promptConfig) remember(value string) {
+	if c.history == "" || value == "" || strings.ContainsAny(value, "\r\n") {
+		return
+	}
+
+	history := slices.DeleteFunc(loadHistory(c.history), func(v string) bool {
+		return v == value
+	})
+	history = append(history, value)
+	if c.historyMax > 0 && len(history) > c.historyMax {
+		history = history[len(history)-c.historyMax:]
+	}
+
+	file := expandHome(c.history)
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(file, []byte(strings.Join(history, "\n")+"\n"), 0o600)
+}
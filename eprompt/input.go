@@ -0,0 +1,171 @@
+package eprompt
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Bubbletea model of a text input prompt.
+type inputModel struct {
+	title    string
+	hint     string
+	input    textinput.Model
+	validate Validator
+	complete func(value string) (string, []string)
+	choices  []string
+	history  []string
+	recalled int
+	draft    string
+	def      string
+	err      error
+	done     bool
+	aborted  bool
+	theme    Theme
+}
+
+func newInputModel(title string, c promptConfig) inputModel {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.CharLimit = c.charLimit
+	ti.Width = c.width
+	ti.Placeholder = c.placeholder
+	if ti.Placeholder == "" {
+		ti.Placeholder = c.defaultValue()
+	}
+	ti.Focus()
+	history := loadHistory(c.history)
+	return inputModel{
+		title:    c.title(title),
+		input:    ti,
+		validate: c.validate,
+		history:  history,
+		recalled: len(history),
+		def:      c.defaultValue(),
+		theme:    c.theme,
+	}
+}
+
+func (m inputModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m inputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.err = nil
+		m.choices = nil
+		switch msg.Type {
+		case tea.KeyTab:
+			if m.complete != nil {
+				value, choices := m.complete(m.input.Value())
+				m.input.SetValue(value)
+				m.input.CursorEnd()
+				m.choices = choices
+				return m, nil
+			}
+		case tea.KeyUp:
+			m.recall(-1)
+			return m, nil
+		case tea.KeyDown:
+			m.recall(1)
+			return m, nil
+		case tea.KeyCtrlC, tea.KeyEsc:
+			m.aborted = true
+			return m, tea.Quit
+		case tea.KeyEnter:
+			if m.validate != nil {
+				if m.err = m.validate(m.value()); m.err != nil {
+					return m, nil
+				}
+			}
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m inputModel) View() string {
+	s := m.theme.renderTitle(m.title) + " "
+	if m.hint != "" && !m.done {
+		s += m.hint + " "
+	}
+	switch {
+	case m.done:
+		s += m.theme.AnswerStyle.Render(m.answer())
+	case !m.aborted:
+		s += m.input.View()
+		if len(m.choices) > 0 {
+			s += "\n" + m.theme.HelpStyle.Render(strings.Join(m.choices, "  "))
+		}
+		if m.err != nil {
+			s += "\n" + m.theme.renderError(m.err)
+		}
+	}
+	return s + "\n"
+}
+
+// Replace the value with the previous (delta -1) or next (delta 1) value of
+// the history, the value being typed is restored past the last one.
+func (m *inputModel) recall(delta int) {
+	i := m.recalled + delta
+	if i < 0 || i > len(m.history) {
+		return
+	}
+	if m.recalled == len(m.history) {
+		m.draft = m.input.Value()
+	}
+	m.recalled = i
+	if i == len(m.history) {
+		m.input.SetValue(m.draft)
+	} else {
+		m.input.SetValue(m.history[i])
+	}
+	m.input.CursorEnd()
+}
+
+// The submitted value as it should be displayed.
+func (m inputModel) answer() string {
+	switch m.input.EchoMode {
+	case textinput.EchoPassword:
+		return strings.Repeat(string(m.input.EchoCharacter), utf8.RuneCountInString(m.value()))
+	case textinput.EchoNone:
+		return ""
+	}
+	return m.value()
+}
+
+// The submitted value, or the default if the input is empty.
+func (m inputModel) value() string {
+	if m.input.Value() == "" {
+		return m.def
+	}
+	return m.input.Value()
+}
+
+// Prompt the user for a line of text.
+// See WithValue and WithEnvFallback to provide the value without interaction.
+//
+//	name, err := eprompt.Input("What is your name?")
+func Input(title string, opts ...PromptOption) (string, error) {
+	c := newPromptConfig(opts)
+	if value, ok, err := c.suppliedText(); ok {
+		return value, err
+	}
+
+	m, err := run(newInputModel(title, c), c)
+	if err != nil {
+		return "", err
+	}
+	if m.aborted {
+		return "", ErrAborted
+	}
+	c.remember(m.value())
+	return m.value(), nil
+}
@@ -0,0 +1,231 @@
+package eprompt
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/ekeys"
+)
+
+// Require at least n options to be selected in a MultiSelect prompt.
+//
+//	eprompt.MultiSelect("Regions", regions, eprompt.WithMinSelected(1))
+func WithMinSelected(n int) PromptOption {
+	return func(c *promptConfig) {
+		c.min = n
+	}
+}
+
+// Allow at most n options to be selected in a MultiSelect prompt, 0 means
+// no limit.
+//
+//	eprompt.MultiSelect("Regions", regions, eprompt.WithMaxSelected(3))
+func WithMaxSelected(n int) PromptOption {
+	return func(c *promptConfig) {
+		c.max = n
+	}
+}
+
+// Bubbletea model of a multiple selection prompt.
+type multiSelectModel struct {
+	title    string
+	options  []Option
+	list     optionWindow
+	selected []bool
+	min      int
+	max      int
+	err      string
+	done     bool
+	aborted  bool
+	theme    Theme
+}
+
+func (m multiSelectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.err = ""
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case "enter":
+			if n := m.count(); n < m.min {
+				m.err = fmt.Sprintf("Select at least %d options", m.min)
+				return m, nil
+			}
+			m.done = true
+			return m, tea.Quit
+		case " ", "x":
+			if !m.selected[m.list.cursor] && m.max > 0 && m.count() >= m.max {
+				m.err = fmt.Sprintf("Select at most %d options", m.max)
+				return m, nil
+			}
+			m.selected = toggled(m.selected, m.list.cursor)
+		case "a":
+			if m.max > 0 && len(m.options) > m.max {
+				m.err = fmt.Sprintf("Select at most %d options", m.max)
+				return m, nil
+			}
+			m.selected = filled(len(m.options), true)
+		case "n":
+			m.selected = filled(len(m.options), false)
+		default:
+			m.list.navigate(ekeys.ListKey(msg.String(), false))
+		}
+	}
+	return m, nil
+}
+
+func (m multiSelectModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.theme.renderTitle(m.title))
+	if m.done {
+		labels := make([]string, 0)
+		for _, o := range m.chosen() {
+			labels = append(labels, o.Label)
+		}
+		b.WriteString(" " + m.theme.AnswerStyle.Render(strings.Join(labels, ", ")) + "\n")
+		return b.String()
+	}
+	b.WriteString("\n")
+	if m.aborted {
+		return b.String()
+	}
+
+	start, end := m.list.visible()
+	for i := start; i < end; i++ {
+		marker := "[ ] "
+		if m.selected[i] {
+			marker = "[x] "
+		}
+		b.WriteString(m.theme.renderOption(m.list.at(i), marker, i == m.list.cursor))
+	}
+	b.WriteString(m.list.renderPosition(m.theme))
+	if m.err != "" {
+		b.WriteString(m.theme.ErrorStyle.Render(m.err) + "\n")
+	}
+	b.WriteString(m.theme.HelpStyle.Render(ekeys.Plain(ekeys.Bind("space", "toggle"), ekeys.Bind("a", "all"), ekeys.Bind("n", "none"), ekeys.Bind("enter", "submit"))) + "\n")
+	return b.String()
+}
+
+// Count the selected options.
+func (m multiSelectModel) count() int {
+	n := 0
+	for _, s := range m.selected {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// Returns the selected options, in the order they were given.
+func (m multiSelectModel) chosen() []Option {
+	chosen := make([]Option, 0)
+	for i, o := range m.options {
+		if m.selected[i] {
+			chosen = append(chosen, o)
+		}
+	}
+	return chosen
+}
+
+// Returns a copy of s with the i-th value negated.
+func toggled(s []bool, i int) []bool {
+	c := make([]bool, len(s))
+	copy(c, s)
+	c[i] = !c[i]
+	return c
+}
+
+// Returns the error of n options selected when they are fewer than the
+// minimum or more than the maximum of c, nil otherwise.
+func (c promptConfig) checkSelected(n int) error {
+	if n < c.min {
+		return fmt.Errorf("select at least %d options, got %d", c.min, n)
+	}
+	if c.max > 0 && n > c.max {
+		return fmt.Errorf("select at most %d options, got %d", c.max, n)
+	}
+	return nil
+}
+
+// Returns a slice of n copies of v.
+func filled(n int, v bool) []bool {
+	s := make([]bool, n)
+	for i := range s {
+		s[i] = v
+	}
+	return s
+}
+
+// Prompt the user to choose any number of the options, toggling them with
+// the space bar. Only a window of the options is rendered, see WithHeight.
+// The values supplied without prompting must respect WithMinSelected and
+// WithMaxSelected, and so must the defaults, except that the user can add
+// to the defaults fewer than the minimum.
+//
+//	regions, err := eprompt.MultiSelect("Regions", []eprompt.Option{
+//		{Label: "eu-west-1", Value: "eu-west-1"},
+//		{Label: "us-east-1", Value: "us-east-1"},
+//	}, eprompt.WithMinSelected(1))
+func MultiSelect(title string, options []Option, opts ...PromptOption) ([]Option, error) {
+	if len(options) == 0 {
+		return nil, ErrNoOptions
+	}
+
+	c := newPromptConfig(opts)
+	if chosen, ok, err := c.suppliedOptions(options); ok {
+		if err != nil {
+			return nil, err
+		}
+		// The same value supplied twice selects its option once
+		unique := make([]Option, 0, len(chosen))
+		for _, o := range chosen {
+			if !slices.Contains(unique, o) {
+				unique = append(unique, o)
+			}
+		}
+		chosen = unique
+		if err := c.checkSelected(len(chosen)); err != nil {
+			return nil, fmt.Errorf("invalid values: %w", err)
+		}
+		return chosen, nil
+	}
+
+	selected := make([]bool, len(options))
+	defaults := 0
+	for i, o := range options {
+		selected[i] = slices.Contains(c.defaults, o.Value)
+		if selected[i] {
+			defaults++
+		}
+	}
+	// Fewer defaults than the minimum are completed by the user
+	if c.max > 0 && defaults > c.max {
+		return nil, fmt.Errorf("invalid defaults: %w", c.checkSelected(defaults))
+	}
+
+	m, err := run(multiSelectModel{
+		title:    c.title(title),
+		options:  options,
+		list:     newStaticWindow(options, c.height),
+		selected: selected,
+		theme:    c.theme,
+		min:      c.min,
+		max:      c.max,
+	}, c)
+	if err != nil {
+		return nil, err
+	}
+	if m.aborted {
+		return nil, ErrAborted
+	}
+	return m.chosen(), nil
+}
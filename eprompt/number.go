@@ -0,0 +1,55 @@
+package eprompt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Prompt the user for an integer between lo and hi, both included.
+// Values that are not integers or out of range are rejected inline.
+//
+//	replicas, err := eprompt.Int("Replicas", 1, 10, eprompt.WithDefault("3"))
+func Int(title string, lo int, hi int, opts ...PromptOption) (int, error) {
+	validate := WithValidate(func(value string) error {
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		if n < lo || n > hi {
+			return fmt.Errorf("must be between %d and %d", lo, hi)
+		}
+		return nil
+	})
+
+	value, err := Input(title, append([]PromptOption{validate}, opts...)...)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(value))
+}
+
+// Prompt the user for a number between lo and hi, both included.
+// Values that are not numbers or out of range are rejected inline.
+//
+//	ratio, err := eprompt.Float("Sampling ratio", 0, 1)
+func Float(title string, lo float64, hi float64, opts ...PromptOption) (float64, error) {
+	validate := WithValidate(func(value string) error {
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return errors.New("must be a number")
+		}
+		if n < lo || n > hi {
+			return fmt.Errorf("must be between %g and %g", lo, hi)
+		}
+		return nil
+	})
+
+	value, err := Input(title, append([]PromptOption{validate}, opts...)...)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(value), 64)
+}
@@ -0,0 +1,49 @@
+package eprompt
+
+import "testing"
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+		err   bool
+	}{
+		{"5", 5, false},
+		{" 1 ", 1, false},
+		{"10", 10, false},
+		{"0", 0, true},
+		{"11", 0, true},
+		{"2.5", 0, true},
+		{"five", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := Int("n", 1, 10, WithValue(tt.value))
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("Int(%q) = %d, %v, want %d, error %v", tt.value, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+		err   bool
+	}{
+		{"0.5", 0.5, false},
+		{"0", 0, false},
+		{"1", 1, false},
+		{"1.5", 0, true},
+		{"-0.1", 0, true},
+		{"NaN", 0, true},
+		{"Inf", 0, true},
+		{"-Inf", 0, true},
+		{"half", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := Float("ratio", 0, 1, WithValue(tt.value))
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("Float(%q) = %g, %v, want %g, error %v", tt.value, got, err, tt.want, tt.err)
+		}
+	}
+}
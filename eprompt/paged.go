@@ -0,0 +1,186 @@
+package eprompt
+
+import (
+	"fmt"
+
+	"github.com/ravvio/easycli-ui/ekeys"
+)
+
+// Returns up to limit options starting from offset, used by SelectPaged to
+// load long lists of options lazily.
+type OptionProvider = func(offset int, limit int) []Option
+
+// Scrolling window over the options of a selection prompt, loading the
+// visible options from a provider as the cursor moves.
+type optionWindow struct {
+	total    int
+	provider OptionProvider
+	cache    map[int]Option
+	cursor   int
+	offset   int
+	height   int
+}
+
+func newOptionWindow(total int, provider OptionProvider, height int) optionWindow {
+	w := optionWindow{
+		total:    total,
+		provider: provider,
+		cache:    make(map[int]Option),
+		height:   max(height, 1),
+	}
+	w.fetch()
+	return w
+}
+
+// Create an optionWindow over a slice of options.
+func newStaticWindow(options []Option, height int) optionWindow {
+	return newOptionWindow(len(options), func(offset int, limit int) []Option {
+		return options[offset:min(offset+limit, len(options))]
+	}, height)
+}
+
+// Move the cursor by delta, wrapping around the ends of the list.
+func (w *optionWindow) move(delta int) {
+	if w.total == 0 {
+		return
+	}
+	w.moveTo((w.cursor + delta%w.total + w.total) % w.total)
+}
+
+// Move the cursor by delta pages, stopping at the ends of the list.
+func (w *optionWindow) page(delta int) {
+	w.moveTo(min(max(w.cursor+delta*w.height, 0), w.total-1))
+}
+
+// Move the cursor for a key of the lists, see ekeys.ListKey.
+func (w *optionWindow) navigate(a ekeys.ListAction) {
+	switch a {
+	case ekeys.ListUp:
+		w.move(-1)
+	case ekeys.ListDown:
+		w.move(1)
+	case ekeys.ListPageUp:
+		w.page(-1)
+	case ekeys.ListPageDown:
+		w.page(1)
+	case ekeys.ListHome:
+		w.moveTo(0)
+	case ekeys.ListEnd:
+		w.moveTo(w.total - 1)
+	}
+}
+
+// Move the cursor to the i-th option, scrolling the window if needed.
+func (w *optionWindow) moveTo(i int) {
+	if w.total == 0 {
+		return
+	}
+	w.cursor = min(max(i, 0), w.total-1)
+	if w.cursor < w.offset {
+		w.offset = w.cursor
+	}
+	if w.cursor >= w.offset+w.height {
+		w.offset = w.cursor - w.height + 1
+	}
+	w.fetch()
+}
+
+// Load the visible options that are not cached yet. When the provider
+// returns fewer options than requested, the list ends after the last one.
+func (w *optionWindow) fetch() {
+	start, end := w.visible()
+	for i := start; i < end; i++ {
+		if _, ok := w.cache[i]; ok {
+			continue
+		}
+		options := w.provider(i, end-i)
+		for j, o := range options {
+			w.cache[i+j] = o
+		}
+		if len(options) < end-i {
+			w.total = i + len(options)
+			w.offset = max(min(w.offset, w.total-w.height), 0)
+			w.moveTo(w.cursor)
+		}
+		return
+	}
+}
+
+// Returns the range of the visible options.
+func (w optionWindow) visible() (int, int) {
+	return w.offset, min(w.offset+w.height, w.total)
+}
+
+// Returns the i-th option, if it has been loaded.
+func (w optionWindow) at(i int) Option {
+	return w.cache[i]
+}
+
+// Returns the option under the cursor.
+func (w optionWindow) current() Option {
+	return w.at(w.cursor)
+}
+
+// Number of options loaded at once while looking for the supplied values.
+const suppliedPage = 100
+
+// Returns a function finding the option with a value among the total
+// options of provider, loading them a page at a time and only as far as
+// needed.
+func findPaged(total int, provider OptionProvider) func(value string) (Option, bool) {
+	var loaded []Option
+	return func(value string) (Option, bool) {
+		for i := 0; ; i++ {
+			if i == len(loaded) {
+				if len(loaded) >= total {
+					return Option{}, false
+				}
+				options := provider(len(loaded), min(suppliedPage, total-len(loaded)))
+				if len(options) == 0 {
+					return Option{}, false
+				}
+				loaded = append(loaded, options...)
+			}
+			if loaded[i].Value == value {
+				return loaded[i], true
+			}
+		}
+	}
+}
+
+// Render the position of the cursor when not all the options fit the window.
+func (w optionWindow) renderPosition(t Theme) string {
+	if w.total <= w.height {
+		return ""
+	}
+	return t.HelpStyle.Render(fmt.Sprintf("%d/%d", w.cursor+1, w.total)) + "\n"
+}
+
+// Prompt the user to choose one of total options, loaded lazily from
+// provider as the user scrolls through them. Only a window of the options
+// is rendered, see WithHeight.
+//
+//	pkg, err := eprompt.SelectPaged("Package", count, func(offset, limit int) []eprompt.Option {
+//		return db.ListPackages(offset, limit)
+//	})
+func SelectPaged(title string, total int, provider OptionProvider, opts ...PromptOption) (Option, error) {
+	if total <= 0 {
+		return Option{}, ErrNoOptions
+	}
+
+	c := newPromptConfig(opts)
+	if len(c.values) > 0 || c.env != "" || !interactive() {
+		if chosen, ok, err := c.suppliedMatching(findPaged(total, provider)); ok {
+			if err != nil {
+				return Option{}, err
+			}
+			return chosen[0], nil
+		}
+	}
+
+	list := newOptionWindow(total, provider, c.height)
+	if list.total == 0 {
+		return Option{}, ErrNoOptions
+	}
+	return runSelect(title, list, c)
+}
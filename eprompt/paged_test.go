@@ -0,0 +1,137 @@
+package eprompt
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ravvio/easycli-ui/ekeys"
+)
+
+// Returns a provider of n options and a pointer to the number of calls.
+func countingProvider(n int) (OptionProvider, *int) {
+	calls := 0
+	return func(offset int, limit int) []Option {
+		calls++
+		var options []Option
+		for i := offset; i < min(offset+limit, n); i++ {
+			options = append(options, Option{Label: strconv.Itoa(i), Value: strconv.Itoa(i)})
+		}
+		return options
+	}, &calls
+}
+
+func TestOptionWindowNavigate(t *testing.T) {
+	provider, _ := countingProvider(10)
+	tests := []struct {
+		actions []ekeys.ListAction
+		cursor  int
+		offset  int
+	}{
+		{nil, 0, 0},
+		{[]ekeys.ListAction{ekeys.ListDown, ekeys.ListDown}, 2, 0},
+		{[]ekeys.ListAction{ekeys.ListDown, ekeys.ListDown, ekeys.ListDown, ekeys.ListDown}, 4, 2},
+		{[]ekeys.ListAction{ekeys.ListUp}, 9, 7},
+		{[]ekeys.ListAction{ekeys.ListEnd, ekeys.ListDown}, 0, 0},
+		{[]ekeys.ListAction{ekeys.ListPageDown}, 3, 1},
+		{[]ekeys.ListAction{ekeys.ListPageDown, ekeys.ListPageDown, ekeys.ListPageDown, ekeys.ListPageDown}, 9, 7},
+		{[]ekeys.ListAction{ekeys.ListEnd, ekeys.ListPageUp}, 6, 6},
+		{[]ekeys.ListAction{ekeys.ListEnd, ekeys.ListHome}, 0, 0},
+	}
+	for _, tt := range tests {
+		w := newOptionWindow(10, provider, 3)
+		for _, a := range tt.actions {
+			w.navigate(a)
+		}
+		if w.cursor != tt.cursor || w.offset != tt.offset {
+			t.Errorf("%v: cursor, offset = %d, %d, want %d, %d", tt.actions, w.cursor, w.offset, tt.cursor, tt.offset)
+		}
+		if got := w.current().Value; got != strconv.Itoa(tt.cursor) {
+			t.Errorf("%v: current = %q, want %q", tt.actions, got, strconv.Itoa(tt.cursor))
+		}
+	}
+}
+
+func TestOptionWindowMoveTo(t *testing.T) {
+	provider, _ := countingProvider(10)
+	tests := []struct {
+		i      int
+		cursor int
+		offset int
+	}{
+		{-5, 0, 0},
+		{2, 2, 0},
+		{5, 5, 3},
+		{42, 9, 7},
+	}
+	for _, tt := range tests {
+		w := newOptionWindow(10, provider, 3)
+		w.moveTo(tt.i)
+		if w.cursor != tt.cursor || w.offset != tt.offset {
+			t.Errorf("moveTo(%d): cursor, offset = %d, %d, want %d, %d", tt.i, w.cursor, w.offset, tt.cursor, tt.offset)
+		}
+	}
+}
+
+func TestOptionWindowFetch(t *testing.T) {
+	provider, calls := countingProvider(10)
+	w := newOptionWindow(10, provider, 3)
+	if *calls != 1 {
+		t.Fatalf("calls = %d after the first window, want 1", *calls)
+	}
+	w.moveTo(1)
+	if *calls != 1 {
+		t.Errorf("calls = %d after moving inside the window, want 1", *calls)
+	}
+	w.moveTo(3)
+	if *calls != 2 {
+		t.Errorf("calls = %d after scrolling, want 2", *calls)
+	}
+	if start, end := w.visible(); start != 1 || end != 4 {
+		t.Errorf("visible = %d, %d, want 1, 4", start, end)
+	}
+}
+
+func TestOptionWindowShortProvider(t *testing.T) {
+	// The provider ends before the announced total
+	provider, _ := countingProvider(5)
+	w := newOptionWindow(100, provider, 3)
+	w.navigate(ekeys.ListEnd)
+	if w.total != 5 {
+		t.Errorf("total = %d, want 5", w.total)
+	}
+	if w.cursor != 4 || w.offset != 2 {
+		t.Errorf("cursor, offset = %d, %d, want 4, 2", w.cursor, w.offset)
+	}
+	if got := w.current().Value; got != "4" {
+		t.Errorf("current = %q, want %q", got, "4")
+	}
+}
+
+func TestOptionWindowEmpty(t *testing.T) {
+	w := newStaticWindow(nil, 3)
+	w.navigate(ekeys.ListDown)
+	w.navigate(ekeys.ListEnd)
+	if w.cursor != 0 || w.offset != 0 || w.total != 0 {
+		t.Errorf("cursor, offset, total = %d, %d, %d, want 0, 0, 0", w.cursor, w.offset, w.total)
+	}
+}
+
+func TestFindPaged(t *testing.T) {
+	provider, calls := countingProvider(250)
+	find := findPaged(1000, provider)
+	if o, ok := find("42"); !ok || o.Value != "42" {
+		t.Errorf("find(%q) = %v, %v, want the option", "42", o, ok)
+	}
+	if *calls != 1 {
+		t.Errorf("calls = %d after the first page, want 1", *calls)
+	}
+	if o, ok := find("150"); !ok || o.Value != "150" {
+		t.Errorf("find(%q) = %v, %v, want the option", "150", o, ok)
+	}
+	if _, ok := find("missing"); ok {
+		t.Errorf("find(%q) found an option", "missing")
+	}
+	if *calls != 4 {
+		t.Errorf("calls = %d, want 4", *calls)
+	}
+}
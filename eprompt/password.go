@@ -0,0 +1,122 @@
+package eprompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/x/term"
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+// Reader shared by the prompts reading from a stdin that is not a terminal.
+var stdin = sync.OnceValue(func() *bufio.Reader {
+	return bufio.NewReader(os.Stdin)
+})
+
+// Set the rune echoed in place of the characters typed in a Password prompt,
+// 0 hides the input completely. Defaults to '*'.
+//
+//	eprompt.Password("Token", eprompt.WithMask(0))
+func WithMask(r rune) PromptOption {
+	return func(c *promptConfig) {
+		c.mask = r
+	}
+}
+
+// Ask a Password a second time with the given title, prompting again until
+// both values match.
+//
+//	eprompt.Password("New passphrase", eprompt.WithRepeat("Repeat passphrase"))
+func WithRepeat(title string) PromptOption {
+	return func(c *promptConfig) {
+		c.repeat = title
+	}
+}
+
+// Prompt the user for a secret without echoing it.
+// When the terminal is not interactive the secret is read from stdin.
+//
+//	token, err := eprompt.Password("API token")
+func Password(title string, opts ...PromptOption) (string, error) {
+	c := newPromptConfig(opts)
+	if len(c.values) > 0 || c.env != "" {
+		if value, ok, err := c.suppliedText(); ok && !errors.Is(err, ErrNonInteractive) {
+			return value, err
+		}
+	}
+
+	for {
+		value, err := readSecret(title, c)
+		if err != nil || c.repeat == "" {
+			return value, err
+		}
+
+		repeated, err := readSecret(c.repeat, c)
+		if err != nil {
+			return "", err
+		}
+		if value == repeated {
+			return value, nil
+		}
+		fmt.Fprintln(os.Stderr, c.theme.ErrorStyle.Render("The values do not match, try again"))
+	}
+}
+
+func readSecret(title string, c promptConfig) (string, error) {
+	if !eterm.IsTerminal(os.Stdin) || !eterm.IsTerminal(os.Stdout) {
+		for {
+			value, err := readSecretPlain(title)
+			if value == "" {
+				value = c.defaultValue()
+			}
+			if err != nil || c.validate == nil {
+				return value, err
+			}
+			err = c.validate(value)
+			if err == nil {
+				return value, nil
+			}
+			fmt.Fprintln(os.Stderr, c.theme.renderError(err))
+		}
+	}
+
+	m := newInputModel(title, c)
+	m.history = nil
+	m.recalled = 0
+	m.input.EchoMode = textinput.EchoPassword
+	m.input.EchoCharacter = c.mask
+	if c.mask == 0 {
+		m.input.EchoMode = textinput.EchoNone
+	}
+
+	m, err := run(m, c)
+	if err != nil {
+		return "", err
+	}
+	if m.aborted {
+		return "", ErrAborted
+	}
+	return m.value(), nil
+}
+
+// Read a secret without a bubbletea program, disabling the echo if stdin is
+// a terminal.
+func readSecretPlain(title string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", title)
+	if term.IsTerminal(os.Stdin.Fd()) {
+		b, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		return string(b), err
+	}
+
+	line, err := stdin().ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
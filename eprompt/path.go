@@ -0,0 +1,157 @@
+package eprompt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Maximum number of completion candidates displayed by a Path prompt.
+const pathMaxChoices = 10
+
+// Kind of filesystem entry accepted by a Path prompt.
+//
+//	eprompt.Path("Output directory", eprompt.WithPathKind(eprompt.PathDir))
+type PathKind int
+
+const (
+	PathAny PathKind = iota
+	PathFile
+	PathDir
+)
+
+// Reject paths of a Path prompt that do not exist.
+//
+//	eprompt.Path("Config file", eprompt.WithMustExist(true))
+func WithMustExist(e bool) PromptOption {
+	return func(c *promptConfig) {
+		c.mustExist = e
+	}
+}
+
+// Reject paths of a Path prompt that exist but are not of the given kind.
+//
+//	eprompt.Path("Output directory", eprompt.WithPathKind(eprompt.PathDir))
+func WithPathKind(k PathKind) PromptOption {
+	return func(c *promptConfig) {
+		c.pathKind = k
+	}
+}
+
+// Expand a leading ~ to the home directory of the user.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// Complete the path typed by the user, returning the completed value and
+// the candidates when the completion is ambiguous.
+func completePath(value string) (string, []string) {
+	dir, base := filepath.Split(value)
+	entries, err := os.ReadDir(expandHome(dirOrDot(dir)))
+	if err != nil {
+		return value, nil
+	}
+
+	matches := make([]string, 0)
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+		if e.IsDir() {
+			name += string(filepath.Separator)
+		}
+		matches = append(matches, name)
+	}
+	sort.Strings(matches)
+
+	switch len(matches) {
+	case 0:
+		return value, nil
+	case 1:
+		return dir + matches[0], nil
+	}
+
+	prefix := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(matches) > pathMaxChoices {
+		matches = append(matches[:pathMaxChoices], "...")
+	}
+	return dir + prefix, matches
+}
+
+func dirOrDot(dir string) string {
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+// Returns a validator checking the existence and kind of a path.
+func validatePath(mustExist bool, kind PathKind) Validator {
+	return func(value string) error {
+		if value == "" {
+			return errors.New("a path is required")
+		}
+		info, err := os.Stat(expandHome(value))
+		if errors.Is(err, os.ErrNotExist) {
+			if mustExist {
+				return errors.New("the path does not exist")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if kind == PathDir && !info.IsDir() {
+			return errors.New("the path is not a directory")
+		}
+		if kind == PathFile && info.IsDir() {
+			return errors.New("the path is a directory")
+		}
+		return nil
+	}
+}
+
+// Prompt the user for a filesystem path, completing it with Tab.
+// A leading ~ is expanded to the home directory of the user.
+//
+//	dir, err := eprompt.Path("Output directory",
+//		eprompt.WithMustExist(true),
+//		eprompt.WithPathKind(eprompt.PathDir),
+//	)
+func Path(title string, opts ...PromptOption) (string, error) {
+	c := newPromptConfig(opts)
+	c = newPromptConfig(append([]PromptOption{
+		WithValidate(validatePath(c.mustExist, c.pathKind)),
+	}, opts...))
+
+	if value, ok, err := c.suppliedText(); ok {
+		return expandHome(value), err
+	}
+
+	m := newInputModel(title, c)
+	m.complete = completePath
+	m, err := run(m, c)
+	if err != nil {
+		return "", err
+	}
+	if m.aborted {
+		return "", ErrAborted
+	}
+	c.remember(m.value())
+	return expandHome(m.value()), nil
+}
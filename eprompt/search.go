@@ -0,0 +1,279 @@
+package eprompt
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/internal/fuzzy"
+)
+
+// Delay between the last keystroke and the loading of the options of a
+// SearchAsync prompt.
+const searchDebounce = 150 * time.Millisecond
+
+// The bubbletea.Msg sent when the query of a search should be loaded
+type searchMsgLoad struct {
+	seq int
+}
+
+// The bubbletea.Msg sent when the options of a search have been loaded
+type searchMsgLoaded struct {
+	seq     int
+	options []Option
+	err     error
+}
+
+// Bubbletea model of a searchable selection prompt.
+type searchModel struct {
+	title   string
+	input   textinput.Model
+	options []Option
+	load    func(query string) ([]Option, error)
+	matches []Option
+	seq     int
+	loading bool
+	err     error
+	cursor  int
+	offset  int
+	height  int
+	done    bool
+	aborted bool
+	theme   Theme
+}
+
+func newSearchModel(title string, c promptConfig) searchModel {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = c.placeholder
+	if ti.Placeholder == "" {
+		ti.Placeholder = "type to search"
+	}
+	ti.Focus()
+	return searchModel{
+		title:  c.title(title),
+		input:  ti,
+		height: max(c.height, 1),
+		theme:  c.theme,
+	}
+}
+
+func (m searchModel) Init() tea.Cmd {
+	if m.load != nil {
+		return tea.Batch(textinput.Blink, m.loadCmd())
+	}
+	return textinput.Blink
+}
+
+// Load the options matching the current query in the background.
+func (m searchModel) loadCmd() tea.Cmd {
+	seq, query, load := m.seq, m.input.Value(), m.load
+	return func() tea.Msg {
+		options, err := load(query)
+		return searchMsgLoaded{seq: seq, options: options, err: err}
+	}
+}
+
+func (m searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case "enter":
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+			m.done = true
+			return m, tea.Quit
+		}
+		if a := ekeys.ListKey(msg.String(), true); a != ekeys.ListNone {
+			m.navigate(a)
+			return m, nil
+		}
+	case searchMsgLoad:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		m.loading = true
+		return m, m.loadCmd()
+	case searchMsgLoaded:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		m.setMatches(msg.options)
+		return m, nil
+	}
+
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() == query {
+		return m, cmd
+	}
+
+	m.seq++
+	if m.load != nil {
+		seq := m.seq
+		return m, tea.Batch(cmd, tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+			return searchMsgLoad{seq: seq}
+		}))
+	}
+	m.filter()
+	return m, cmd
+}
+
+// Filter the static options of the prompt with the current query.
+func (m *searchModel) filter() {
+	labels := make([]string, len(m.options))
+	for i, o := range m.options {
+		labels[i] = o.Label
+	}
+
+	matches := make([]Option, 0)
+	for _, i := range fuzzy.Filter(m.input.Value(), labels) {
+		matches = append(matches, m.options[i])
+	}
+	m.setMatches(matches)
+}
+
+func (m *searchModel) setMatches(matches []Option) {
+	m.matches = matches
+	m.cursor = 0
+	m.offset = 0
+}
+
+// Move the cursor for a key of the lists, see ekeys.ListKey. The cursor
+// stops at the ends of the matches.
+func (m *searchModel) navigate(a ekeys.ListAction) {
+	switch a {
+	case ekeys.ListUp:
+		m.moveCursor(-1)
+	case ekeys.ListDown:
+		m.moveCursor(1)
+	case ekeys.ListPageUp:
+		m.moveCursor(-m.height)
+	case ekeys.ListPageDown:
+		m.moveCursor(m.height)
+	case ekeys.ListHome:
+		m.moveCursor(-len(m.matches))
+	case ekeys.ListEnd:
+		m.moveCursor(len(m.matches))
+	}
+}
+
+// Move the cursor by delta, scrolling the visible window if needed.
+func (m *searchModel) moveCursor(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.matches)-1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+}
+
+func (m searchModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.theme.renderTitle(m.title) + " ")
+	if m.done {
+		b.WriteString(m.theme.AnswerStyle.Render(m.matches[m.cursor].Label) + "\n")
+		return b.String()
+	}
+	if m.aborted {
+		return b.String() + "\n"
+	}
+	b.WriteString(m.input.View() + "\n")
+
+	end := min(m.offset+m.height, len(m.matches))
+	for i := m.offset; i < end; i++ {
+		b.WriteString(m.theme.renderOption(m.matches[i], "", i == m.cursor))
+	}
+
+	switch {
+	case m.err != nil:
+		b.WriteString(m.theme.ErrorStyle.Render(m.err.Error()) + "\n")
+	case m.loading:
+		b.WriteString(m.theme.HelpStyle.Render("Loading...") + "\n")
+	case len(m.matches) == 0:
+		b.WriteString(m.theme.HelpStyle.Render("No matches") + "\n")
+	default:
+		b.WriteString(m.theme.HelpStyle.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.matches))) + "\n")
+	}
+	return b.String()
+}
+
+// Prompt the user to choose one of the options, filtering them by typing.
+// Options are matched fuzzily on their label and only a window of them
+// is rendered, see WithHeight.
+//
+//	pkg, err := eprompt.Search("Package", packages)
+func Search(title string, options []Option, opts ...PromptOption) (Option, error) {
+	if len(options) == 0 {
+		return Option{}, ErrNoOptions
+	}
+
+	c := newPromptConfig(opts)
+	if chosen, ok, err := c.suppliedOptions(options); ok {
+		if err != nil {
+			return Option{}, err
+		}
+		return chosen[0], nil
+	}
+
+	m := newSearchModel(title, c)
+	m.options = options
+	m.filter()
+	m.moveCursor(c.defaultIndex(options))
+	return runSearch(m, c)
+}
+
+// Prompt the user to choose one of the options returned by load for the
+// typed query. load is called in the background after the user stops
+// typing, so it can query remote services.
+//
+//	repo, err := eprompt.SearchAsync("Repository", func(query string) ([]eprompt.Option, error) {
+//		return client.SearchRepositories(query)
+//	})
+func SearchAsync(title string, load func(query string) ([]Option, error), opts ...PromptOption) (Option, error) {
+	c := newPromptConfig(opts)
+	if values, ok, err := c.supplied(); ok {
+		if err != nil {
+			return Option{}, err
+		}
+		options, err := load(values[0])
+		if err != nil {
+			return Option{}, err
+		}
+		chosen, _, err := c.suppliedOptions(options)
+		if err != nil {
+			return Option{}, err
+		}
+		return chosen[0], nil
+	}
+
+	m := newSearchModel(title, c)
+	m.load = load
+	m.loading = true
+	return runSearch(m, c)
+}
+
+func runSearch(m searchModel, c promptConfig) (Option, error) {
+	m, err := run(m, c)
+	if err != nil {
+		return Option{}, err
+	}
+	if m.aborted {
+		return Option{}, ErrAborted
+	}
+	return m.matches[m.cursor], nil
+}
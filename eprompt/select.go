@@ -0,0 +1,102 @@
+package eprompt
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/ekeys"
+)
+
+// Option of a selection prompt. The Label is displayed to the user, the
+// optional Description is rendered next to it.
+type Option struct {
+	Label       string
+	Value       string
+	Description string
+}
+
+// Bubbletea model of a single selection prompt.
+type selectModel struct {
+	title   string
+	list    optionWindow
+	done    bool
+	aborted bool
+	theme   Theme
+}
+
+func (m selectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m selectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		default:
+			m.list.navigate(ekeys.ListKey(msg.String(), false))
+		}
+	}
+	return m, nil
+}
+
+func (m selectModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.theme.renderTitle(m.title))
+	if m.done {
+		b.WriteString(" " + m.theme.AnswerStyle.Render(m.list.current().Label) + "\n")
+		return b.String()
+	}
+	b.WriteString("\n")
+	if m.aborted {
+		return b.String()
+	}
+
+	start, end := m.list.visible()
+	for i := start; i < end; i++ {
+		b.WriteString(m.theme.renderOption(m.list.at(i), "", i == m.list.cursor))
+	}
+	b.WriteString(m.list.renderPosition(m.theme))
+	return b.String()
+}
+
+// Prompt the user to choose one of the options with the arrow keys.
+// Only a window of the options is rendered, see WithHeight.
+//
+//	env, err := eprompt.Select("Environment", []eprompt.Option{
+//		{Label: "Production", Value: "prod", Description: "Live traffic"},
+//		{Label: "Staging", Value: "staging"},
+//	})
+func Select(title string, options []Option, opts ...PromptOption) (Option, error) {
+	if len(options) == 0 {
+		return Option{}, ErrNoOptions
+	}
+
+	c := newPromptConfig(opts)
+	if chosen, ok, err := c.suppliedOptions(options); ok {
+		if err != nil {
+			return Option{}, err
+		}
+		return chosen[0], nil
+	}
+
+	list := newStaticWindow(options, c.height)
+	list.moveTo(c.defaultIndex(options))
+	return runSelect(title, list, c)
+}
+
+func runSelect(title string, list optionWindow, c promptConfig) (Option, error) {
+	m, err := run(selectModel{title: c.title(title), list: list, theme: c.theme}, c)
+	if err != nil {
+		return Option{}, err
+	}
+	if m.aborted {
+		return Option{}, ErrAborted
+	}
+	return m.list.current(), nil
+}
@@ -0,0 +1,131 @@
+package eprompt
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/ekeys"
+)
+
+// Bubbletea model of a multi-line text prompt.
+type textAreaModel struct {
+	title    string
+	area     textarea.Model
+	validate Validator
+	err      error
+	done     bool
+	aborted  bool
+	theme    Theme
+}
+
+func newTextAreaModel(title string, initial string, c promptConfig) textAreaModel {
+	ta := textarea.New()
+	ta.ShowLineNumbers = false
+	ta.Prompt = "│ "
+	ta.Placeholder = c.placeholder
+	ta.CharLimit = c.charLimit
+	if c.width > 0 {
+		ta.SetWidth(c.width)
+	}
+	ta.SetHeight(max(c.height, 1))
+	ta.SetValue(initial)
+	ta.Focus()
+	return textAreaModel{
+		title:    title,
+		area:     ta,
+		validate: c.validate,
+		theme:    c.theme,
+	}
+}
+
+func (m textAreaModel) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m textAreaModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.err = nil
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case "ctrl+d", "ctrl+s":
+			if m.validate != nil {
+				if m.err = m.validate(m.area.Value()); m.err != nil {
+					return m, nil
+				}
+			}
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.area, cmd = m.area.Update(msg)
+	return m, cmd
+}
+
+func (m textAreaModel) View() string {
+	s := m.theme.renderTitle(m.title) + " "
+	if m.done {
+		return s + m.theme.AnswerStyle.Render(summarizeText(m.area.Value())) + "\n"
+	}
+	if m.aborted {
+		return s + "\n"
+	}
+	s += "\n" + m.area.View() + "\n"
+	if m.err != nil {
+		s += m.theme.renderError(m.err) + "\n"
+	}
+	return s + m.theme.HelpStyle.Render(m.counter()+" · "+ekeys.Plain(ekeys.Bind("ctrl+d", "submit"), ekeys.Bind("esc", "cancel"))) + "\n"
+}
+
+// Render the number of characters of the value, and the limit if any.
+func (m textAreaModel) counter() string {
+	n := utf8.RuneCountInString(m.area.Value())
+	if m.area.CharLimit > 0 {
+		return fmt.Sprintf("%d/%d", n, m.area.CharLimit)
+	}
+	return fmt.Sprintf("%d chars", n)
+}
+
+// Describe a multi-line answer in a single line.
+func summarizeText(s string) string {
+	s = strings.TrimRight(s, "\n")
+	lines := strings.Count(s, "\n") + 1
+	if s == "" {
+		lines = 0
+	}
+	if lines == 1 {
+		return s
+	}
+	return fmt.Sprintf("%d lines", lines)
+}
+
+func runTextArea(title string, initial string, c promptConfig) (string, error) {
+	m, err := run(newTextAreaModel(title, initial, c), c)
+	if err != nil {
+		return "", err
+	}
+	if m.aborted {
+		return "", ErrAborted
+	}
+	return m.area.Value(), nil
+}
+
+// Prompt the user for a multi-line text, submitted with Ctrl+D or Ctrl+S.
+// The height of the input is set with WithHeight, the number of characters
+// with WithCharLimit and the initial text with WithDefault.
+//
+//	message, err := eprompt.TextArea("Commit message", eprompt.WithHeight(5), eprompt.WithValidate(eprompt.Required()))
+func TextArea(title string, opts ...PromptOption) (string, error) {
+	c := newPromptConfig(append([]PromptOption{WithHeight(6)}, opts...))
+	if value, ok, err := c.suppliedText(); ok {
+		return value, err
+	}
+	return runTextArea(c.title(title), c.defaultValue(), c)
+}
@@ -0,0 +1,79 @@
+package eprompt
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Theme of the prompts. The default theme shares the colors of the other
+// components, from the current etheme.Theme.
+type Theme struct {
+	Glyph         string
+	GlyphStyle    lipgloss.Style
+	TitleStyle    lipgloss.Style
+	AnswerStyle   lipgloss.Style
+	Cursor        string
+	SelectedStyle lipgloss.Style
+	ErrorStyle    lipgloss.Style
+	HelpStyle     lipgloss.Style
+}
+
+// Default Theme used by the prompts. Uses the primary color of the
+// etheme.Theme for the glyph and the selection, the success color for
+// answers and the error color for errors.
+var ThemeDefault = promptTheme(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		ThemeDefault = promptTheme(t)
+	})
+}
+
+// Returns the Theme of the prompts for the theme t.
+func promptTheme(t etheme.Theme) Theme {
+	return Theme{
+		Glyph:         "?",
+		GlyphStyle:    lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		TitleStyle:    lipgloss.NewStyle().Bold(true),
+		AnswerStyle:   lipgloss.NewStyle().Foreground(t.Success),
+		Cursor:        ">",
+		SelectedStyle: lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		ErrorStyle:    lipgloss.NewStyle().Foreground(t.Error),
+		HelpStyle:     lipgloss.NewStyle().Faint(true),
+	}
+}
+
+// Specify the Theme of a prompt, ThemeDefault is used otherwise.
+//
+//	name, err := eprompt.Input("Name", eprompt.WithTheme(myTheme))
+func WithTheme(t Theme) PromptOption {
+	return func(c *promptConfig) {
+		c.theme = t
+	}
+}
+
+// Render the header line of a prompt.
+func (t Theme) renderTitle(title string) string {
+	return t.GlyphStyle.Render(t.Glyph) + " " + t.TitleStyle.Render(title)
+}
+
+// Render a validation error below a prompt.
+func (t Theme) renderError(err error) string {
+	return t.ErrorStyle.Render("✗ " + err.Error())
+}
+
+// Render a line of a selection prompt, marker is rendered between the cursor
+// and the label.
+func (t Theme) renderOption(o Option, marker string, active bool) string {
+	s := "  "
+	label := marker + o.Label
+	if active {
+		s = t.SelectedStyle.Render(t.Cursor + " ")
+		label = t.SelectedStyle.Render(label)
+	}
+	s += label
+	if o.Description != "" {
+		s += "  " + t.HelpStyle.Render(o.Description)
+	}
+	return s + "\n"
+}
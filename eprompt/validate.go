@@ -0,0 +1,106 @@
+package eprompt
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator checks the value submitted to a prompt, a non nil error rejects
+// the value and is displayed to the user.
+type Validator = func(value string) error
+
+// Validate the value submitted to the prompt, keeping the prompt open until
+// all the validators accept it. Validators are run in order.
+//
+//	eprompt.Input("Email", eprompt.WithValidate(
+//		eprompt.Required(),
+//		eprompt.Regexp(`^[^@]+@[^@]+$`, "not a valid email"),
+//	))
+func WithValidate(validators ...Validator) PromptOption {
+	return func(c *promptConfig) {
+		prev := c.validate
+		c.validate = func(value string) error {
+			if prev != nil {
+				if err := prev(value); err != nil {
+					return err
+				}
+			}
+			for _, v := range validators {
+				if err := v(value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// Reject empty or blank values.
+func Required() Validator {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New("a value is required")
+		}
+		return nil
+	}
+}
+
+// Reject values not matching the regular expression pattern, message is
+// displayed to the user. Panics if pattern is not a valid expression.
+//
+//	eprompt.Regexp(`^[a-z0-9-]+$`, "only lowercase letters, digits and dashes")
+func Regexp(pattern string, message string) Validator {
+	re := regexp.MustCompile(pattern)
+	return func(value string) error {
+		if !re.MatchString(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// Reject values shorter than n characters.
+func MinLength(n int) Validator {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) < n {
+			return fmt.Errorf("must be at least %d characters long", n)
+		}
+		return nil
+	}
+}
+
+// Reject values longer than n characters.
+func MaxLength(n int) Validator {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Errorf("must be at most %d characters long", n)
+		}
+		return nil
+	}
+}
+
+// Reject values that are not integers.
+func Integer() Validator {
+	return func(value string) error {
+		if _, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err != nil {
+			return errors.New("must be an integer")
+		}
+		return nil
+	}
+}
+
+// Reject values that are not absolute URLs.
+func URL() Validator {
+	return func(value string) error {
+		u, err := url.Parse(strings.TrimSpace(value))
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("must be a valid URL")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,254 @@
+package eprompt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Answers collected by a Wizard, by step key.
+type Answers map[string]any
+
+// Function asking the question of a step of a Wizard. It receives the
+// answers to the previous steps and must pass opts to the prompt it runs.
+//
+//	func(a eprompt.Answers, opts ...eprompt.PromptOption) (any, error) {
+//		return eprompt.Input("Project name", opts...)
+//	}
+type WizardAsk = func(a Answers, opts ...PromptOption) (any, error)
+
+type wizardStep struct {
+	key  string
+	ask  WizardAsk
+	when func(a Answers) bool
+}
+
+// Wizard runs a sequence of prompts showing the progress of the user,
+// who can go back to edit the previous answers with Esc, Esc on the first
+// step aborts with ErrAborted. Steps can be conditional on previous answers
+// and a final review screen lets the user edit any answer before submitting,
+// coming back to the review after the edited step. Esc on the review screen
+// goes back to the last step.
+type Wizard struct {
+	steps  []wizardStep
+	review bool
+}
+
+// Create a new empty Wizard.
+//
+//	w := eprompt.NewWizard()
+func NewWizard() Wizard {
+	return Wizard{
+		steps:  []wizardStep{},
+		review: true,
+	}
+}
+
+// Add a step to the Wizard, its answer is stored with the given key.
+//
+//	w := eprompt.NewWizard().
+//		WithStep("name", func(a eprompt.Answers, opts ...eprompt.PromptOption) (any, error) {
+//			return eprompt.Input("Project name", opts...)
+//		})
+func (w Wizard) WithStep(key string, ask WizardAsk) Wizard {
+	return w.WithStepWhen(key, nil, ask)
+}
+
+// Add a step to the Wizard that is asked only when the condition on the
+// previous answers holds.
+//
+//	w := w.WithStepWhen("domain",
+//		func(a eprompt.Answers) bool { return a["public"] == true },
+//		func(a eprompt.Answers, opts ...eprompt.PromptOption) (any, error) {
+//			return eprompt.Input("Domain", opts...)
+//		},
+//	)
+func (w Wizard) WithStepWhen(key string, when func(a Answers) bool, ask WizardAsk) Wizard {
+	steps := make([]wizardStep, len(w.steps), len(w.steps)+1)
+	copy(steps, w.steps)
+	w.steps = append(steps, wizardStep{key: key, ask: ask, when: when})
+	return w
+}
+
+// Show or hide the review screen displayed after the last step.
+//
+//	w := eprompt.NewWizard().WithReview(false)
+func (w Wizard) WithReview(r bool) Wizard {
+	w.review = r
+	return w
+}
+
+// Returns the indexes of the steps to ask given the current answers.
+func (w Wizard) active(a Answers) []int {
+	active := make([]int, 0)
+	for i, s := range w.steps {
+		if s.when == nil || s.when(a) {
+			active = append(active, i)
+		}
+	}
+	return active
+}
+
+// Run the steps of the Wizard and return the collected answers.
+//
+//	answers, err := w.Run()
+//	name := answers["name"].(string)
+func (w Wizard) Run() (Answers, error) {
+	answers := Answers{}
+	pos := 0
+	editing := false
+	for {
+		active := w.active(answers)
+		if pos >= len(active) {
+			if !w.review {
+				return answers.prune(w, active), nil
+			}
+			edit, err := w.runReview(answers, active)
+			if errors.Is(err, errBack) {
+				pos, editing = len(active)-1, false
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if edit < 0 {
+				return answers.prune(w, active), nil
+			}
+			pos, editing = edit, true
+			continue
+		}
+
+		step := w.steps[active[pos]]
+		opts := []PromptOption{withStep(fmt.Sprintf("[%d/%d]", pos+1, len(active)))}
+		if pos > 0 {
+			// Esc on the first step aborts the Wizard
+			opts = append(opts, withBack())
+		}
+		if prev, ok := answers[step.key]; ok {
+			opts = append(opts, WithDefault(defaultsOf(prev)...))
+		}
+
+		value, err := step.ask(answers, opts...)
+		if errors.Is(err, errBack) {
+			pos--
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		answers[step.key] = value
+		pos++
+		if editing {
+			// Back to the review, asking first the steps made active by the
+			// edited answer
+			active = w.active(answers)
+			for pos < len(active) {
+				if _, ok := answers[w.steps[active[pos]].key]; !ok {
+					break
+				}
+				pos++
+			}
+		}
+	}
+}
+
+// Show the review screen, returning the position of the step to edit or
+// -1 to submit the answers, or errBack when the user goes back to the last
+// step.
+func (w Wizard) runReview(answers Answers, active []int) (int, error) {
+	options := []Option{{Label: "Submit", Value: "-1"}}
+	for pos, i := range active {
+		key := w.steps[i].key
+		options = append(options, Option{
+			Label:       "Edit " + key,
+			Value:       fmt.Sprint(pos),
+			Description: formatAnswer(answers[key]),
+		})
+	}
+
+	opts := []PromptOption{}
+	if len(active) > 0 {
+		opts = append(opts, withBack())
+	}
+	o, err := Select("Review your answers", options, opts...)
+	if err != nil {
+		return 0, err
+	}
+	var pos int
+	_, err = fmt.Sscan(o.Value, &pos)
+	return pos, err
+}
+
+// Returns the answers without the ones of the steps that are no longer
+// active.
+func (a Answers) prune(w Wizard, active []int) Answers {
+	pruned := Answers{}
+	for _, i := range active {
+		key := w.steps[i].key
+		if v, ok := a[key]; ok {
+			pruned[key] = v
+		}
+	}
+	return pruned
+}
+
+// Returns the default values for a prompt whose previous answer is v.
+func defaultsOf(v any) []string {
+	switch v := v.(type) {
+	case Option:
+		return []string{v.Value}
+	case []Option:
+		values := make([]string, len(v))
+		for i, o := range v {
+			values[i] = o.Value
+		}
+		return values
+	case bool:
+		if v {
+			return []string{"yes"}
+		}
+		return []string{"no"}
+	case time.Time:
+		return []string{v.Format("2006-01-02 15:04")}
+	}
+	return []string{fmt.Sprint(v)}
+}
+
+// Format an answer for the review screen.
+func formatAnswer(v any) string {
+	switch v := v.(type) {
+	case Option:
+		return v.Label
+	case []Option:
+		labels := make([]string, len(v))
+		for i, o := range v {
+			labels[i] = o.Label
+		}
+		return strings.Join(labels, ", ")
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case time.Time:
+		return v.Format("2006-01-02 15:04")
+	case string:
+		return summarizeText(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// Prefix the title of the prompt with the step of a Wizard.
+func withStep(step string) PromptOption {
+	return func(c *promptConfig) {
+		c.step = step
+	}
+}
+
+// Make Esc return errBack instead of aborting the prompt.
+func withBack() PromptOption {
+	return func(c *promptConfig) {
+		c.back = true
+	}
+}
@@ -0,0 +1,109 @@
+// Package ereplay records what the components draw on the terminal, like
+// spinners, prompts and tables, to a file and plays it back with the same
+// timing. Keys can be scripted to answer the prompts, to produce
+// reproducible demos, and users can attach a recording to the issues they
+// report.
+//
+//	stop, err := ereplay.NewRecorder("demo.cast").
+//		WithScript(ereplay.NewScript().Sleep(time.Second).Type("prod").Key("enter")).
+//		Start()
+//	if err != nil {
+//		return err
+//	}
+//	defer stop()
+//
+// The recordings use the asciicast v2 format of asciinema, so they can also
+// be played with its tools.
+package ereplay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+var (
+	// ErrRecording is returned when starting a recording while another one
+	// is running.
+	ErrRecording = errors.New("already recording")
+	// ErrInvalidRecording is returned when a recording cannot be read.
+	ErrInvalidRecording = errors.New("invalid recording")
+	// ErrInterrupted is returned when the playback is interrupted by the
+	// user.
+	ErrInterrupted = eterm.ErrInterrupted
+)
+
+// Version of the asciicast format written and read.
+const castVersion = 2
+
+// First line of a recording.
+type header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// A chunk of output written at Time since the start of the recording.
+type frame struct {
+	Time time.Duration
+	Data string
+}
+
+// Write the frame f as an output event, [time, "o", data].
+func writeFrame(w io.Writer, f frame) error {
+	data, err := json.Marshal(f.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "[%.6f, \"o\", %s]\n", f.Time.Seconds(), data)
+	return err
+}
+
+// Read the header of the recording decoded by d.
+func readHeader(d *json.Decoder) (header, error) {
+	var h header
+	if err := d.Decode(&h); err != nil {
+		return h, fmt.Errorf("%w: %v", ErrInvalidRecording, err)
+	}
+	if h.Version != castVersion {
+		return h, fmt.Errorf("%w: unsupported version %d", ErrInvalidRecording, h.Version)
+	}
+	return h, nil
+}
+
+// Read the next output frame of the recording decoded by d, skipping the
+// other events like the input. Returns io.EOF at the end of the recording.
+func readFrame(d *json.Decoder) (frame, error) {
+	for {
+		var event []json.RawMessage
+		if err := d.Decode(&event); err != nil {
+			if errors.Is(err, io.EOF) {
+				return frame{}, io.EOF
+			}
+			return frame{}, fmt.Errorf("%w: %v", ErrInvalidRecording, err)
+		}
+		if len(event) != 3 {
+			return frame{}, fmt.Errorf("%w: event of %d fields", ErrInvalidRecording, len(event))
+		}
+
+		var (
+			seconds float64
+			kind    string
+			data    string
+		)
+		for i, v := range []any{&seconds, &kind, &data} {
+			if err := json.Unmarshal(event[i], v); err != nil {
+				return frame{}, fmt.Errorf("%w: %v", ErrInvalidRecording, err)
+			}
+		}
+		if kind == "o" {
+			return frame{Time: time.Duration(seconds * float64(time.Second)), Data: data}, nil
+		}
+	}
+}
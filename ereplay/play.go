@@ -0,0 +1,119 @@
+package ereplay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Player writes the frames of a recording to stdout with their timing.
+type Player struct {
+	path    string
+	speed   float64
+	maxIdle time.Duration
+}
+
+// Create a Player of the recording at path.
+//
+//	err := ereplay.NewPlayer("demo.cast").Play()
+func NewPlayer(path string) Player {
+	return Player{path: path, speed: 1}
+}
+
+// Specify the speed of the playback, 2 plays the recording twice as fast.
+//
+//	player := ereplay.NewPlayer("demo.cast").WithSpeed(2)
+func (p Player) WithSpeed(speed float64) Player {
+	if speed > 0 {
+		p.speed = speed
+	}
+	return p
+}
+
+// Specify the longest pause between two frames, longer ones are shortened
+// to d. Useful to skip the time spent waiting for the user or the network.
+//
+//	player := ereplay.NewPlayer("demo.cast").WithMaxIdle(time.Second)
+func (p Player) WithMaxIdle(d time.Duration) Player {
+	p.maxIdle = d
+	return p
+}
+
+// Play the recording until its end, or until the user interrupts it with
+// ctrl+c, returning ErrInterrupted. The terminal should be at least as large
+// as the recorded one, see Size.
+//
+//	if err := ereplay.NewPlayer(path).Play(); err != nil {
+//		return err
+//	}
+func (p Player) Play() error {
+	f, err := os.Open(p.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return p.play(ctx, f, os.Stdout)
+}
+
+func (p Player) play(ctx context.Context, r io.Reader, w io.Writer) error {
+	d := json.NewDecoder(bufio.NewReader(r))
+	if _, err := readHeader(d); err != nil {
+		return err
+	}
+
+	var last time.Duration
+	for {
+		f, err := readFrame(d)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		pause := max(f.Time-last, 0)
+		if p.maxIdle > 0 {
+			pause = min(pause, p.maxIdle)
+		}
+		last = f.Time
+		t := time.NewTimer(time.Duration(float64(pause) / p.speed))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			// Leave the terminal as the recorded program would on exit
+			fmt.Fprint(w, ansi.ResetStyle+ansi.ShowCursor+ansi.ResetAltScreenSaveCursorMode+"\n")
+			return ErrInterrupted
+		case <-t.C:
+		}
+		if _, err := io.WriteString(w, f.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// Returns the size of the terminal of the recording at path, in cells.
+//
+//	width, height, err := ereplay.Size("demo.cast")
+//	if w, h := eterm.Size(); err == nil && (w < width || h < height) {
+//		fmt.Printf("resize the terminal to %dx%d\n", width, height)
+//	}
+func Size(path string) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	h, err := readHeader(json.NewDecoder(f))
+	return h.Width, h.Height, err
+}
@@ -0,0 +1,210 @@
+package ereplay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+var (
+	recordingMu sync.Mutex
+	recording   bool
+)
+
+// Recorder writes what is printed on stdout and stderr to a file, along
+// with the time it was printed.
+type Recorder struct {
+	path   string
+	width  int
+	height int
+	script Script
+}
+
+// Create a Recorder writing to the file at path, which is replaced.
+//
+//	rec := ereplay.NewRecorder("demo.cast")
+func NewRecorder(path string) Recorder {
+	return Recorder{path: path}
+}
+
+// Specify the size of the recorded terminal, by default the size of the
+// terminal when the recording starts. The components render to this size
+// for the whole recording.
+//
+//	rec := ereplay.NewRecorder("demo.cast").WithSize(100, 30)
+func (r Recorder) WithSize(width int, height int) Recorder {
+	r.width = width
+	r.height = height
+	return r
+}
+
+// Specify the keys pressed in the interactive components during the
+// recording. The keys typed by the user are received as well.
+//
+//	script := ereplay.NewScript().Sleep(time.Second).Key("down", "enter")
+//	rec := ereplay.NewRecorder("demo.cast").WithScript(script)
+func (r Recorder) WithScript(s Script) Recorder {
+	r.script = s
+	return r
+}
+
+// Start recording, until the returned function is called. Meanwhile
+// os.Stdout and os.Stderr are replaced, so the output written to the files
+// they referred to before, like the one of a logger created earlier, is not
+// recorded. The components are rendered as in a terminal, even when the
+// output is not one, and their colors are the ones detected for the
+// terminal, see eterm.ForceColorDepth.
+//
+//	stop, err := ereplay.NewRecorder("demo.cast").Start()
+//	if err != nil {
+//		return err
+//	}
+//	defer stop()
+func (r Recorder) Start() (func() error, error) {
+	if err := r.script.validate(); err != nil {
+		return nil, err
+	}
+	recordingMu.Lock()
+	defer recordingMu.Unlock()
+	if recording {
+		return nil, ErrRecording
+	}
+
+	f, err := os.Create(r.path)
+	if err != nil {
+		return nil, err
+	}
+	width, height := eterm.Size()
+	if r.width > 0 && r.height > 0 {
+		width, height = r.width, r.height
+	}
+	out := bufio.NewWriter(f)
+	enc := json.NewEncoder(out)
+	err = enc.Encode(header{
+		Version:   castVersion,
+		Width:     width,
+		Height:    height,
+		Timestamp: time.Now().Unix(),
+		Env:       map[string]string{"TERM": os.Getenv("TERM")},
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	var stdPipes [2][2]*os.File
+	for i := range stdPipes {
+		pr, pw, err := os.Pipe()
+		if err != nil {
+			for _, p := range stdPipes[:i] {
+				p[0].Close()
+				p[1].Close()
+			}
+			f.Close()
+			return nil, err
+		}
+		stdPipes[i] = [2]*os.File{pr, pw}
+	}
+
+	var (
+		outMu    sync.Mutex
+		writeErr error
+		copiers  sync.WaitGroup
+	)
+	start := time.Now()
+	record := func(data string) {
+		outMu.Lock()
+		defer outMu.Unlock()
+		if writeErr == nil {
+			writeErr = writeFrame(out, frame{Time: time.Since(start), Data: data})
+		}
+	}
+
+	stdout, stderr := os.Stdout, os.Stderr
+	for i, dst := range []*os.File{stdout, stderr} {
+		copiers.Add(1)
+		go func() {
+			defer copiers.Done()
+			defer stdPipes[i][0].Close()
+			tee(stdPipes[i][0], dst, record)
+		}()
+	}
+	os.Stdout, os.Stderr = stdPipes[0][1], stdPipes[1][1]
+
+	restore := eterm.Save()
+	eterm.ForceTTY(true)
+	eterm.ForceWidth(width)
+	eterm.ForceHeight(height)
+	live.SetSize(width, height)
+	recording = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scripted := make(chan struct{})
+	go func() {
+		defer close(scripted)
+		r.script.run(ctx)
+	}()
+
+	var once sync.Once
+	return func() error {
+		once.Do(func() {
+			cancel()
+			<-scripted
+			os.Stdout, os.Stderr = stdout, stderr
+			for _, p := range stdPipes {
+				p[1].Close()
+			}
+			copiers.Wait()
+
+			live.SetSize(0, 0)
+			restore()
+			writeErr = errors.Join(writeErr, out.Flush(), f.Close())
+
+			recordingMu.Lock()
+			recording = false
+			recordingMu.Unlock()
+		})
+		return writeErr
+	}, nil
+}
+
+// Copy what is written to r to dst, passing it to record as well. The
+// characters split between two reads are recorded whole.
+func tee(r *os.File, dst *os.File, record func(data string)) {
+	buf := make([]byte, 32*1024)
+	var pending []byte
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			_, _ = dst.Write(buf[:n])
+			data := append(pending, buf[:n]...)
+			pending = nil
+			for i := len(data) - 1; i >= max(len(data)-utf8.UTFMax, 0); i-- {
+				if utf8.RuneStart(data[i]) {
+					if !utf8.FullRune(data[i:]) {
+						pending = append([]byte(nil), data[i:]...)
+						data = data[:i]
+					}
+					break
+				}
+			}
+			if len(data) > 0 {
+				record(string(data))
+			}
+		}
+		if err != nil {
+			if len(pending) > 0 {
+				record(string(pending))
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package ereplay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// ErrUnknownKey is returned when a Script presses a key which does not
+// exist.
+var ErrUnknownKey = errors.New("unknown key")
+
+// Delay between the characters typed by Script.Type.
+const typingDelay = 80 * time.Millisecond
+
+// Interval at which a key waits for a component to receive it.
+const waitInterval = 10 * time.Millisecond
+
+// Names of the keys, as reported by tea.KeyMsg.String, like "enter" or
+// "ctrl+c".
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{"space": tea.KeySpace}
+	for k := tea.KeyType(-128); k < 128; k++ {
+		if name := k.String(); name != "" && name != " " {
+			types[name] = k
+		}
+	}
+	return types
+}()
+
+// Script is a sequence of keys pressed in the interactive components while
+// recording, like the answers of prompts, see Recorder.WithScript.
+type Script struct {
+	steps []step
+}
+
+// A step of a Script: a pause or the keys pressed.
+type step struct {
+	delay time.Duration
+	keys  []string
+	// Pause before each key
+	interval time.Duration
+}
+
+// Create an empty Script.
+//
+//	script := ereplay.NewScript().
+//		Sleep(time.Second).
+//		Type("my-app").Key("enter").
+//		Key("down", "down", "enter")
+func NewScript() Script {
+	return Script{}
+}
+
+// Returns s followed by st.
+func (s Script) then(st step) Script {
+	s.steps = append(slices.Clip(s.steps), st)
+	return s
+}
+
+// Wait for d before the next step.
+//
+//	script := ereplay.NewScript().Sleep(500 * time.Millisecond)
+func (s Script) Sleep(d time.Duration) Script {
+	return s.then(step{delay: d})
+}
+
+// Type the characters of text, at the pace of a person.
+//
+//	script := ereplay.NewScript().Type("hello world")
+func (s Script) Type(text string) Script {
+	keys := make([]string, 0, len(text))
+	for _, r := range text {
+		keys = append(keys, string(r))
+	}
+	return s.then(step{keys: keys, interval: typingDelay})
+}
+
+// Press the keys, named like "enter", "esc", "up", "tab", "ctrl+a",
+// "alt+b" or "q".
+//
+//	script := ereplay.NewScript().Key("down", "down", "enter")
+func (s Script) Key(keys ...string) Script {
+	return s.then(step{keys: keys, interval: typingDelay})
+}
+
+// Returns the tea.KeyMsg of the key name.
+func parseKey(name string) (tea.KeyMsg, error) {
+	if k, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: k}, nil
+	}
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		msg, err := parseKey(rest)
+		msg.Alt = true
+		return msg, err
+	}
+	if r := []rune(name); len(r) == 1 {
+		if r[0] == ' ' {
+			return tea.KeyMsg{Type: tea.KeySpace, Runes: r}, nil
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: r}, nil
+	}
+	return tea.KeyMsg{}, fmt.Errorf("%w: %q", ErrUnknownKey, name)
+}
+
+// Check that the keys of the Script exist.
+func (s Script) validate() error {
+	for _, st := range s.steps {
+		for _, key := range st.keys {
+			if _, err := parseKey(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Run the steps of the Script until the end or until ctx is done. Each key
+// waits for an interactive component to be running to receive it.
+func (s Script) run(ctx context.Context) {
+	sleep := func(d time.Duration) bool {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return false
+		case <-t.C:
+			return true
+		}
+	}
+
+	for _, st := range s.steps {
+		if !sleep(st.delay) {
+			return
+		}
+		for _, key := range st.keys {
+			msg, _ := parseKey(key)
+			if !sleep(st.interval) {
+				return
+			}
+			for !live.Send(msg) {
+				if !sleep(waitInterval) {
+					return
+				}
+			}
+		}
+	}
+}
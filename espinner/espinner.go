@@ -1,13 +1,23 @@
 package espinner
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/enotify"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
 )
 
+// The bubbletea.Msg sent when the deadline of the spinner expires
+type spinnerMsgDeadline struct{}
+
 // The bubbletea.Msg sent when the spinner should stop
 type spinnerMsgStop struct {
 	err error
@@ -19,6 +29,9 @@ func (s spinnerMsgStop) Error() string {
 
 type SpinnerTask = func() error
 
+// A task receiving the Handle of the spinner running it.
+type SpinnerHandleTask = func(h *Handle) error
+
 type Spinner = spinner.Spinner
 
 // Spinner style definition
@@ -26,48 +39,100 @@ type SpinnerStyle struct {
 	ProgressStyle lipgloss.Style
 	SuccessStyle  lipgloss.Style
 	FailureStyle  lipgloss.Style
+	StepStyle     lipgloss.Style
+}
+
+// Default SpinnerStyle, built from the current etheme.Theme.
+var SpinnerStyleDefault = spinnerStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		SpinnerStyleDefault = spinnerStyle(t)
+	})
 }
 
-var SpinnerStyleDefault = SpinnerStyle{
-	ProgressStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true),
-	SuccessStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
-	FailureStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true),
+// Returns the SpinnerStyle of the theme t.
+func spinnerStyle(t etheme.Theme) SpinnerStyle {
+	return SpinnerStyle{
+		ProgressStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		SuccessStyle:  lipgloss.NewStyle().Foreground(t.Success),
+		FailureStyle:  lipgloss.NewStyle().Foreground(t.Error).Bold(true),
+		StepStyle:     lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+	}
 }
 
 // Bubbletea model of the spinner, wraps spinner.Model and contains the task
 // to execute
 type SpinnerModel struct {
-	title string
-	task  SpinnerTask
-	inner spinner.Model
-	style SpinnerStyle
-	err   error
-	done  bool
+	title     string
+	task      SpinnerHandleTask
+	handle    *Handle
+	inner     spinner.Model
+	style     SpinnerStyle
+	fps       time.Duration
+	timeout   time.Duration
+	deadline  time.Time
+	countdown bool
+	lite      bool
+	notify    bool
+	step      int
+	steps     int
+	err       error
+	status    TaskStatus
+	paused    bool
 }
 
 // Create a new SpinnerModel.
 func NewSpinner(title string, task SpinnerTask) SpinnerModel {
+	return NewSpinnerWithHandle(title, func(h *Handle) error {
+		return task()
+	})
+}
+
+// Create a new SpinnerModel whose task receives a Handle to interact with
+// the running spinner.
+//
+//	s := espinner.NewSpinnerWithHandle("Deploying", func(h *espinner.Handle) error {
+//		h.Pause()
+//		defer h.Resume()
+//		// ask for a password
+//	})
+func NewSpinnerWithHandle(title string, task SpinnerHandleTask) SpinnerModel {
 	s := spinner.New()
 	s.Spinner = spinner.Line
 	return SpinnerModel{
-		title: title,
-		task:  task,
-		style: SpinnerStyleDefault,
-		inner: s,
-		err:   nil,
-		done:  false,
+		title:  title,
+		task:   task,
+		handle: &Handle{},
+		style:  SpinnerStyleDefault,
+		inner:  s,
+		err:    nil,
+		status: TaskRunning,
 	}
 }
 
 // Initialize the SpinnerModel
 func (m SpinnerModel) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.inner.Tick,
 		func() tea.Msg {
-			err := m.task()
+			err := m.run()
 			return spinnerMsgStop{err: err}
 		},
-	)
+	}
+	if !m.deadline.IsZero() {
+		cmds = append(cmds, tea.Tick(time.Until(m.deadline), func(time.Time) tea.Msg {
+			return spinnerMsgDeadline{}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Execute the task of the SpinnerModel through the registered middlewares.
+func (m SpinnerModel) run() error {
+	return wrap(TaskInfo{Title: m.title}, func() error {
+		return m.task(m.handle)
+	})()
 }
 
 func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -75,11 +140,27 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyCtrlC:
-			return m, tea.Quit
+			return m.interrupt()
 		}
+	case spinnerMsgInterrupt:
+		return m.interrupt()
+	case spinnerMsgPause:
+		m.paused = bool(msg)
+		return m, nil
+	case spinnerMsgDeadline:
+		if m.status != TaskRunning {
+			return m, nil
+		}
+		m.status = TaskFailed
+		m.err = context.DeadlineExceeded
+		return m, tea.Quit
 	case spinnerMsgStop:
-		m.done = true
+		if m.status != TaskRunning {
+			return m, nil
+		}
+		m.status = TaskDone
 		if msg.err != nil {
+			m.status = TaskFailed
 			m.err = msg.err
 		}
 		return m, tea.Quit
@@ -91,18 +172,50 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m SpinnerModel) View() string {
-	s := ""
-	if !m.done {
-		s += m.style.ProgressStyle.Render(fmt.Sprintf("%s %s", m.inner.View(), m.title))
-	} else {
-		if m.err != nil {
-			s += m.style.FailureStyle.Render(fmt.Sprintf("* %s ... Failed: %v", m.title, m.err))
-		} else {
-			s += m.style.SuccessStyle.Render(fmt.Sprintf("* %s ... Done", m.title))
-		}
+	if m.paused {
+		return ""
+	}
+	return m.render(m.inner.View()) + "\n"
+}
+
+// Render the line of the SpinnerModel with the given spinner frame.
+func (m SpinnerModel) render(frame string) string {
+	title := m.title
+	if m.countdown && !m.deadline.IsZero() && m.status == TaskRunning {
+		left := max(time.Until(m.deadline), 0).Round(time.Second)
+		title = fmt.Sprintf("%s %s left", title, left)
 	}
-	s += "\n"
-	return s
+	return m.renderStep() + renderTask(m.style, frame, title, m.status, m.err)
+}
+
+// Stop the SpinnerModel marking its task as cancelled.
+func (m SpinnerModel) interrupt() (tea.Model, tea.Cmd) {
+	m.status = TaskCancelled
+	m.err = ErrInterrupted
+	return m, tea.Quit
+}
+
+// Render the step number prefix of the SpinnerModel, if it is part of a TaskList.
+func (m SpinnerModel) renderStep() string {
+	if m.steps == 0 {
+		return ""
+	}
+	return m.style.StepStyle.Render(fmt.Sprintf("[%d/%d]", m.step, m.steps)) + " "
+}
+
+// Render a single task line given its state.
+func renderTask(style SpinnerStyle, frame string, title string, status TaskStatus, err error) string {
+	switch status {
+	case TaskNotStarted:
+		return style.ProgressStyle.Render(fmt.Sprintf("* %s ... Not started", title))
+	case TaskDone:
+		return style.SuccessStyle.Render(fmt.Sprintf("* %s ... Done", title))
+	case TaskFailed:
+		return style.FailureStyle.Render(fmt.Sprintf("* %s ... Failed: %v", title, err))
+	case TaskCancelled:
+		return style.FailureStyle.Render(fmt.Sprintf("* %s ... Cancelled", title))
+	}
+	return style.ProgressStyle.Render(fmt.Sprintf("%s %s", frame, title))
 }
 
 func (m SpinnerModel) Err() error {
@@ -122,6 +235,75 @@ func (m SpinnerModel) WithStyle(s SpinnerStyle) SpinnerModel {
 //	s := espinner.NewSpinner(...).WithSpinner(spinner.Dot)
 func (m SpinnerModel) WithSpinner(s Spinner) SpinnerModel {
 	m.inner.Spinner = s
+	if m.fps > 0 {
+		m.inner.Spinner.FPS = m.fps
+	}
+	return m
+}
+
+// Specify the interval between two frames of the SpinnerModel. The same
+// interval is used to throttle the rendering of the terminal output, lower
+// rates reduce flickering and bandwidth over slow connections.
+//
+//	s := espinner.NewSpinner(...).WithFPS(time.Second / 4)
+func (m SpinnerModel) WithFPS(d time.Duration) SpinnerModel {
+	m.fps = d
+	if d > 0 {
+		m.inner.Spinner.FPS = d
+	}
+	return m
+}
+
+// Specify a maximum duration for the task of the SpinnerModel, counted from
+// when the spinner starts. When it expires the spinner stops and
+// context.DeadlineExceeded is returned, the task can observe it through
+// the context of its Handle.
+//
+//	s := espinner.NewSpinner(...).WithTimeout(5 * time.Minute)
+func (m SpinnerModel) WithTimeout(d time.Duration) SpinnerModel {
+	m.timeout = d
+	m.deadline = time.Time{}
+	return m
+}
+
+// Specify the instant after which the task of the SpinnerModel is
+// considered failed, see WithTimeout.
+//
+//	s := espinner.NewSpinner(...).WithDeadline(time.Now().Add(time.Hour))
+func (m SpinnerModel) WithDeadline(t time.Time) SpinnerModel {
+	m.deadline = t
+	m.timeout = 0
+	return m
+}
+
+// Render the time left before the deadline of the SpinnerModel next to
+// its title.
+//
+//	s := espinner.NewSpinner(...).WithTimeout(5 * time.Minute).WithCountdown(true)
+func (m SpinnerModel) WithCountdown(c bool) SpinnerModel {
+	m.countdown = c
+	return m
+}
+
+// Run the SpinnerModel redrawing a single line from a goroutine instead of
+// starting a bubbletea program. Useful when the caller already owns a
+// bubbletea program or a full program per task is overkill. In lite mode
+// Handle.Pause only stops the redraw, the terminal is never taken.
+//
+//	s := espinner.NewSpinner(...).WithLite(true)
+func (m SpinnerModel) WithLite(l bool) SpinnerModel {
+	m.lite = l
+	return m
+}
+
+// Notify the user when the task of the SpinnerModel finishes: the bell
+// rings and a desktop notification reports the outcome, while the task runs
+// the progress indicator of the terminal is busy, see enotify. Nothing is
+// notified when the task is cancelled.
+//
+//	s := espinner.NewSpinner(...).WithNotify(true)
+func (m SpinnerModel) WithNotify(n bool) SpinnerModel {
+	m.notify = n
 	return m
 }
 
@@ -134,10 +316,96 @@ func (m SpinnerModel) WithSpinnerStyle(s lipgloss.Style) SpinnerModel {
 }
 
 // Run the SpinnerModel.
+// When the output is not a terminal the task is executed without animation
+// and its progress is reported with plain lines.
+// On SIGINT, SIGTERM or Ctrl+C the task is marked as cancelled and
+// ErrInterrupted is returned.
 func (s *SpinnerModel) Spin() error {
-	tp := tea.NewProgram(s)
-	if _, err := tp.Run(); err != nil {
+	sig, stop := catchInterrupts()
+	defer stop()
+	if !s.notify {
+		return s.spin(sig)
+	}
+
+	enotify.SetProgress(enotify.ProgressIndeterminate, 0)
+	err := s.spin(sig)
+	enotify.ClearProgress()
+	if s.status != TaskCancelled {
+		enotify.Finished(s.title, err)
+	}
+	return err
+}
+
+func (s *SpinnerModel) spin(sig <-chan os.Signal) error {
+	if s.timeout > 0 {
+		s.deadline = time.Now().Add(s.timeout)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if !s.deadline.IsZero() {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithDeadline(ctx, s.deadline)
+		defer cancelDeadline()
+	}
+	s.handle.setContext(ctx)
+
+	if !eterm.IsTerminal(os.Stdout) {
+		return s.spinPlain(ctx, sig)
+	}
+	if s.lite {
+		return s.spinLite(ctx, sig)
+	}
+
+	opts := []tea.ProgramOption{tea.WithoutSignalHandler()}
+	if s.fps > 0 {
+		opts = append(opts, tea.WithFPS(max(1, int(time.Second/s.fps))))
+	}
+
+	tp := tea.NewProgram(*s, opts...)
+	s.handle.attach(tp)
+	defer s.handle.attach(nil)
+
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-sig:
+			tp.Send(spinnerMsgInterrupt{})
+		case <-finished:
+		}
+	}()
+
+	final, err := live.Run(tp)
+	if err != nil {
 		return err
 	}
+	*s = final.(SpinnerModel)
+	return s.err
+}
+
+// Run the SpinnerModel without animation, for outputs that are not a terminal.
+func (s *SpinnerModel) spinPlain(ctx context.Context, sig <-chan os.Signal) error {
+	fmt.Println(s.renderStep() + s.style.ProgressStyle.Render(fmt.Sprintf("%s ...", s.title)))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.run()
+	}()
+
+	select {
+	case err := <-done:
+		s.status = TaskDone
+		if err != nil {
+			s.status = TaskFailed
+			s.err = err
+		}
+	case <-ctx.Done():
+		s.status = TaskFailed
+		s.err = ctx.Err()
+	case <-sig:
+		s.status = TaskCancelled
+		s.err = ErrInterrupted
+	}
+	fmt.Print(s.View())
 	return s.err
 }
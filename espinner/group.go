@@ -0,0 +1,353 @@
+package espinner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// Result of a task executed by a GroupWith.
+type Result[T any] struct {
+	Value  T
+	Err    error
+	Status TaskStatus
+}
+
+// The bubbletea.Msg sent when a task of a group completes
+type groupMsgDone[T any] struct {
+	index int
+	value T
+	err   error
+}
+
+type groupTask[T any] struct {
+	key   string
+	title string
+	task  func() (T, error)
+}
+
+// GroupWith runs multiple tasks in parallel, rendering a spinner for each
+// of them, and collects the values they produce.
+type GroupWith[T any] struct {
+	tasks   []groupTask[T]
+	style   SpinnerStyle
+	spinner Spinner
+}
+
+// Create a new GroupWith producing values of type T.
+//
+//	g := espinner.NewGroupWith[[]byte]()
+func NewGroupWith[T any]() GroupWith[T] {
+	return GroupWith[T]{
+		tasks:   []groupTask[T]{},
+		style:   SpinnerStyleDefault,
+		spinner: spinner.Line,
+	}
+}
+
+// Add a task to the GroupWith. The key identifies the result of the task,
+// it panics if another task of the GroupWith has the same key.
+//
+//	g := espinner.NewGroupWith[[]byte]().
+//		WithTask("users", "Fetching users", fetchUsers).
+//		WithTask("orders", "Fetching orders", fetchOrders)
+func (g GroupWith[T]) WithTask(key string, title string, task func() (T, error)) GroupWith[T] {
+	for _, t := range g.tasks {
+		if t.key == key {
+			panic(fmt.Sprintf("espinner: duplicate task key %q", key))
+		}
+	}
+	tasks := make([]groupTask[T], len(g.tasks), len(g.tasks)+1)
+	copy(tasks, g.tasks)
+	g.tasks = append(tasks, groupTask[T]{key: key, title: title, task: task})
+	return g
+}
+
+// Specify the style of the GroupWith.
+//
+//	g := espinner.NewGroupWith[int]().WithStyle(espinner.SpinnerStyleDefault)
+func (g GroupWith[T]) WithStyle(s SpinnerStyle) GroupWith[T] {
+	g.style = s
+	return g
+}
+
+// Specify the spinner of the GroupWith.
+//
+//	g := espinner.NewGroupWith[int]().WithSpinner(spinner.Dot)
+func (g GroupWith[T]) WithSpinner(s Spinner) GroupWith[T] {
+	g.spinner = s
+	return g
+}
+
+// Run all the tasks of the GroupWith in parallel and wait for them to complete.
+// The returned map contains the result of every task by key, the returned
+// error joins the errors of the failed tasks.
+// On SIGINT, SIGTERM or Ctrl+C the final state of every task is rendered,
+// the tasks still running are marked as cancelled and ErrInterrupted is
+// returned. When stdout is not a terminal a line is printed when each task
+// starts and when it completes.
+//
+//	results, err := g.Run()
+//	users := results["users"].Value
+func (g GroupWith[T]) Run() (map[string]Result[T], error) {
+	sig, stop := catchInterrupts()
+	defer stop()
+
+	m, err := g.run(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]Result[T], len(m.tasks))
+	errs := make([]error, 0)
+	for i, t := range m.tasks {
+		results[t.key] = m.results[i]
+		if m.results[i].Status == TaskFailed {
+			errs = append(errs, fmt.Errorf("%s: %w", t.title, m.results[i].Err))
+		}
+	}
+	if m.interrupted {
+		return results, ErrInterrupted
+	}
+	return results, errors.Join(errs...)
+}
+
+// Print the tasks of the GroupWith without executing them. All the tasks
+// are run in parallel by Run, so they are listed in the order they were added.
+func (g GroupWith[T]) RunDryRun() {
+	for _, t := range g.tasks {
+		fmt.Println(g.style.ProgressStyle.Render(fmt.Sprintf("* %s (parallel)", t.title)))
+	}
+}
+
+func (g GroupWith[T]) run(sig <-chan os.Signal) (groupModel[T], error) {
+	s := spinner.New()
+	s.Spinner = g.spinner
+	m := groupModel[T]{
+		tasks:   g.tasks,
+		style:   g.style,
+		inner:   s,
+		results: make([]Result[T], len(g.tasks)),
+	}
+	for i := range m.results {
+		m.results[i].Status = TaskRunning
+	}
+	if len(m.tasks) == 0 {
+		return m, nil
+	}
+	if !eterm.IsTerminal(os.Stdout) {
+		return m.runPlain(sig), nil
+	}
+
+	tp := tea.NewProgram(m, tea.WithoutSignalHandler())
+
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-sig:
+			tp.Send(spinnerMsgInterrupt{})
+		case <-finished:
+		}
+	}()
+
+	final, err := live.Run(tp)
+	if err != nil {
+		return m, err
+	}
+	return final.(groupModel[T]), nil
+}
+
+// Run the tasks without animation, for outputs that are not a terminal,
+// printing a line when each task starts and when it completes.
+func (m groupModel[T]) runPlain(sig <-chan os.Signal) groupModel[T] {
+	done := make(chan groupMsgDone[T], len(m.tasks))
+	for i, t := range m.tasks {
+		fmt.Println(m.style.ProgressStyle.Render(fmt.Sprintf("%s ...", t.title)))
+		go func() {
+			var value T
+			err := wrap(TaskInfo{Title: t.title, Key: t.key}, func() error {
+				var err error
+				value, err = t.task()
+				return err
+			})()
+			done <- groupMsgDone[T]{index: i, value: value, err: err}
+		}()
+	}
+
+	for range m.tasks {
+		select {
+		case msg := <-done:
+			status := TaskDone
+			if msg.err != nil {
+				status = TaskFailed
+			}
+			m.results[msg.index] = Result[T]{Value: msg.value, Err: msg.err, Status: status}
+			fmt.Println(renderTask(m.style, "", m.tasks[msg.index].title, status, msg.err))
+		case <-sig:
+			final, _ := m.interrupt()
+			m = final.(groupModel[T])
+			for i, t := range m.tasks {
+				if m.results[i].Status == TaskCancelled {
+					fmt.Println(renderTask(m.style, "", t.title, TaskCancelled, nil))
+				}
+			}
+			return m
+		}
+	}
+	return m
+}
+
+// Bubbletea model of a running GroupWith.
+type groupModel[T any] struct {
+	tasks       []groupTask[T]
+	style       SpinnerStyle
+	inner       spinner.Model
+	results     []Result[T]
+	interrupted bool
+}
+
+func (m groupModel[T]) Init() tea.Cmd {
+	cmds := []tea.Cmd{m.inner.Tick}
+	for i, t := range m.tasks {
+		cmds = append(cmds, func() tea.Msg {
+			var value T
+			err := wrap(TaskInfo{Title: t.title, Key: t.key}, func() error {
+				var err error
+				value, err = t.task()
+				return err
+			})()
+			return groupMsgDone[T]{index: i, value: value, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m groupModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m.interrupt()
+		}
+	case spinnerMsgInterrupt:
+		return m.interrupt()
+	case groupMsgDone[T]:
+		if m.interrupted {
+			return m, nil
+		}
+		status := TaskDone
+		if msg.err != nil {
+			status = TaskFailed
+		}
+		m.results[msg.index] = Result[T]{Value: msg.value, Err: msg.err, Status: status}
+		for _, r := range m.results {
+			if r.Status == TaskRunning {
+				return m, nil
+			}
+		}
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.inner, cmd = m.inner.Update(msg)
+	return m, cmd
+}
+
+func (m groupModel[T]) View() string {
+	var b strings.Builder
+	frame := m.inner.View()
+	for i, t := range m.tasks {
+		b.WriteString(renderTask(m.style, frame, t.title, m.results[i].Status, m.results[i].Err))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Stop the group marking the running tasks as cancelled.
+func (m groupModel[T]) interrupt() (tea.Model, tea.Cmd) {
+	m.interrupted = true
+	results := make([]Result[T], len(m.results))
+	copy(results, m.results)
+	for i := range results {
+		if results[i].Status == TaskRunning {
+			results[i] = Result[T]{Err: ErrInterrupted, Status: TaskCancelled}
+		}
+	}
+	m.results = results
+	return m, tea.Quit
+}
+
+// Group runs multiple tasks in parallel, rendering a spinner for each of them.
+// Use GroupWith to collect the values produced by the tasks.
+type Group struct {
+	inner GroupWith[struct{}]
+}
+
+// Create a new Group.
+//
+//	g := espinner.NewGroup()
+func NewGroup() Group {
+	return Group{inner: NewGroupWith[struct{}]()}
+}
+
+// Add a task to the Group, it panics if another task of the Group has the
+// same key.
+//
+//	g := espinner.NewGroup().WithTask("db", "Migrating database", migrate)
+func (g Group) WithTask(key string, title string, task SpinnerTask) Group {
+	g.inner = g.inner.WithTask(key, title, func() (struct{}, error) {
+		return struct{}{}, task()
+	})
+	return g
+}
+
+// Specify the style of the Group.
+//
+//	g := espinner.NewGroup().WithStyle(espinner.SpinnerStyleDefault)
+func (g Group) WithStyle(s SpinnerStyle) Group {
+	g.inner = g.inner.WithStyle(s)
+	return g
+}
+
+// Specify the spinner of the Group.
+//
+//	g := espinner.NewGroup().WithSpinner(spinner.Dot)
+func (g Group) WithSpinner(s Spinner) Group {
+	g.inner = g.inner.WithSpinner(s)
+	return g
+}
+
+// Run all the tasks of the Group in parallel and wait for them to complete.
+// The returned Summary reports the final state of every task, the returned
+// error joins the errors of the failed tasks or is ErrInterrupted if the run
+// was interrupted.
+//
+//	summary, err := g.Run()
+//	if errors.Is(err, espinner.ErrInterrupted) {
+//		log.Printf("interrupted: %s", summary)
+//	}
+func (g Group) Run() (Summary, error) {
+	results, err := g.inner.Run()
+	if results == nil {
+		return nil, err
+	}
+
+	summary := make(Summary, 0, len(g.inner.tasks))
+	for _, t := range g.inner.tasks {
+		r := results[t.key]
+		summary = append(summary, TaskReport{Title: t.title, Status: r.Status, Err: r.Err})
+	}
+	return summary, err
+}
+
+// Print the tasks of the Group without executing them, see GroupWith.RunDryRun.
+func (g Group) RunDryRun() {
+	g.inner.RunDryRun()
+}
@@ -0,0 +1,125 @@
+package espinner
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// The bubbletea.Msg sent when the spinner is paused or resumed
+type spinnerMsgPause bool
+
+// Handle allows a running task to interact with the spinner displaying it.
+type Handle struct {
+	mu      sync.Mutex
+	program *tea.Program
+	lite    *liteRenderer
+	ctx     context.Context
+	paused  bool
+}
+
+func (h *Handle) attachLite(r *liteRenderer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lite = r
+}
+
+func (h *Handle) setContext(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ctx = ctx
+}
+
+// Returns a context that is done when the deadline of the spinner expires
+// or the spinner stops.
+//
+//	req, _ := http.NewRequestWithContext(h.Context(), "GET", url, nil)
+func (h *Handle) Context() context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.ctx == nil {
+		return context.Background()
+	}
+	return h.ctx
+}
+
+func (h *Handle) attach(p *tea.Program) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.program = p
+	h.paused = false
+}
+
+// Pause the spinner and release the terminal, restoring the cursor and
+// stopping any redraw until Resume is called. Use it to let the task
+// interact with the user, for example reading a password.
+//
+//	h.Pause()
+//	defer h.Resume()
+func (h *Handle) Pause() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.lite != nil {
+		h.lite.pause()
+		return nil
+	}
+	if h.program == nil || h.paused {
+		return nil
+	}
+
+	h.program.Send(spinnerMsgPause(true))
+	// Messages are processed one at a time, once the next one is accepted
+	// the paused view has been handed to the renderer.
+	h.program.Send(nil)
+	if err := h.program.ReleaseTerminal(); err != nil {
+		return err
+	}
+	h.paused = true
+	return nil
+}
+
+// Resume a spinner paused with Pause, taking back the terminal and restarting
+// the animation.
+func (h *Handle) Resume() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.lite != nil {
+		h.lite.resume()
+		return nil
+	}
+	if h.program == nil || !h.paused {
+		return nil
+	}
+
+	if err := h.program.RestoreTerminal(); err != nil {
+		return err
+	}
+	h.program.Send(spinnerMsgPause(false))
+	h.paused = false
+	return nil
+}
+
+// Suspend the spinner and give the terminal to the provided bubbletea model
+// until it quits, then resume the spinner. The final state of the model is
+// returned so that the task can read the answer of the user.
+//
+//	final, err := h.Prompt(confirmModel)
+//	if err != nil {
+//		return err
+//	}
+//	if !final.(ConfirmModel).Accepted() {
+//		return nil
+//	}
+func (h *Handle) Prompt(m tea.Model) (tea.Model, error) {
+	if err := h.Pause(); err != nil {
+		return m, err
+	}
+
+	final, err := live.Run(tea.NewProgram(m))
+	if rerr := h.Resume(); err == nil {
+		err = rerr
+	}
+	return final, err
+}
@@ -0,0 +1,108 @@
+package espinner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// Renderer of a SpinnerModel in lite mode, redraws a single line in place.
+type liteRenderer struct {
+	mu     sync.Mutex
+	out    io.Writer
+	paused bool
+	last   string
+}
+
+func (r *liteRenderer) draw(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = line
+	if r.paused {
+		return
+	}
+	fmt.Fprint(r.out, "\r"+ansi.EraseEntireLine+line)
+}
+
+// Print line above the spinner, then redraw it.
+func (r *liteRenderer) println(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.paused {
+		fmt.Fprintln(r.out, line)
+		return
+	}
+	fmt.Fprint(r.out, "\r"+ansi.EraseEntireLine+line+"\n"+r.last)
+}
+
+func (r *liteRenderer) pause() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.paused {
+		fmt.Fprint(r.out, "\r"+ansi.EraseEntireLine+ansi.ShowCursor)
+	}
+	r.paused = true
+}
+
+func (r *liteRenderer) resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.paused {
+		fmt.Fprint(r.out, ansi.HideCursor)
+	}
+	r.paused = false
+}
+
+// Run the SpinnerModel in lite mode.
+func (s *SpinnerModel) spinLite(ctx context.Context, sig <-chan os.Signal) error {
+	r := &liteRenderer{out: os.Stdout}
+	s.handle.attachLite(r)
+	defer s.handle.attachLite(nil)
+	defer live.Push(r.println)()
+
+	fmt.Fprint(r.out, ansi.HideCursor)
+	defer fmt.Fprint(r.out, ansi.ShowCursor)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.run()
+	}()
+
+	frames := s.inner.Spinner.Frames
+	fps := s.inner.Spinner.FPS
+	if fps <= 0 {
+		fps = time.Second / 10
+	}
+	ticker := time.NewTicker(fps)
+	defer ticker.Stop()
+
+	for frame := 0; s.status == TaskRunning; frame++ {
+		r.draw(s.render(s.inner.Style.Render(frames[frame%len(frames)])))
+
+		select {
+		case err := <-done:
+			s.status = TaskDone
+			if err != nil {
+				s.status = TaskFailed
+				s.err = err
+			}
+		case <-ctx.Done():
+			s.status = TaskFailed
+			s.err = ctx.Err()
+		case <-sig:
+			s.status = TaskCancelled
+			s.err = ErrInterrupted
+		case <-ticker.C:
+		}
+	}
+
+	r.resume()
+	r.draw(s.View())
+	return s.err
+}
@@ -0,0 +1,47 @@
+package espinner
+
+import "sync"
+
+// TaskInfo identifies the task wrapped by a Middleware.
+type TaskInfo struct {
+	// Title of the task
+	Title string
+	// Key of the task in its Group, empty for the other tasks
+	Key string
+}
+
+// Middleware wraps the execution of a task, see Use.
+type Middleware = func(info TaskInfo, next SpinnerTask) SpinnerTask
+
+var (
+	middlewaresMu sync.RWMutex
+	middlewares   []Middleware
+)
+
+// Register middlewares applied to every task run by a SpinnerModel, a Group
+// or a TaskList. Middlewares are applied in registration order, the first one
+// being the outermost. Each middleware receives the TaskInfo of the task it
+// wraps.
+//
+//	espinner.Use(func(info espinner.TaskInfo, next espinner.SpinnerTask) espinner.SpinnerTask {
+//		return func() error {
+//			start := time.Now()
+//			defer func() { log.Printf("%s took %s", info.Title, time.Since(start)) }()
+//			return next()
+//		}
+//	})
+func Use(mw ...Middleware) {
+	middlewaresMu.Lock()
+	defer middlewaresMu.Unlock()
+	middlewares = append(middlewares, mw...)
+}
+
+// Wrap the task with the registered middlewares.
+func wrap(info TaskInfo, task SpinnerTask) SpinnerTask {
+	middlewaresMu.RLock()
+	defer middlewaresMu.RUnlock()
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		task = middlewares[i](info, task)
+	}
+	return task
+}
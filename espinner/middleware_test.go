@@ -0,0 +1,78 @@
+package espinner
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Register the middlewares for the duration of the test.
+func useMiddlewares(t *testing.T, mw ...Middleware) {
+	t.Helper()
+	middlewaresMu.Lock()
+	saved := middlewares
+	middlewares = nil
+	middlewaresMu.Unlock()
+	t.Cleanup(func() {
+		middlewaresMu.Lock()
+		middlewares = saved
+		middlewaresMu.Unlock()
+	})
+	Use(mw...)
+}
+
+func TestWrapOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(info TaskInfo, next SpinnerTask) SpinnerTask {
+			return func() error {
+				calls = append(calls, name+" "+info.Title)
+				return next()
+			}
+		}
+	}
+	useMiddlewares(t, record("outer"), record("inner"))
+
+	err := wrap(TaskInfo{Title: "task"}, func() error {
+		calls = append(calls, "task")
+		return nil
+	})()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"outer task", "inner task", "task"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestMiddlewareInfo(t *testing.T) {
+	var mu sync.Mutex
+	var infos []TaskInfo
+	useMiddlewares(t, func(info TaskInfo, next SpinnerTask) SpinnerTask {
+		mu.Lock()
+		infos = append(infos, info)
+		mu.Unlock()
+		return next
+	})
+
+	s := NewSpinner("Single", func() error { return nil })
+	if err := s.Spin(); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewGroup().
+		WithTask("a", "First", func() error { return nil }).
+		WithTask("b", "Second", func() error { return nil }).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slices.SortFunc(infos, func(a, b TaskInfo) int { return strings.Compare(a.Title, b.Title) })
+	want := []TaskInfo{{Title: "First", Key: "a"}, {Title: "Second", Key: "b"}, {Title: "Single"}}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("infos = %v, want %v", infos, want)
+	}
+}
@@ -0,0 +1,99 @@
+package espinner
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+// ErrInterrupted is returned when a run is stopped by SIGINT, SIGTERM or Ctrl+C.
+var ErrInterrupted = eterm.ErrInterrupted
+
+// The bubbletea.Msg sent when the run is interrupted
+type spinnerMsgInterrupt struct{}
+
+// Status of a task run by a spinner.
+type TaskStatus int
+
+const (
+	TaskNotStarted TaskStatus = iota
+	TaskRunning
+	TaskDone
+	TaskFailed
+	TaskCancelled
+)
+
+func (s TaskStatus) String() string {
+	switch s {
+	case TaskNotStarted:
+		return "not started"
+	case TaskRunning:
+		return "running"
+	case TaskDone:
+		return "done"
+	case TaskFailed:
+		return "failed"
+	case TaskCancelled:
+		return "cancelled"
+	}
+	return fmt.Sprintf("TaskStatus(%d)", int(s))
+}
+
+// Final state of a task run by a TaskList or a Group.
+type TaskReport struct {
+	Title  string
+	Status TaskStatus
+	Err    error
+}
+
+// Summary of the tasks run by a TaskList or a Group, in the order they were
+// added.
+type Summary []TaskReport
+
+// Count the tasks of the Summary with the given status.
+func (s Summary) Count(status TaskStatus) int {
+	n := 0
+	for _, r := range s {
+		if r.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Returns a single line description of the Summary.
+//
+//	3 done, 1 failed, 1 cancelled, 2 not started
+func (s Summary) String() string {
+	parts := make([]string, 0)
+	for _, status := range []TaskStatus{TaskDone, TaskFailed, TaskCancelled, TaskNotStarted} {
+		if n := s.Count(status); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Catch SIGINT and SIGTERM until the returned function is called, so that
+// runs can be stopped gracefully.
+func catchInterrupts() (<-chan os.Signal, func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	return sig, func() {
+		signal.Stop(sig)
+	}
+}
+
+// Report whether an interrupt has already been received, without blocking.
+func interrupted(sig <-chan os.Signal) bool {
+	select {
+	case <-sig:
+		return true
+	default:
+		return false
+	}
+}
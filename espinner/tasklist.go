@@ -0,0 +1,143 @@
+package espinner
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+)
+
+type listTask struct {
+	title string
+	task  SpinnerHandleTask
+}
+
+// TaskList runs multiple tasks sequentially, rendering a spinner for each of
+// them prefixed by its step number.
+//
+//	[2/5] * Building image ... Done
+type TaskList struct {
+	tasks   []listTask
+	style   SpinnerStyle
+	spinner Spinner
+}
+
+// Create a new empty TaskList.
+//
+//	l := espinner.NewTaskList()
+func NewTaskList() TaskList {
+	return TaskList{
+		tasks:   []listTask{},
+		style:   SpinnerStyleDefault,
+		spinner: spinner.Line,
+	}
+}
+
+// Add a task at the end of the TaskList.
+//
+//	l := espinner.NewTaskList().
+//		WithTask("Building image", build).
+//		WithTask("Pushing image", push)
+func (l TaskList) WithTask(title string, task SpinnerTask) TaskList {
+	return l.WithHandleTask(title, func(h *Handle) error {
+		return task()
+	})
+}
+
+// Add a task receiving the Handle of its spinner at the end of the TaskList.
+//
+//	l := espinner.NewTaskList().WithHandleTask("Logging in", login)
+func (l TaskList) WithHandleTask(title string, task SpinnerHandleTask) TaskList {
+	tasks := make([]listTask, len(l.tasks), len(l.tasks)+1)
+	copy(tasks, l.tasks)
+	l.tasks = append(tasks, listTask{title: title, task: task})
+	return l
+}
+
+// Specify the style of the TaskList. The step number is rendered with
+// the StepStyle of the SpinnerStyle.
+//
+//	l := espinner.NewTaskList().WithStyle(espinner.SpinnerStyleDefault)
+func (l TaskList) WithStyle(s SpinnerStyle) TaskList {
+	l.style = s
+	return l
+}
+
+// Specify the spinner of the TaskList.
+//
+//	l := espinner.NewTaskList().WithSpinner(spinner.Dot)
+func (l TaskList) WithSpinner(s Spinner) TaskList {
+	l.spinner = s
+	return l
+}
+
+// Run the tasks of the TaskList one after the other, stopping at the first
+// failure. The returned Summary reports the final state of every task.
+// On SIGINT, SIGTERM or Ctrl+C the running task is marked as cancelled, the
+// remaining ones are rendered as not started and ErrInterrupted is returned.
+//
+//	summary, err := l.Run()
+//	if err != nil {
+//		log.Printf("deploy stopped: %s", summary)
+//	}
+func (l TaskList) Run() (Summary, error) {
+	sig, stop := catchInterrupts()
+	defer stop()
+
+	summary := make(Summary, 0, len(l.tasks))
+	for _, t := range l.tasks {
+		summary = append(summary, TaskReport{Title: t.title, Status: TaskNotStarted})
+	}
+
+	for i := range l.tasks {
+		s := l.step(i)
+		if interrupted(sig) {
+			l.flush(i)
+			return summary, ErrInterrupted
+		}
+
+		err := s.spin(sig)
+		summary[i].Status = s.status
+		summary[i].Err = s.err
+		if err != nil {
+			if errors.Is(err, ErrInterrupted) {
+				l.flush(i + 1)
+			}
+			return summary, err
+		}
+	}
+	return summary, nil
+}
+
+// Print the tasks of the TaskList with their step number, in the order they
+// would be executed by Run, without executing them.
+//
+//	if dryRun {
+//		l.RunDryRun()
+//		return nil
+//	}
+func (l TaskList) RunDryRun() {
+	for i, t := range l.tasks {
+		s := l.step(i)
+		fmt.Println(s.renderStep() + l.style.ProgressStyle.Render(t.title))
+	}
+}
+
+// Create the SpinnerModel of the i-th task of the TaskList.
+func (l TaskList) step(i int) SpinnerModel {
+	s := NewSpinnerWithHandle(l.tasks[i].title, l.tasks[i].task).
+		WithStyle(l.style).
+		WithSpinner(l.spinner)
+	s.step = i + 1
+	s.steps = len(l.tasks)
+	return s
+}
+
+// Render the tasks of the TaskList starting from the i-th as not started.
+func (l TaskList) flush(from int) {
+	for i := from; i < len(l.tasks); i++ {
+		s := l.step(i)
+		s.status = TaskNotStarted
+		fmt.Print(s.View())
+	}
+}
@@ -0,0 +1,388 @@
+package etable
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eclip"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etext"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+	"github.com/ravvio/easycli-ui/internal/search"
+)
+
+// Style of the interactive parts of a browsed Table.
+type BrowseStyle struct {
+	SelectedStyle   lipgloss.Style
+	MatchStyle      lipgloss.Style
+	StatusStyle     lipgloss.Style
+	AscendingGlyph  string
+	DescendingGlyph string
+}
+
+// Default BrowseStyle used by Table.Browse, built from the current
+// etheme.Theme.
+var BrowseStyleDefault = browseStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		BrowseStyleDefault = browseStyle(t)
+	})
+}
+
+// Returns the BrowseStyle of the theme t.
+func browseStyle(t etheme.Theme) BrowseStyle {
+	return BrowseStyle{
+		SelectedStyle:   lipgloss.NewStyle().Underline(true),
+		MatchStyle:      lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Warning),
+		StatusStyle:     lipgloss.NewStyle().Reverse(true),
+		AscendingGlyph:  "▲",
+		DescendingGlyph: "▼",
+	}
+}
+
+// Browse the Table interactively until the user quits with q or Ctrl+C: the
+// rows scroll under the header, Left and Right select a column, s sorts the
+// rows by it and reverses the order when pressed again, / filters the rows
+// containing the query and Esc clears the filter, y copies the rows shown
+// to the clipboard as tab separated values. The title is rendered in the
+// status line.
+// When stdout is not a terminal the Table is printed as by Render.
+//
+//	err := t.Browse("users")
+func (t *Table) Browse(title string) error {
+	if !eterm.IsTerminal(os.Stdout) {
+		_, err := fmt.Println(ansi.Strip(t.Render()))
+		return err
+	}
+
+	m := browseModel{
+		table: t,
+		title: title,
+		style: t.browseStyle,
+		sort:  -1,
+		cells: t.getRowMatrix(),
+	}
+	for _, col := range t.columns {
+		if col.active {
+			m.columns = append(m.columns, col)
+		}
+	}
+	m.widths = m.columnWidths()
+	m.refresh()
+	_, err := live.Run(tea.NewProgram(m, tea.WithAltScreen()))
+	return err
+}
+
+// Open the CSV, TSV or JSON file at path, see ImportFile, and browse it, see
+// Table.Browse.
+//
+//	if err := etable.BrowseFile(args[0]); err != nil {
+//		return err
+//	}
+func BrowseFile(path string) error {
+	t, err := ImportFile(path)
+	if err != nil {
+		return err
+	}
+	return t.Browse(filepath.Base(path))
+}
+
+// Bubbletea model of a browsed Table.
+type browseModel struct {
+	table   *Table
+	title   string
+	style   BrowseStyle
+	columns []TableColumn
+	widths  []int
+	// Values of the rows, a slice per row with a value per active column
+	cells [][]string
+	// Indices of the rows shown, filtered and sorted
+	rows []int
+	// Column the rows are sorted by, -1 if none
+	sort       int
+	descending bool
+	selected   int
+	offset     int
+	// Number of cells scrolled horizontally
+	scroll int
+	width  int
+	height int
+	search search.Search
+	// Feedback of the last action, shown in the status line until the next
+	// key
+	message string
+	// Sequence copying to the clipboard, rendered with the status line
+	// rather than written while the program renders
+	clipboard string
+}
+
+// Filter and sort the rows shown.
+func (m *browseModel) refresh() {
+	m.rows = m.rows[:0]
+	for i, row := range m.cells {
+		if m.matches(row) {
+			m.rows = append(m.rows, i)
+		}
+	}
+	if m.sort >= 0 {
+		col := m.sort
+		sort.SliceStable(m.rows, func(i, j int) bool {
+			a, b := m.cells[m.rows[i]][col], m.cells[m.rows[j]][col]
+			if m.descending {
+				return less(b, a)
+			}
+			return less(a, b)
+		})
+	}
+	m.scrollTo(m.offset)
+}
+
+// Reports whether a row matches the filter.
+func (m browseModel) matches(row []string) bool {
+	if !m.search.Active() {
+		return true
+	}
+	for _, value := range row {
+		if m.search.Match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare two values, as numbers when both are.
+func less(a, b string) bool {
+	x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// Number of rows shown at once, below the header and above the status
+// line.
+func (m browseModel) page() int {
+	return max(m.height-2, 1)
+}
+
+// Scroll to the row at offset, keeping the last page full.
+func (m *browseModel) scrollTo(offset int) {
+	m.offset = max(min(offset, len(m.rows)-m.page()), 0)
+}
+
+// Returns the width of each column, fitting its title with the sort glyph
+// and its values.
+func (m browseModel) columnWidths() []int {
+	widths := make([]int, len(m.columns))
+	for i, col := range m.columns {
+		widths[i] = ansi.StringWidth(col.title) + 2
+		for _, row := range m.cells {
+			widths[i] = max(widths[i], ansi.StringWidth(row[i]))
+		}
+	}
+	return widths
+}
+
+func (m browseModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollTo(m.offset)
+	case tea.KeyMsg:
+		m.message, m.clipboard = "", ""
+		if m.search.Typing() {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			if m.search.Update(msg) {
+				m.offset = 0
+				m.refresh()
+			}
+			return m, nil
+		}
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			if !m.search.Active() {
+				return m, tea.Quit
+			}
+			m.search.Clear()
+			m.refresh()
+		case "/":
+			m.search.Start()
+		case "left", "h":
+			m.selected = max(m.selected-1, 0)
+			m.reveal()
+		case "right", "l":
+			m.selected = min(m.selected+1, max(len(m.columns)-1, 0))
+			m.reveal()
+		case "s":
+			if m.sort == m.selected {
+				m.descending = !m.descending
+			} else {
+				m.sort, m.descending = m.selected, false
+			}
+			m.refresh()
+		case "y":
+			m.copyRows()
+		case "up", "k":
+			m.scrollTo(m.offset - 1)
+		case "down", "j":
+			m.scrollTo(m.offset + 1)
+		case "pgup", "b", "ctrl+b":
+			m.scrollTo(m.offset - m.page())
+		case "pgdown", " ", "f", "ctrl+f":
+			m.scrollTo(m.offset + m.page())
+		case "home", "g":
+			m.scrollTo(0)
+		case "end", "G":
+			m.scrollTo(len(m.rows))
+		}
+	}
+	return m, nil
+}
+
+// Copy the rows shown to the clipboard, in their order and with the header,
+// as tab separated values, and report the result in the status line.
+func (m *browseModel) copyRows() {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = '\t'
+	header := make([]string, len(m.columns))
+	for i, col := range m.columns {
+		header[i] = col.title
+	}
+	_ = w.Write(header)
+	for _, i := range m.rows {
+		_ = w.Write(m.cells[i])
+	}
+	w.Flush()
+
+	seq, err := eclip.Sequence(ansi.Strip(b.String()))
+	if err != nil {
+		m.message = "copy failed: " + err.Error()
+		return
+	}
+	m.message, m.clipboard = fmt.Sprintf("copied %d rows", len(m.rows)), seq
+}
+
+// Scroll horizontally to show the selected column.
+func (m *browseModel) reveal() {
+	if len(m.columns) == 0 {
+		return
+	}
+	start := 0
+	for _, w := range m.widths[:m.selected] {
+		start += w + 2
+	}
+	end := start + m.widths[m.selected]
+	if start < m.scroll {
+		m.scroll = start
+	}
+	if end > m.scroll+m.width {
+		m.scroll = end - m.width
+	}
+}
+
+// Pad a cell to width, following the alignment of its column.
+func pad(s string, width int, alignment TableAlignment) string {
+	switch alignment {
+	case TableAlignmentRight:
+		return etext.PadLeft(s, width)
+	case TableAlignmentCenter:
+		return etext.Center(s, width)
+	}
+	return etext.PadRight(s, width)
+}
+
+// Render the header, with the selected column and the sort order.
+func (m browseModel) renderHeader() string {
+	header := m.table.style.HeaderStyle.UnsetPadding()
+	cells := make([]string, len(m.columns))
+	for i, col := range m.columns {
+		title := col.title
+		if i == m.sort {
+			glyph := m.style.AscendingGlyph
+			if m.descending {
+				glyph = m.style.DescendingGlyph
+			}
+			title += " " + glyph
+		}
+		style := header
+		if i == m.selected {
+			style = style.Inherit(m.style.SelectedStyle)
+		}
+		cells[i] = etext.PadRight(style.Render(title), m.widths[i])
+	}
+	return strings.Join(cells, "  ")
+}
+
+// Render a row, highlighting the matches of the filter.
+func (m browseModel) renderRow(row []string) string {
+	cells := make([]string, len(m.columns))
+	for i, col := range m.columns {
+		style := col.styleFunc(m.table.style.RowStyle.UnsetPadding(), row[i])
+		cells[i] = pad(m.search.Highlight(style.Render(row[i]), m.style.MatchStyle), m.widths[i], col.alignment)
+	}
+	return strings.Join(cells, "  ")
+}
+
+// Render the status line, with the title, the number of rows and the
+// filter.
+func (m browseModel) status() string {
+	style := m.style.StatusStyle
+	if m.search.Typing() {
+		prompt := ansi.Truncate(m.search.Prompt(), m.width, "…")
+		return style.Render(prompt + strings.Repeat(" ", max(m.width-ansi.StringWidth(prompt), 0)))
+	}
+
+	left := fmt.Sprintf("%d rows", len(m.rows))
+	if m.search.Active() {
+		left = fmt.Sprintf("%d/%d rows matching %q", len(m.rows), len(m.cells), m.search.Query())
+	}
+	if m.title != "" {
+		left = m.title + " · " + left
+	}
+	if m.message != "" {
+		left = m.message
+	}
+	right := " " + ekeys.Plain(ekeys.Bind("/", "filter"), ekeys.Bind("s", "sort"), ekeys.Bind("y", "copy").WithEnabled(eclip.Supported()), ekeys.Quit) + " "
+	left = ansi.Truncate(" "+left, max(m.width-ansi.StringWidth(right), 0), "…")
+	fill := strings.Repeat(" ", max(m.width-ansi.StringWidth(left)-ansi.StringWidth(right), 0))
+	return style.Render(left + fill + right)
+}
+
+func (m browseModel) View() string {
+	if m.height == 0 {
+		return ""
+	}
+	lines := []string{m.renderHeader()}
+	for i := m.offset; i < m.offset+m.page(); i++ {
+		if i < len(m.rows) {
+			lines = append(lines, m.renderRow(m.cells[m.rows[i]]))
+		} else {
+			lines = append(lines, "")
+		}
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(ansi.Cut(line, m.scroll, m.scroll+m.width), " ")
+	}
+	return strings.Join(lines, "\n") + "\n" + m.clipboard + m.status()
+}
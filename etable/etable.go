@@ -2,11 +2,12 @@ package etable
 
 import (
 	"encoding/csv"
-	"fmt"
 	"io"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/etheme"
 )
 
 // Table style definition.
@@ -22,17 +23,29 @@ type TableStyle struct {
 	BorderRight  bool
 }
 
-// Default TableStyle used by Table. Uses color ANSI termcolor 4 for the heading.
-var TableStyleDefault = TableStyle{
-	HeaderStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("4")).Bold(true).Padding(0, 1),
-	RowStyle:     lipgloss.NewStyle().Padding(0, 1),
-	BorderStyle:  lipgloss.HiddenBorder(),
-	BorderHeader: false,
-	BorderColumn: false,
-	BorderTop:    false,
-	BorderLeft:   false,
-	BorderBottom: false,
-	BorderRight:  false,
+// Default TableStyle used by Table. Uses the primary color of the
+// etheme.Theme for the heading.
+var TableStyleDefault = tableStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		TableStyleDefault = tableStyle(t)
+	})
+}
+
+// Returns the TableStyle of the theme t.
+func tableStyle(t etheme.Theme) TableStyle {
+	return TableStyle{
+		HeaderStyle:  lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Padding(0, 1),
+		RowStyle:     lipgloss.NewStyle().Padding(0, 1),
+		BorderStyle:  lipgloss.HiddenBorder(),
+		BorderHeader: false,
+		BorderColumn: false,
+		BorderTop:    false,
+		BorderLeft:   false,
+		BorderBottom: false,
+		BorderRight:  false,
+	}
 }
 
 // TableStyle for markdown formatting of the table
@@ -114,7 +127,8 @@ func NewTableColumn(key string, title string) TableColumn {
 	}
 }
 
-// Set a maximum width for the column after which its value will be truncated.
+// Set a maximum width for the column after which its value will be truncated,
+// ending with "...".
 //
 //	c := etable.NewTableColumn("id", "ID").WithMaxWidth(30)
 func (c TableColumn) WithMaxWidth(w int) TableColumn {
@@ -178,9 +192,10 @@ func (c TableColumn) WithStyleFunc(
 
 // A rapresentation of a Table.
 type Table struct {
-	columns []TableColumn
-	rows    []TableRow
-	style   TableStyle
+	columns     []TableColumn
+	rows        []TableRow
+	style       TableStyle
+	browseStyle BrowseStyle
 }
 
 // Create a new Table given its columns as TableColumn.
@@ -191,9 +206,10 @@ type Table struct {
 //	t := etable.NewTable(columns)
 func NewTable(columns []TableColumn) Table {
 	return Table{
-		columns: columns,
-		rows:    []TableRow{},
-		style:   TableStyleDefault,
+		columns:     columns,
+		rows:        []TableRow{},
+		style:       TableStyleDefault,
+		browseStyle: BrowseStyleDefault,
 	}
 }
 
@@ -205,6 +221,15 @@ func (t Table) WithStyle(s TableStyle) Table {
 	return t
 }
 
+// Specify the style of the selected column, the matches of the filter and
+// the status line when the Table is browsed, see Browse.
+//
+//	t := etable.NewTable(columns).WithBrowseStyle(etable.BrowseStyleDefault)
+func (t Table) WithBrowseStyle(s BrowseStyle) Table {
+	t.browseStyle = s
+	return t
+}
+
 // Adds a slice of TableRow to the Table
 //
 //	t := etable.NewTable(columns)
@@ -229,8 +254,9 @@ func (t *Table) getRowMatrix() [][]string {
 			if value == "" {
 				value = col.emptyString
 			}
-			if col.maxWidth > 0 && col.maxWidth < len(value) {
-				value = fmt.Sprintf("%.*s...", col.maxWidth-3, value)
+			if col.maxWidth > 0 {
+				// ASCII tail, the tables are often copied to logs and files
+				value = ansi.Truncate(value, col.maxWidth, "...")
 			}
 			row = append(row, value)
 		}
@@ -0,0 +1,43 @@
+package etable_test
+
+import (
+	"testing"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etest"
+)
+
+// Returns a Table of pods, the id column truncated to maxWidth.
+func pods(maxWidth int) etable.Table {
+	columns := []etable.TableColumn{
+		etable.NewTableColumn("id", "ID").WithMaxWidth(maxWidth),
+		etable.NewTableColumn("status", "Status").WithEmptyString("-"),
+		etable.NewTableColumn("restarts", "Restarts").WithAlignment(etable.TableAlignmentRight),
+	}
+	return etable.NewTable(columns).WithRows([]etable.TableRow{
+		{"id": "web-7d9f8c6b5-x2x4k", "status": "Running", "restarts": "0"},
+		{"id": "worker-5c4b8d7f9-q8z1m", "status": "CrashLoopBackOff", "restarts": "12"},
+		{"id": "cron-28345120-abcde", "restarts": "1"},
+	})
+}
+
+func TestRender(t *testing.T) {
+	etest.Setup(t)
+	table := pods(0)
+	etest.Golden(t, table.Render())
+}
+
+func TestRenderTruncated(t *testing.T) {
+	term := etest.TerminalDefault
+	term.Colors = eterm.Colors16
+	etest.SetupTerminal(t, term)
+	table := pods(12)
+	etest.Golden(t, table.Render())
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	etest.Setup(t)
+	table := pods(0).WithStyle(etable.TableStyleMarkdown)
+	etest.Golden(t, table.Render())
+}
@@ -0,0 +1,166 @@
+package etable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Import a Table from CSV data, with the fields separated by comma. The first
+// record is the header: its fields are the keys and the titles of the
+// columns. The key of a repeated title is suffixed with the index of its
+// column, like name_1.
+//
+//	t, err := etable.ImportCSV(f, ',')
+func ImportCSV(r io.Reader, comma rune) (Table, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	// TSV files often contain bare quotes
+	cr.LazyQuotes = comma == '\t'
+	records, err := cr.ReadAll()
+	if err != nil {
+		return Table{}, err
+	}
+	if len(records) == 0 {
+		return NewTable(nil), nil
+	}
+
+	columns := make([]TableColumn, len(records[0]))
+	keys := map[string]bool{}
+	for i, title := range records[0] {
+		key := title
+		for n := i; keys[key]; n++ {
+			key = fmt.Sprintf("%s_%d", title, n)
+		}
+		keys[key] = true
+		columns[i] = NewTableColumn(key, title)
+	}
+	rows := make([]TableRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := TableRow{}
+		for i, value := range record {
+			if i < len(columns) {
+				row[columns[i].key] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return NewTable(columns).WithRows(rows), nil
+}
+
+// Import a Table from a JSON array of objects. The columns are the keys of
+// the objects, in order of first appearance. Strings are used as they are,
+// the other values are rendered as compact JSON, and null as an empty
+// string.
+//
+//	t, err := etable.ImportJSON(resp.Body)
+func ImportJSON(r io.Reader) (Table, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil {
+		return Table{}, err
+	} else if tok != json.Delim('[') {
+		return Table{}, errors.New("expected a JSON array of objects")
+	}
+
+	columns := []TableColumn{}
+	seen := map[string]bool{}
+	rows := []TableRow{}
+	for dec.More() {
+		// Decoding the members one by one keeps the order of the keys
+		if tok, err := dec.Token(); err != nil {
+			return Table{}, err
+		} else if tok != json.Delim('{') {
+			return Table{}, fmt.Errorf("expected a JSON object at index %d", len(rows))
+		}
+		row := TableRow{}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return Table{}, err
+			}
+			key := tok.(string)
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return Table{}, err
+			}
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, NewTableColumn(key, key))
+			}
+			row[key] = jsonValue(value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return Table{}, err
+		}
+		rows = append(rows, row)
+	}
+	if _, err := dec.Token(); err != nil {
+		return Table{}, err
+	}
+	return NewTable(columns).WithRows(rows), nil
+}
+
+// Returns the value of a cell imported from JSON.
+func jsonValue(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	if string(raw) == "null" {
+		return ""
+	}
+	var b bytes.Buffer
+	if json.Compact(&b, raw) != nil {
+		return string(raw)
+	}
+	return b.String()
+}
+
+// Import a Table from the file at path: CSV, TSV or a JSON array of objects,
+// detected from the extension of the file or else from its content.
+//
+//	t, err := etable.ImportFile("users.csv")
+func ImportFile(path string) (Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Table{}, err
+	}
+
+	format := strings.ToLower(filepath.Ext(path))
+	switch format {
+	case ".csv", ".tsv", ".json":
+	default:
+		trimmed := bytes.TrimSpace(data)
+		firstLine, _, _ := bytes.Cut(trimmed, []byte("\n"))
+		switch {
+		case bytes.HasPrefix(trimmed, []byte("[")):
+			format = ".json"
+		case bytes.Contains(firstLine, []byte("\t")):
+			format = ".tsv"
+		default:
+			format = ".csv"
+		}
+	}
+
+	var t Table
+	switch format {
+	case ".json":
+		t, err = ImportJSON(bytes.NewReader(data))
+	case ".tsv":
+		t, err = ImportCSV(bytes.NewReader(data), '\t')
+	default:
+		t, err = ImportCSV(bytes.NewReader(data), ',')
+	}
+	if err != nil {
+		return Table{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,191 @@
+package etable
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Returns the keys of the columns of t.
+func keys(t Table) []string {
+	keys := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		keys[i] = c.key
+	}
+	return keys
+}
+
+func TestImportCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		comma   rune
+		columns []string
+		rows    []TableRow
+	}{
+		{
+			name:    "empty",
+			data:    "",
+			comma:   ',',
+			columns: []string{},
+			rows:    []TableRow{},
+		},
+		{
+			name:    "header only",
+			data:    "name,age\n",
+			comma:   ',',
+			columns: []string{"name", "age"},
+			rows:    []TableRow{},
+		},
+		{
+			name:    "quoted",
+			data:    "name,note\n\"Doe, John\",\"said \"\"hi\"\"\"\n",
+			comma:   ',',
+			columns: []string{"name", "note"},
+			rows:    []TableRow{{"name": "Doe, John", "note": `said "hi"`}},
+		},
+		{
+			name:    "ragged",
+			data:    "a,b\n1\n1,2,3\n",
+			comma:   ',',
+			columns: []string{"a", "b"},
+			rows:    []TableRow{{"a": "1"}, {"a": "1", "b": "2"}},
+		},
+		{
+			name:    "repeated titles",
+			data:    "name,name,name_2,name\nalice,bob,carol,dave\n",
+			comma:   ',',
+			columns: []string{"name", "name_1", "name_2", "name_3"},
+			rows:    []TableRow{{"name": "alice", "name_1": "bob", "name_2": "carol", "name_3": "dave"}},
+		},
+		{
+			name:    "tsv with bare quotes",
+			data:    "title\tsize\n12\" vinyl\t30cm\n",
+			comma:   '\t',
+			columns: []string{"title", "size"},
+			rows:    []TableRow{{"title": `12" vinyl`, "size": "30cm"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ImportCSV(strings.NewReader(tt.data), tt.comma)
+			if err != nil {
+				t.Fatalf("ImportCSV: %v", err)
+			}
+			if k := keys(got); !reflect.DeepEqual(k, tt.columns) {
+				t.Errorf("columns = %q, want %q", k, tt.columns)
+			}
+			if !reflect.DeepEqual(got.rows, tt.rows) {
+				t.Errorf("rows = %v, want %v", got.rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestImportCSVError(t *testing.T) {
+	if _, err := ImportCSV(strings.NewReader("a,b\n\"unterminated\n"), ','); err == nil {
+		t.Error("ImportCSV of an unterminated quote succeeded")
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		columns []string
+		rows    []TableRow
+	}{
+		{
+			name:    "empty",
+			data:    "[]",
+			columns: []string{},
+			rows:    []TableRow{},
+		},
+		{
+			name:    "keys in order of appearance",
+			data:    `[{"name": "web", "port": 80}, {"tls": true, "name": "api"}]`,
+			columns: []string{"name", "port", "tls"},
+			rows: []TableRow{
+				{"name": "web", "port": "80"},
+				{"name": "api", "tls": "true"},
+			},
+		},
+		{
+			name:    "values",
+			data:    `[{"s": "text", "n": 1.50, "null": null, "list": [1, 2], "obj": {"a": "b"}}]`,
+			columns: []string{"s", "n", "null", "list", "obj"},
+			rows: []TableRow{
+				{"s": "text", "n": "1.50", "null": "", "list": "[1,2]", "obj": `{"a":"b"}`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ImportJSON(strings.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("ImportJSON: %v", err)
+			}
+			if k := keys(got); !reflect.DeepEqual(k, tt.columns) {
+				t.Errorf("columns = %q, want %q", k, tt.columns)
+			}
+			if !reflect.DeepEqual(got.rows, tt.rows) {
+				t.Errorf("rows = %v, want %v", got.rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestImportJSONError(t *testing.T) {
+	tests := []string{
+		``,
+		`{"name": "web"}`,
+		`[1, 2]`,
+		`[{"name": "web"}`,
+		`[{"name": }]`,
+	}
+	for _, data := range tests {
+		if _, err := ImportJSON(strings.NewReader(data)); err == nil {
+			t.Errorf("ImportJSON(%q) succeeded", data)
+		}
+	}
+}
+
+func TestImportFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		columns []string
+	}{
+		{"users.csv", "name,age\nann,30\n", []string{"name", "age"}},
+		{"users.tsv", "name\tage\nann\t30\n", []string{"name", "age"}},
+		{"users.json", `[{"name": "ann"}]`, []string{"name"}},
+		{"users.txt", "name\tage\n", []string{"name", "age"}},
+		{"users", "  [{\"id\": 1}]", []string{"id"}},
+		{"users.data", "name,age\n", []string{"name", "age"}},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name)
+		if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ImportFile(path)
+		if err != nil {
+			t.Errorf("ImportFile(%s): %v", tt.name, err)
+			continue
+		}
+		if k := keys(got); !reflect.DeepEqual(k, tt.columns) {
+			t.Errorf("ImportFile(%s): columns = %q, want %q", tt.name, k, tt.columns)
+		}
+	}
+
+	path := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportFile(path); err == nil || !strings.HasPrefix(err.Error(), path+": ") {
+		t.Errorf("ImportFile(broken.json) = %v, want an error prefixed with the path", err)
+	}
+}
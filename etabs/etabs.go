@@ -0,0 +1,291 @@
+// Package etabs shows several views of the same output, like the summary,
+// the events and the logs of a resource, as tabs of a single interactive
+// screen.
+package etabs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// Identifiers of the TabsModels, to route their messages.
+var lastTabsID atomic.Int64
+
+// Tabs style definition. The tab bar is rendered above a rule of Rule
+// repeated on the whole width.
+type TabsStyle struct {
+	ActiveStyle   lipgloss.Style
+	InactiveStyle lipgloss.Style
+	RuleStyle     lipgloss.Style
+	Separator     string
+	Rule          string
+}
+
+// Default TabsStyle used by New. Uses the primary color of the etheme.Theme
+// for the active tab.
+var TabsStyleDefault = tabsStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		TabsStyleDefault = tabsStyle(t)
+	})
+}
+
+// Returns TabsStyleDefault for the theme t, TabsStyleASCII when the terminal
+// cannot display unicode.
+func tabsStyle(t etheme.Theme) TabsStyle {
+	if !eterm.Unicode() {
+		return TabsStyleASCII
+	}
+	return TabsStyle{
+		ActiveStyle:   lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Underline(true).Padding(0, 1),
+		InactiveStyle: lipgloss.NewStyle().Foreground(t.Muted).Faint(true).Padding(0, 1),
+		RuleStyle:     lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		Separator:     "│",
+		Rule:          "─",
+	}
+}
+
+// TabsStyle without colors nor unicode characters, for terminals with
+// limited support.
+var TabsStyleASCII = TabsStyle{
+	ActiveStyle:   lipgloss.NewStyle().Reverse(true).Padding(0, 1),
+	InactiveStyle: lipgloss.NewStyle().Padding(0, 1),
+	RuleStyle:     lipgloss.NewStyle(),
+	Separator:     "|",
+	Rule:          "-",
+}
+
+// A tab of a TabsModel, showing the View of its Model under its Title.
+type Tab struct {
+	Title string
+	Model tea.Model
+}
+
+// SelectMsg activates the tab at Index of the TabsModel with the same ID.
+type SelectMsg struct {
+	ID    int64
+	Index int
+}
+
+// Number of lines of the tab bar and its rule.
+const barHeight = 2
+
+// TabsModel is a bar of tabs over the View of the active one, to embed in
+// an existing bubbletea program: forward it the messages of the program and
+// render its View. Left and Right switch tabs, the other keys go to the
+// active tab and the other messages to all of them, so the inactive tabs
+// keep loading. Each tab receives the tea.WindowSizeMsg minus the lines of
+// the tab bar.
+//
+//	type model struct {
+//		tabs etabs.TabsModel
+//	}
+//
+//	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+//		var cmd tea.Cmd
+//		m.tabs, cmd = m.tabs.Update(msg)
+//		return m, cmd
+//	}
+type TabsModel struct {
+	id     int64
+	tabs   []Tab
+	active int
+	style  TabsStyle
+	width  int
+}
+
+// Create a new TabsModel of tabs, with the first one active.
+//
+//	tabs := etabs.New([]etabs.Tab{
+//		{Title: "Summary", Model: etabs.Static(card)},
+//		{Title: "Events", Model: etabs.Static(events.Render())},
+//		{Title: "Logs", Model: logs},
+//	})
+func New(tabs []Tab) TabsModel {
+	return TabsModel{
+		id:    lastTabsID.Add(1),
+		tabs:  tabs,
+		style: TabsStyleDefault,
+	}
+}
+
+// Specify the style of the TabsModel.
+//
+//	tabs := etabs.New(tabs).WithStyle(etabs.TabsStyleASCII)
+func (m TabsModel) WithStyle(s TabsStyle) TabsModel {
+	m.style = s
+	return m
+}
+
+// Specify the tab active at first, counting from 0.
+//
+//	tabs := etabs.New(tabs).WithActive(2)
+func (m TabsModel) WithActive(i int) TabsModel {
+	m.active = min(max(i, 0), max(len(m.tabs)-1, 0))
+	return m
+}
+
+// Identifier of the TabsModel, set in its messages.
+func (m TabsModel) ID() int64 {
+	return m.id
+}
+
+// Returns the index of the active tab.
+func (m TabsModel) Active() int {
+	return m.active
+}
+
+// Returns the tabs, with their Models updated.
+func (m TabsModel) Tabs() []Tab {
+	return m.tabs
+}
+
+// Returns the message activating the tab at i, to send to the program from
+// any goroutine.
+//
+//	program.Send(m.tabs.Select(1))
+func (m TabsModel) Select(i int) SelectMsg {
+	return SelectMsg{ID: m.id, Index: i}
+}
+
+// Initialize the Models of all the tabs.
+func (m TabsModel) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.tabs))
+	for i, tab := range m.tabs {
+		cmds[i] = tab.Model.Init()
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m TabsModel) Update(msg tea.Msg) (TabsModel, tea.Cmd) {
+	if len(m.tabs) == 0 {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case SelectMsg:
+		if msg.ID == m.id {
+			m.active = min(max(msg.Index, 0), len(m.tabs)-1)
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m.broadcast(tea.WindowSizeMsg{Width: msg.Width, Height: max(msg.Height-barHeight, 0)})
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left":
+			m.active = (m.active - 1 + len(m.tabs)) % len(m.tabs)
+			return m, nil
+		case "right":
+			m.active = (m.active + 1) % len(m.tabs)
+			return m, nil
+		}
+		tabs := append([]Tab(nil), m.tabs...)
+		var cmd tea.Cmd
+		tabs[m.active].Model, cmd = tabs[m.active].Model.Update(msg)
+		m.tabs = tabs
+		return m, cmd
+	}
+	return m.broadcast(msg)
+}
+
+// Forward msg to the Models of all the tabs.
+func (m TabsModel) broadcast(msg tea.Msg) (TabsModel, tea.Cmd) {
+	tabs := make([]Tab, len(m.tabs))
+	cmds := make([]tea.Cmd, len(m.tabs))
+	for i, tab := range m.tabs {
+		tab.Model, cmds[i] = tab.Model.Update(msg)
+		tabs[i] = tab
+	}
+	m.tabs = tabs
+	return m, tea.Batch(cmds...)
+}
+
+// Render the tab bar and its rule.
+func (m TabsModel) renderBar() string {
+	titles := make([]string, len(m.tabs))
+	for i, tab := range m.tabs {
+		if i == m.active {
+			titles[i] = m.style.ActiveStyle.Render(tab.Title)
+		} else {
+			titles[i] = m.style.InactiveStyle.Render(tab.Title)
+		}
+	}
+	bar := strings.Join(titles, m.style.RuleStyle.Render(m.style.Separator))
+	width := m.width
+	if width <= 0 {
+		width = ansi.StringWidth(bar)
+	}
+	bar = ansi.Truncate(bar, width, "…")
+	rule := ""
+	if m.style.Rule != "" {
+		rule = strings.Repeat(m.style.Rule, width/max(ansi.StringWidth(m.style.Rule), 1))
+	}
+	return bar + "\n" + m.style.RuleStyle.Render(rule)
+}
+
+func (m TabsModel) View() string {
+	if len(m.tabs) == 0 {
+		return ""
+	}
+	return m.renderBar() + "\n" + m.tabs[m.active].Model.View()
+}
+
+// Show tabs filling the terminal until the user quits with q, Esc or
+// Ctrl+C, see TabsModel.
+// When stdout is not a terminal the View of each tab is printed under its
+// title instead.
+//
+//	err := etabs.Run([]etabs.Tab{
+//		{Title: "Summary", Model: etabs.Static(summary)},
+//		{Title: "Events", Model: etabs.Static(events)},
+//	})
+func Run(tabs []Tab) error {
+	if !eterm.IsTerminal(os.Stdout) {
+		for i, tab := range tabs {
+			if i > 0 {
+				fmt.Println()
+			}
+			if _, err := fmt.Println(tab.Title + "\n" + ansi.Strip(tab.Model.View())); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	_, err := live.Run(tea.NewProgram(runModel{tabs: New(tabs)}, tea.WithAltScreen()))
+	return err
+}
+
+// Bubbletea model of a running Run.
+type runModel struct {
+	tabs TabsModel
+}
+
+func (m runModel) Init() tea.Cmd {
+	return m.tabs.Init()
+}
+
+func (m runModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.tabs, cmd = m.tabs.Update(msg)
+	return m, cmd
+}
+
+func (m runModel) View() string {
+	return m.tabs.View()
+}
@@ -0,0 +1,51 @@
+package etabs
+
+import (
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Bubbletea model of the content of a Static tab.
+type staticModel struct {
+	content  string
+	viewport viewport.Model
+	sized    bool
+}
+
+// Returns a tab Model showing content, like a rendered table or card,
+// scrolled with Up, Down, PgUp and PgDown when it is taller than the tab.
+//
+//	tab := etabs.Tab{Title: "Pods", Model: etabs.Static(t.Render())}
+func Static(content string) tea.Model {
+	return staticModel{content: content}
+}
+
+func (m staticModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m staticModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		if !m.sized {
+			m.viewport = viewport.New(msg.Width, msg.Height)
+			m.viewport.SetContent(m.content)
+			m.sized = true
+		} else {
+			m.viewport.Width, m.viewport.Height = msg.Width, msg.Height
+		}
+		return m, nil
+	}
+	if !m.sized {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m staticModel) View() string {
+	if !m.sized {
+		return m.content
+	}
+	return m.viewport.View()
+}
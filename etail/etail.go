@@ -0,0 +1,417 @@
+package etail
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/ekeys"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+	"github.com/ravvio/easycli-ui/internal/search"
+)
+
+// The bubbletea.Msg carrying the lines read from the stream
+type tailMsgLines struct {
+	lines []string
+}
+
+// The bubbletea.Msg sent when the stream ends
+type tailMsgEnd struct {
+	err error
+}
+
+// Tail style definition.
+type TailStyle struct {
+	TextStyle   lipgloss.Style
+	MatchStyle  lipgloss.Style
+	SearchStyle lipgloss.Style
+	StatusStyle lipgloss.Style
+}
+
+// Default TailStyle used by Tail, with the status line in reverse video.
+var TailStyleDefault = tailStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		TailStyleDefault = tailStyle(t)
+	})
+}
+
+// Returns TailStyleDefault for the theme t.
+func tailStyle(t etheme.Theme) TailStyle {
+	return TailStyle{
+		TextStyle:   lipgloss.NewStyle(),
+		MatchStyle:  lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Warning),
+		SearchStyle: lipgloss.NewStyle().Foreground(t.Contrast).Background(t.Accent),
+		StatusStyle: lipgloss.NewStyle().Reverse(true),
+	}
+}
+
+// Tail shows the lines of a stream as they are written, like tail -f,
+// scrolling to follow them.
+type Tail struct {
+	r         io.Reader
+	title     string
+	style     TailStyle
+	maxLines  int
+	highlight *regexp.Regexp
+}
+
+// Create a new Tail of the lines read from r, keeping the last 10000.
+//
+//	err := etail.NewTail(logs).WithTitle("api-server").Run()
+func NewTail(r io.Reader) Tail {
+	return Tail{
+		r:        r,
+		style:    TailStyleDefault,
+		maxLines: 10000,
+	}
+}
+
+// Specify a title rendered in the status line.
+//
+//	t := etail.NewTail(logs).WithTitle("api-server")
+func (t Tail) WithTitle(title string) Tail {
+	t.title = title
+	return t
+}
+
+// Specify the style of the Tail.
+//
+//	t := etail.NewTail(logs).WithStyle(etail.TailStyleDefault)
+func (t Tail) WithStyle(s TailStyle) Tail {
+	t.style = s
+	return t
+}
+
+// Specify the number of lines kept, the oldest lines are discarded.
+//
+//	t := etail.NewTail(logs).WithMaxLines(1000)
+func (t Tail) WithMaxLines(n int) Tail {
+	t.maxLines = max(n, 1)
+	return t
+}
+
+// Highlight the matches of re in the lines, with the MatchStyle of the
+// TailStyle. Lines that are already styled are not highlighted.
+//
+//	t := etail.NewTail(logs).WithHighlight(regexp.MustCompile(`ERROR|WARN`))
+func (t Tail) WithHighlight(re *regexp.Regexp) Tail {
+	t.highlight = re
+	return t
+}
+
+// Follow the lines read from r with the default Tail, see Tail.Run.
+//
+//	if err := etail.Follow(stream); err != nil {
+//		return err
+//	}
+func Follow(r io.Reader) error {
+	return NewTail(r).Run()
+}
+
+// Show the lines read from r until the user quits with q or Ctrl+C. The
+// view follows the new lines until the user scrolls up, and follows them
+// again from the bottom, with End or G. / searches the lines, n and N move to
+// the next and the previous match, pausing the view, Esc clears the search.
+// The lines remain browsable when the stream ends. Returns the error of the
+// stream, if any.
+// When stdout is not a terminal the lines are copied to it instead.
+//
+//	err := etail.NewTail(logs).Run()
+func (t Tail) Run() error {
+	if !eterm.IsTerminal(os.Stdout) {
+		_, err := io.Copy(os.Stdout, t.r)
+		return err
+	}
+
+	lines := make(chan string, 1024)
+	end := make(chan error, 1)
+	go func() {
+		br := bufio.NewReader(t.r)
+		for {
+			line, err := br.ReadString('\n')
+			if line != "" {
+				lines <- strings.TrimRight(line, "\r\n")
+			}
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				end <- err
+				close(lines)
+				return
+			}
+		}
+	}()
+
+	m := tailModel{
+		tail:   t,
+		source: lines,
+		end:    end,
+		follow: true,
+	}
+	final, err := live.Run(tea.NewProgram(m, tea.WithAltScreen()))
+	if err != nil {
+		return err
+	}
+	return final.(tailModel).err
+}
+
+// Bubbletea model of a running Tail.
+type tailModel struct {
+	tail   Tail
+	source chan string
+	end    chan error
+	lines  []string
+	// Index of the first line shown, when not following
+	offset int
+	follow bool
+	// Lines received while not following
+	unread  int
+	ended   bool
+	err     error
+	width   int
+	height  int
+	search  search.Search
+	message string
+}
+
+// Returns the command waiting for the next lines of the stream, receiving
+// the ones already available at once.
+func (m tailModel) wait() tea.Cmd {
+	source, end := m.source, m.end
+	return func() tea.Msg {
+		line, ok := <-source
+		if !ok {
+			return tailMsgEnd{err: <-end}
+		}
+		batch := []string{line}
+		for len(batch) < 1000 {
+			select {
+			case line, ok := <-source:
+				if !ok {
+					return tailMsgLines{lines: batch}
+				}
+				batch = append(batch, line)
+			default:
+				return tailMsgLines{lines: batch}
+			}
+		}
+		return tailMsgLines{lines: batch}
+	}
+}
+
+// Number of rows of lines shown at once, above the status line.
+func (m tailModel) page() int {
+	return max(m.height-1, 1)
+}
+
+// Number of rows a line takes once wrapped.
+func (m tailModel) rows(line string) int {
+	if m.width <= 0 {
+		return 1
+	}
+	return max((ansi.StringWidth(line)+m.width-1)/m.width, 1)
+}
+
+// Returns the index of the first line shown when the last line is at the
+// bottom.
+func (m tailModel) bottom() int {
+	rows := 0
+	for i := len(m.lines) - 1; i >= 0; i-- {
+		rows += m.rows(m.lines[i])
+		if rows > m.page() {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Scroll to the line at index offset, following the new lines again when
+// the last one is visible.
+func (m *tailModel) scrollTo(offset int) {
+	bottom := m.bottom()
+	if offset >= bottom {
+		m.follow = true
+		m.unread = 0
+		return
+	}
+	m.follow = false
+	m.offset = max(offset, 0)
+}
+
+// Index of the first line shown.
+func (m tailModel) first() int {
+	if m.follow {
+		return m.bottom()
+	}
+	return m.offset
+}
+
+func (m tailModel) Init() tea.Cmd {
+	return m.wait()
+}
+
+func (m tailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tailMsgLines:
+		m.lines = append(m.lines, msg.lines...)
+		if !m.follow {
+			m.unread += len(msg.lines)
+		}
+		if extra := len(m.lines) - m.tail.maxLines; extra > 0 {
+			m.lines = append([]string(nil), m.lines[extra:]...)
+			m.offset = max(m.offset-extra, 0)
+		}
+		return m, m.wait()
+	case tailMsgEnd:
+		m.ended, m.err = true, msg.err
+	case tea.KeyMsg:
+		m.message = ""
+		first := m.first()
+		if m.search.Typing() {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			if m.search.Update(msg) {
+				m.find(first-1, false)
+			}
+			return m, nil
+		}
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			if !m.search.Active() {
+				return m, tea.Quit
+			}
+			m.search.Clear()
+		case "/":
+			m.search.Start()
+		case "n":
+			m.find(first, false)
+		case "N":
+			m.find(first, true)
+		case "up", "k":
+			m.scrollTo(first - 1)
+		case "down", "j":
+			m.scrollTo(first + 1)
+		case "pgup", "b", "ctrl+b":
+			m.scrollTo(first - m.page())
+		case "pgdown", " ", "f", "ctrl+f":
+			m.scrollTo(first + m.page())
+		case "home", "g":
+			m.scrollTo(0)
+		case "end", "G":
+			m.scrollTo(len(m.lines))
+		}
+	}
+	return m, nil
+}
+
+// Scroll to the next line matching the search after the line at index
+// from, or the previous one when backward is set.
+func (m *tailModel) find(from int, backward bool) {
+	if !m.search.Active() || len(m.lines) == 0 {
+		return
+	}
+	line, ok := search.Next(len(m.lines), from, backward, func(i int) bool {
+		return m.search.Match(m.lines[i])
+	})
+	if !ok {
+		m.message = "pattern not found: " + m.search.Query()
+		return
+	}
+	m.scrollTo(line)
+}
+
+// Render a line, highlighting the matches of the pattern and of the search.
+func (m tailModel) renderLine(line string) string {
+	return m.search.Highlight(m.highlight(line), m.tail.style.SearchStyle)
+}
+
+// Render a line, highlighting the matches of the pattern.
+func (m tailModel) highlight(line string) string {
+	style := m.tail.style
+	re := m.tail.highlight
+	if re == nil || strings.Contains(line, "\x1b") {
+		return style.TextStyle.Render(line)
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range re.FindAllStringIndex(line, -1) {
+		if match[1] == match[0] {
+			continue
+		}
+		b.WriteString(style.TextStyle.Render(line[last:match[0]]))
+		b.WriteString(style.MatchStyle.Render(line[match[0]:match[1]]))
+		last = match[1]
+	}
+	b.WriteString(style.TextStyle.Render(line[last:]))
+	return b.String()
+}
+
+// Render the status line, with the title, the state of the stream and the
+// number of lines.
+func (m tailModel) status() string {
+	if m.search.Typing() {
+		prompt := ansi.Truncate(m.search.Prompt(), m.width, "…")
+		return m.tail.style.StatusStyle.Render(prompt + strings.Repeat(" ", max(m.width-ansi.StringWidth(prompt), 0)))
+	}
+
+	state := "following"
+	switch {
+	case m.message != "":
+		state = m.message
+	case m.err != nil:
+		state = "error: " + m.err.Error()
+	case m.ended:
+		state = "end of stream"
+	case !m.follow && m.unread == 1:
+		state = "paused, 1 new line"
+	case !m.follow && m.unread > 1:
+		state = fmt.Sprintf("paused, %d new lines", m.unread)
+	case !m.follow:
+		state = "paused"
+	}
+	left := " " + state
+	if m.tail.title != "" {
+		left = " " + m.tail.title + " · " + state
+	}
+	right := fmt.Sprintf(" %d lines · %s ", len(m.lines), ekeys.Plain(ekeys.Bind("G", "follow").WithEnabled(!m.follow), ekeys.Quit))
+	left = ansi.Truncate(left, max(m.width-ansi.StringWidth(right), 0), "…")
+	fill := strings.Repeat(" ", max(m.width-ansi.StringWidth(left)-ansi.StringWidth(right), 0))
+	return m.tail.style.StatusStyle.Render(left + fill + right)
+}
+
+func (m tailModel) View() string {
+	if m.height == 0 {
+		return ""
+	}
+	rows := []string{}
+	for i := m.first(); i < len(m.lines) && len(rows) < m.page(); i++ {
+		line := m.renderLine(m.lines[i])
+		if m.width > 0 {
+			rows = append(rows, strings.Split(ansi.Hardwrap(line, m.width, true), "\n")...)
+		} else {
+			rows = append(rows, line)
+		}
+	}
+	rows = rows[:min(len(rows), m.page())]
+	for len(rows) < m.page() {
+		rows = append(rows, "")
+	}
+	return strings.Join(rows, "\n") + "\n" + m.status()
+}
@@ -0,0 +1,282 @@
+// Package etask runs a workflow declared as a sequence of stages, each shown
+// with a spinner or a progress bar, retried on failure and producing
+// artifacts for the following stages, and ends with a summary of the run.
+package etask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/eprogress"
+	"github.com/ravvio/easycli-ui/espinner"
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/etimer"
+)
+
+// ErrInterrupted is returned when the pipeline is stopped by SIGINT,
+// SIGTERM or Ctrl+C.
+var ErrInterrupted = eterm.ErrInterrupted
+
+// Pipeline style definition. The statuses of the stages in the summary are
+// rendered with DoneStyle, FailedStyle and SkippedStyle, used for the
+// cancelled and not started ones.
+type PipelineStyle struct {
+	SpinnerStyle  espinner.SpinnerStyle
+	ProgressStyle eprogress.ProgressStyle
+	TimerStyle    etimer.TimerStyle
+	TableStyle    etable.TableStyle
+	DoneStyle     lipgloss.Style
+	FailedStyle   lipgloss.Style
+	SkippedStyle  lipgloss.Style
+}
+
+// Default PipelineStyle used by NewPipeline. Uses the default styles of
+// espinner, eprogress, etimer and etable, and the success and error colors
+// of the etheme.Theme for the statuses.
+var PipelineStyleDefault = pipelineStyle(etheme.Current())
+
+func init() {
+	// The hooks of the other packages run first, as they are registered
+	// when the packages are initialized.
+	etheme.OnChange(func(t etheme.Theme) {
+		PipelineStyleDefault = pipelineStyle(t)
+	})
+}
+
+// Returns PipelineStyleDefault for the theme t.
+func pipelineStyle(t etheme.Theme) PipelineStyle {
+	return PipelineStyle{
+		SpinnerStyle:  espinner.SpinnerStyleDefault,
+		ProgressStyle: eprogress.ProgressStyleDefault,
+		TimerStyle:    etimer.TimerStyleDefault,
+		TableStyle:    etable.TableStyleDefault,
+		DoneStyle:     lipgloss.NewStyle().Foreground(t.Success),
+		FailedStyle:   lipgloss.NewStyle().Foreground(t.Error).Bold(true),
+		SkippedStyle:  lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+	}
+}
+
+// A stage of a Pipeline. Run is rendered with a spinner, or with a
+// progress bar when Progress is set, updated with Context.Report. A failed
+// stage is attempted again up to Retries times, RetryDelay after the
+// failure.
+type Stage struct {
+	Name       string
+	Run        func(c *Context) error
+	Progress   bool
+	Retries    int
+	RetryDelay time.Duration
+}
+
+// A value produced by a stage, like the path of a binary or the tag of an
+// image.
+type Artifact struct {
+	Name  string
+	Value string
+}
+
+// Context lets the running stage report its progress, produce artifacts
+// and read the ones of the previous stages.
+type Context struct {
+	ctx       context.Context
+	report    eprogress.ReportFunc
+	attempt   int
+	pipeline  *artifacts
+	artifacts []Artifact
+}
+
+// Returns a context done when the spinner of the stage stops.
+//
+//	req, _ := http.NewRequestWithContext(c.Context(), "GET", url, nil)
+func (c *Context) Context() context.Context {
+	return c.ctx
+}
+
+// Returns the number of the current attempt of the stage, starting from 1.
+func (c *Context) Attempt() int {
+	return c.attempt
+}
+
+// Report the progress of a stage rendered with a progress bar, done out of
+// total units of work. It does nothing for the other stages.
+//
+//	c.Report(int64(i+1), int64(len(files)))
+func (c *Context) Report(done int64, total int64) {
+	if c.report != nil {
+		c.report(done, total)
+	}
+}
+
+// Produce the artifact name, readable by the following stages and listed in
+// the summary. Only the artifacts of the successful attempt are kept.
+//
+//	c.Produce("image", "registry.example.com/app:"+tag)
+func (c *Context) Produce(name string, value string) {
+	c.artifacts = append(c.artifacts, Artifact{Name: name, Value: value})
+}
+
+// Returns the artifact name produced by a previous stage.
+//
+//	image, ok := c.Artifact("image")
+func (c *Context) Artifact(name string) (string, bool) {
+	return c.pipeline.get(name)
+}
+
+// Artifacts produced by the completed stages of a Pipeline.
+type artifacts struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (a *artifacts) add(list []Artifact) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, artifact := range list {
+		a.values[artifact.Name] = artifact.Value
+	}
+}
+
+func (a *artifacts) get(name string) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	v, ok := a.values[name]
+	return v, ok
+}
+
+// Pipeline runs its stages one after the other, stopping at the first one
+// failing all its attempts, then prints a summary of the results.
+type Pipeline struct {
+	stages  []Stage
+	style   PipelineStyle
+	summary bool
+}
+
+// Create a new empty Pipeline.
+//
+//	p := etask.NewPipeline().
+//		WithStage(etask.Stage{Name: "Build", Run: build}).
+//		WithStage(etask.Stage{Name: "Upload", Run: upload, Progress: true, Retries: 2, RetryDelay: 5 * time.Second}).
+//		WithStage(etask.Stage{Name: "Deploy", Run: deploy})
+func NewPipeline() Pipeline {
+	return Pipeline{
+		stages:  []Stage{},
+		style:   PipelineStyleDefault,
+		summary: true,
+	}
+}
+
+// Add a stage at the end of the Pipeline.
+func (p Pipeline) WithStage(s Stage) Pipeline {
+	stages := make([]Stage, len(p.stages), len(p.stages)+1)
+	copy(stages, p.stages)
+	p.stages = append(stages, s)
+	return p
+}
+
+// Specify the style of the Pipeline.
+//
+//	p := etask.NewPipeline().WithStyle(style)
+func (p Pipeline) WithStyle(s PipelineStyle) Pipeline {
+	p.style = s
+	return p
+}
+
+// Print the summary of the run when the Pipeline ends, the default.
+//
+//	p := etask.NewPipeline().WithSummary(false)
+func (p Pipeline) WithSummary(s bool) Pipeline {
+	p.summary = s
+	return p
+}
+
+// Run the stages of the Pipeline, returning the result of each of them and
+// the error of the stage which failed. On SIGINT, SIGTERM or Ctrl+C the
+// running stage is cancelled, the remaining ones are not started and
+// ErrInterrupted is returned.
+//
+//	report, err := p.Run()
+//	if err != nil {
+//		return err
+//	}
+//	image, _ := report.Artifact("image")
+func (p Pipeline) Run() (Report, error) {
+	report := Report{Stages: make([]StageResult, len(p.stages)), style: p.style}
+	for i, stage := range p.stages {
+		report.Stages[i] = StageResult{Name: stage.Name, Status: espinner.TaskNotStarted}
+	}
+
+	store := &artifacts{values: map[string]string{}}
+	var err error
+	for i, stage := range p.stages {
+		report.Stages[i], err = p.runStage(i, stage, store)
+		if err != nil {
+			break
+		}
+	}
+	if p.summary {
+		fmt.Println(report.Render())
+	}
+	return report, err
+}
+
+// Run the i-th stage of the Pipeline until it succeeds or fails all its
+// attempts.
+func (p Pipeline) runStage(i int, stage Stage, store *artifacts) (StageResult, error) {
+	result := StageResult{Name: stage.Name}
+	start := time.Now()
+	finish := func(status espinner.TaskStatus, err error) (StageResult, error) {
+		result.Status, result.Err, result.Duration = status, err, time.Since(start)
+		return result, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		result.Attempts = attempt
+		title := fmt.Sprintf("[%d/%d] %s", i+1, len(p.stages), stage.Name)
+		if attempt > 1 {
+			title += fmt.Sprintf(" (attempt %d/%d)", attempt, stage.Retries+1)
+		}
+
+		c := &Context{ctx: context.Background(), attempt: attempt, pipeline: store}
+		err := p.attempt(title, stage, c)
+		switch {
+		case err == nil:
+			store.add(c.artifacts)
+			result.Artifacts = c.artifacts
+			return finish(espinner.TaskDone, nil)
+		case errors.Is(err, ErrInterrupted):
+			return finish(espinner.TaskCancelled, ErrInterrupted)
+		case attempt > stage.Retries:
+			return finish(espinner.TaskFailed, err)
+		}
+
+		if stage.RetryDelay > 0 {
+			wait := etimer.Countdown(stage.RetryDelay, "Retrying "+stage.Name+" in").WithStyle(p.style.TimerStyle)
+			if err := wait.Run(); err != nil {
+				return finish(espinner.TaskCancelled, ErrInterrupted)
+			}
+		}
+	}
+}
+
+// Run an attempt of the stage with a spinner or a progress bar.
+func (p Pipeline) attempt(title string, stage Stage, c *Context) error {
+	if stage.Progress {
+		bar := eprogress.NewProgress(title, func(report eprogress.ReportFunc) error {
+			c.report = report
+			return stage.Run(c)
+		}).WithStyle(p.style.ProgressStyle)
+		return bar.Run()
+	}
+
+	s := espinner.NewSpinnerWithHandle(title, func(h *espinner.Handle) error {
+		c.ctx = h.Context()
+		return stage.Run(c)
+	}).WithStyle(p.style.SpinnerStyle)
+	return s.Spin()
+}
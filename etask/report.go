@@ -0,0 +1,121 @@
+package etask
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/espinner"
+	"github.com/ravvio/easycli-ui/etable"
+)
+
+// Result of a stage run by a Pipeline. Err is the error of the last
+// attempt of a failed stage.
+type StageResult struct {
+	Name      string
+	Status    espinner.TaskStatus
+	Err       error
+	Attempts  int
+	Duration  time.Duration
+	Artifacts []Artifact
+}
+
+// Results of the stages run by a Pipeline, in the order they were added.
+type Report struct {
+	Stages []StageResult
+	style  PipelineStyle
+}
+
+// Returns the artifact name produced by the stages of the run.
+//
+//	image, ok := report.Artifact("image")
+func (r Report) Artifact(name string) (string, bool) {
+	for i := len(r.Stages) - 1; i >= 0; i-- {
+		for _, a := range r.Stages[i].Artifacts {
+			if a.Name == name {
+				return a.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Returns the total duration of the stages of the run.
+func (r Report) Duration() time.Duration {
+	var total time.Duration
+	for _, s := range r.Stages {
+		total += s.Duration
+	}
+	return total
+}
+
+// Render a table of the stages with their status, duration and artifacts.
+// The Attempts and Error columns are shown only when a stage was retried or
+// failed.
+//
+//	fmt.Println(report.Render())
+//
+//	Stage   Status  Duration  Artifacts
+//	Build   done        12.4s  binary=dist/app
+//	Upload  done         3.1s
+//	Deploy  done         41s  url=https://app.example.com
+func (r Report) Render() string {
+	retried, failed := false, false
+	rows := make([]etable.TableRow, len(r.Stages))
+	for i, s := range r.Stages {
+		artifacts := make([]string, len(s.Artifacts))
+		for j, a := range s.Artifacts {
+			artifacts[j] = a.Name + "=" + a.Value
+		}
+		row := etable.TableRow{
+			"stage":     s.Name,
+			"status":    s.Status.String(),
+			"attempts":  "",
+			"duration":  "",
+			"artifacts": strings.Join(artifacts, ", "),
+			"error":     "",
+		}
+		if s.Status != espinner.TaskNotStarted {
+			row["attempts"] = strconv.Itoa(s.Attempts)
+			row["duration"] = formatDuration(s.Duration)
+		}
+		if s.Err != nil {
+			row["error"] = s.Err.Error()
+			failed = true
+		}
+		retried = retried || s.Attempts > 1
+		rows[i] = row
+	}
+
+	s := r.style
+	columns := []etable.TableColumn{
+		etable.NewTableColumn("stage", "Stage"),
+		etable.NewTableColumn("status", "Status").WithStyleFunc(func(style lipgloss.Style, value string) lipgloss.Style {
+			switch value {
+			case espinner.TaskDone.String():
+				return style.Inherit(s.DoneStyle)
+			case espinner.TaskFailed.String():
+				return style.Inherit(s.FailedStyle)
+			}
+			return style.Inherit(s.SkippedStyle)
+		}),
+		etable.NewTableColumn("attempts", "Attempts").WithAlignment(etable.TableAlignmentRight).WithActive(retried),
+		etable.NewTableColumn("duration", "Duration").WithAlignment(etable.TableAlignmentRight).WithEmptyString("-"),
+		etable.NewTableColumn("artifacts", "Artifacts"),
+		etable.NewTableColumn("error", "Error").WithActive(failed),
+	}
+	t := etable.NewTable(columns).WithStyle(s.TableStyle).WithRows(rows)
+	return t.Render()
+}
+
+// Format a duration with a precision fitting its magnitude.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,148 @@
+package eterm
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Number of colors supported by the terminal.
+type ColorDepth int
+
+const (
+	// No colors, only attributes like bold and reverse
+	NoColor ColorDepth = iota
+	// The 16 ANSI colors
+	Colors16
+	// The 256 colors of the xterm palette
+	Colors256
+	// 24-bit colors
+	TrueColor
+)
+
+var (
+	colorMu sync.Mutex
+	// Profile detected by lipgloss, saved when the depth is first forced
+	detected *termenv.Profile
+)
+
+// Returns the color depth of the terminal, detected from the environment
+// like TERM, COLORTERM and NO_COLOR, or the one set with ForceColorDepth.
+//
+//	if eterm.Colors() == eterm.TrueColor {
+//		etheme.SetTheme(etheme.ThemeNord)
+//	}
+func Colors() ColorDepth {
+	switch lipgloss.ColorProfile() {
+	case termenv.TrueColor:
+		return TrueColor
+	case termenv.ANSI256:
+		return Colors256
+	case termenv.ANSI:
+		return Colors16
+	}
+	return NoColor
+}
+
+// Force the color depth used to render the styles of the components,
+// colors are degraded to the nearest supported one.
+//
+//	if *flagNoColor {
+//		eterm.ForceColorDepth(eterm.NoColor)
+//	}
+func ForceColorDepth(d ColorDepth) {
+	colorMu.Lock()
+	defer colorMu.Unlock()
+	if detected == nil {
+		p := lipgloss.ColorProfile()
+		detected = &p
+	}
+	profile := termenv.Ascii
+	switch d {
+	case TrueColor:
+		profile = termenv.TrueColor
+	case Colors256:
+		profile = termenv.ANSI256
+	case Colors16:
+		profile = termenv.ANSI
+	}
+	lipgloss.SetColorProfile(profile)
+}
+
+// Returns a function restoring the color depth to the current one, forced
+// or not.
+func saveColorDepth() func() {
+	colorMu.Lock()
+	defer colorMu.Unlock()
+	profile, saved := lipgloss.ColorProfile(), detected
+
+	return func() {
+		colorMu.Lock()
+		defer colorMu.Unlock()
+		lipgloss.SetColorProfile(profile)
+		detected = saved
+	}
+}
+
+// Restore the color depth detected before it was forced.
+func resetColorDepth() {
+	colorMu.Lock()
+	defer colorMu.Unlock()
+	if detected != nil {
+		lipgloss.SetColorProfile(*detected)
+		detected = nil
+	}
+}
+
+// Reports whether the terminal can display unicode characters like box
+// drawing and braille, or the value set with ForceUnicode. The locale of
+// LC_ALL, LC_CTYPE or LANG must be UTF-8 when set, the Linux console and
+// the legacy Windows console are considered limited.
+//
+//	check := "✓"
+//	if !eterm.Unicode() {
+//		check = "ok"
+//	}
+func Unicode() bool {
+	mu.RLock()
+	forced := unicode
+	mu.RUnlock()
+	if forced != nil {
+		return *forced
+	}
+
+	if runtime.GOOS == "windows" {
+		return os.Getenv("WT_SESSION") != "" || os.Getenv("TERM_PROGRAM") != ""
+	}
+	if os.Getenv("TERM") == "linux" {
+		return false
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(os.Getenv(name)); locale != "" {
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return true
+}
+
+// Set whether the terminal is considered able to display unicode
+// characters, see Unicode. The default styles of the components are
+// rebuilt, to use their ASCII variants when u is false.
+//
+//	eterm.ForceUnicode(false)
+func ForceUnicode(u bool) {
+	mu.Lock()
+	unicode = &u
+	mu.Unlock()
+	rebuildStyles()
+}
+
+// Rebuild the default styles of the components, which depend on Unicode.
+func rebuildStyles() {
+	etheme.SetTheme(etheme.Current())
+}
@@ -0,0 +1,191 @@
+// Package eterm detects the capabilities of the terminal, like its size,
+// whether the output is a terminal, the colors and the characters it
+// supports. The components consult it instead of the terminal directly, so
+// the detection can be overridden in one place, for example from a flag or
+// in tests.
+//
+//	if *flagWidth > 0 {
+//		eterm.ForceWidth(*flagWidth)
+//	}
+package eterm
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// Size used when the output is not a terminal and COLUMNS or LINES are not
+// set.
+const (
+	widthDefault  = 80
+	heightDefault = 24
+)
+
+var (
+	mu sync.RWMutex
+	// Overrides of the detection, 0 or nil when not set
+	width   int
+	height  int
+	tty     *bool
+	unicode *bool
+)
+
+// ErrInterrupted is returned by the live components when the user stops them
+// with Ctrl+C or the program receives SIGINT or SIGTERM. The packages of the
+// components export it with the same name.
+//
+//	if errors.Is(err, eterm.ErrInterrupted) {
+//		os.Exit(130)
+//	}
+var ErrInterrupted = errors.New("interrupted")
+
+// Reports whether f is a terminal, or the value set with ForceTTY.
+//
+//	if !eterm.IsTerminal(os.Stdout) {
+//		// print plain lines
+//	}
+func IsTerminal(f *os.File) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	if tty != nil {
+		return *tty
+	}
+	return term.IsTerminal(f.Fd())
+}
+
+// Returns the terminal the control sequences are written to, like the ones
+// setting its title or its clipboard: stdout or else stderr, or nil when
+// neither is a terminal.
+//
+//	if w := eterm.ControlWriter(); w != nil {
+//		io.WriteString(w, ansi.SetWindowTitle("deploying"))
+//	}
+func ControlWriter() io.Writer {
+	switch {
+	case IsTerminal(os.Stdout):
+		return os.Stdout
+	case IsTerminal(os.Stderr):
+		return os.Stderr
+	}
+	return nil
+}
+
+// Set whether the files are considered terminals. Forcing false renders
+// every component as plain output, as if redirected to a file.
+//
+//	eterm.ForceTTY(!*flagNoInteractive)
+func ForceTTY(t bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	tty = &t
+}
+
+// Returns the size of the terminal in cells. Forced sizes take precedence,
+// when the output is not a terminal the size is read from the COLUMNS and
+// LINES environment variables, or 80x24.
+//
+//	width, height := eterm.Size()
+func Size() (int, int) {
+	mu.RLock()
+	w, h := width, height
+	mu.RUnlock()
+	if w > 0 && h > 0 {
+		return w, h
+	}
+
+	tw, th, err := term.GetSize(os.Stdout.Fd())
+	if err != nil || tw <= 0 || th <= 0 {
+		tw, th = envSize("COLUMNS", widthDefault), envSize("LINES", heightDefault)
+	}
+	if w <= 0 {
+		w = tw
+	}
+	if h <= 0 {
+		h = th
+	}
+	return w, h
+}
+
+// Returns the width of the terminal in cells, see Size.
+func Width() int {
+	w, _ := Size()
+	return w
+}
+
+// Returns the height of the terminal in lines, see Size.
+func Height() int {
+	_, h := Size()
+	return h
+}
+
+// Returns the positive integer in the environment variable name, or def.
+func envSize(name string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// Force the width returned by Size, used by the components rendering to the
+// width of the terminal. A width of 0 or less restores the detection.
+// Interactive components keep following the size of the terminal.
+//
+//	eterm.ForceWidth(120)
+func ForceWidth(w int) {
+	mu.Lock()
+	defer mu.Unlock()
+	width = max(w, 0)
+}
+
+// Force the height returned by Size, see ForceWidth.
+//
+//	eterm.ForceHeight(40)
+func ForceHeight(h int) {
+	mu.Lock()
+	defer mu.Unlock()
+	height = max(h, 0)
+}
+
+// Returns a function restoring the overrides of the detection, including
+// the color depth, to their current values. Useful to override the
+// detection temporarily.
+//
+//	restore := eterm.Save()
+//	defer restore()
+//	eterm.ForceTTY(true)
+func Save() func() {
+	mu.RLock()
+	w, h, t, u := width, height, tty, unicode
+	mu.RUnlock()
+	restoreColors := saveColorDepth()
+
+	return func() {
+		mu.Lock()
+		changed := unicode != u
+		width, height, tty, unicode = w, h, t, u
+		mu.Unlock()
+		restoreColors()
+		if changed {
+			rebuildStyles()
+		}
+	}
+}
+
+// Remove all the overrides, restoring the detection of the terminal.
+//
+//	defer eterm.Reset()
+func Reset() {
+	mu.Lock()
+	forced := unicode != nil
+	width, height, tty, unicode = 0, 0, nil, nil
+	mu.Unlock()
+	resetColorDepth()
+	if forced {
+		rebuildStyles()
+	}
+}
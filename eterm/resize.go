@@ -0,0 +1,32 @@
+package eterm
+
+// Call fn with the new size of the terminal each time it is resized, until
+// the returned function is called. Useful to re-render static output, the
+// interactive components receive the size from bubbletea instead.
+//
+//	stop := eterm.OnResize(func(width, height int) {
+//		redraw(width)
+//	})
+//	defer stop()
+func OnResize(fn func(width, height int)) func() {
+	resized, stopEvents := resizeEvents()
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-resized:
+				fn(Size())
+			}
+		}
+	}()
+
+	return func() {
+		stopEvents()
+		close(stop)
+		<-stopped
+	}
+}
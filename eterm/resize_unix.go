@@ -0,0 +1,35 @@
+//go:build !windows
+
+package eterm
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Returns a channel receiving a value when the terminal is resized, and
+// the function releasing it. The resizes are signaled by SIGWINCH.
+func resizeEvents() (<-chan struct{}, func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGWINCH)
+	resized := make(chan struct{}, 1)
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-sig:
+				select {
+				case resized <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return resized, func() {
+		signal.Stop(sig)
+		close(stop)
+	}
+}
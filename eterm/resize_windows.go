@@ -0,0 +1,36 @@
+package eterm
+
+import "time"
+
+// Interval between two checks of the size of the console.
+const resizePoll = 250 * time.Millisecond
+
+// Returns a channel receiving a value when the terminal is resized, and
+// the function releasing it. The console does not signal its resizes, so
+// its size is polled.
+func resizeEvents() (<-chan struct{}, func()) {
+	resized := make(chan struct{}, 1)
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(resizePoll)
+		defer ticker.Stop()
+		w, h := Size()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if nw, nh := Size(); nw != w || nh != h {
+					w, h = nw, nh
+					select {
+					case resized <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}
+	}()
+	return resized, func() {
+		close(stop)
+	}
+}
@@ -0,0 +1,151 @@
+// Package etest helps writing stable tests of the output of the
+// components: it emulates a terminal of fixed size and colors, captures
+// what the components print and compares it with golden files.
+//
+//	func TestStatus(t *testing.T) {
+//		etest.Setup(t)
+//		etest.Golden(t, renderStatus(pods).Render())
+//	}
+package etest
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Terminal emulated by SetupTerminal.
+type Terminal struct {
+	Width  int
+	Height int
+	Colors eterm.ColorDepth
+	// Whether AdaptiveColors use their Dark variant
+	Dark    bool
+	Unicode bool
+	Theme   etheme.Theme
+}
+
+// Terminal emulated by Setup: 80x24 cells, without colors, with the
+// default etheme.Theme. Its output is not a terminal, so the components
+// print plain lines instead of animations.
+var TerminalDefault = Terminal{
+	Width:   80,
+	Height:  24,
+	Colors:  eterm.NoColor,
+	Dark:    true,
+	Unicode: true,
+	Theme:   etheme.ThemeDefault,
+}
+
+// Emulate TerminalDefault until the end of the test t.
+//
+//	etest.Setup(t)
+func Setup(t testing.TB) {
+	t.Helper()
+	SetupTerminal(t, TerminalDefault)
+}
+
+// Emulate term until the end of the test t, restoring the detection of the
+// terminal and the etheme.Theme afterwards. The emulation is global, so
+// the tests using it must not run in parallel.
+//
+//	term := etest.TerminalDefault
+//	term.Colors = eterm.TrueColor
+//	etest.SetupTerminal(t, term)
+func SetupTerminal(t testing.TB, term Terminal) {
+	t.Helper()
+	theme, dark := etheme.Current(), lipgloss.HasDarkBackground()
+	t.Cleanup(func() {
+		eterm.Reset()
+		lipgloss.SetHasDarkBackground(dark)
+		etheme.SetTheme(theme)
+	})
+
+	eterm.ForceWidth(term.Width)
+	eterm.ForceHeight(term.Height)
+	eterm.ForceTTY(false)
+	eterm.ForceUnicode(term.Unicode)
+	eterm.ForceColorDepth(term.Colors)
+	lipgloss.SetHasDarkBackground(term.Dark)
+	etheme.SetTheme(term.Theme)
+}
+
+// Returns what fn prints on stdout, like the plain lines of a spinner or
+// a rendered table.
+//
+//	out := etest.Capture(t, func() {
+//		s := espinner.NewSpinner("Building", build)
+//		s.Spin()
+//	})
+func Capture(t testing.TB, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("etest: capture stdout: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	read := make(chan []byte)
+	go func() {
+		var b bytes.Buffer
+		_, _ = io.Copy(&b, r)
+		r.Close()
+		read <- b.Bytes()
+	}()
+
+	fn()
+	w.Close()
+	return string(<-read)
+}
+
+// Returns s without its escape sequences.
+//
+//	etest.StripANSI("\x1b[1mbold\x1b[0m") // "bold"
+func StripANSI(s string) string {
+	return ansi.Strip(s)
+}
+
+// Select Graphic Rendition sequences, setting the colors and attributes.
+var sgr = regexp.MustCompile(`\x1b\[([0-9;:]*)m`)
+
+// Returns s with its colors and attributes written as readable tags, like
+// <1;34> and <0>, and without its other escape sequences, so golden files
+// can check the styles and remain readable.
+//
+//	etest.NormalizeANSI("\x1b[1mbold\x1b[m") // "<1>bold<0>"
+func NormalizeANSI(s string) string {
+	s = sgr.ReplaceAllStringFunc(s, func(seq string) string {
+		params := sgr.FindStringSubmatch(seq)[1]
+		if params == "" {
+			params = "0"
+		}
+		return "\x00" + params + "\x01"
+	})
+	s = ansi.Strip(s)
+	return strings.NewReplacer("\x00", "<", "\x01", ">").Replace(s)
+}
+
+// Returns s with Unix line endings and without trailing spaces at the end
+// of the lines nor trailing empty lines, which depend on the padding of the
+// components rather than on their content.
+//
+//	etest.Normalize("a  \r\nb\n\n") // "a\nb\n"
+func Normalize(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
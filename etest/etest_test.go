@@ -0,0 +1,74 @@
+package etest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ravvio/easycli-ui/eterm"
+)
+
+func TestNormalizeANSI(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"plain", "plain"},
+		{"\x1b[1mbold\x1b[m", "<1>bold<0>"},
+		{"\x1b[1;34mblue\x1b[0m", "<1;34>blue<0>"},
+		{"\x1b[38:5:196mred\x1b[0m", "<38:5:196>red<0>"},
+		{"\x1b[2Kline\x1b]0;title\x07", "line"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeANSI(tt.s); got != tt.want {
+			t.Errorf("NormalizeANSI(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", "\n"},
+		{"a", "a\n"},
+		{"a  \r\nb\t\n\n", "a\nb\n"},
+		{"  indented\n\nkept", "  indented\n\nkept\n"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.s); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	if got := StripANSI("\x1b[1mbold\x1b[0m"); got != "bold" {
+		t.Errorf("StripANSI = %q, want %q", got, "bold")
+	}
+}
+
+func TestSetupTerminal(t *testing.T) {
+	width, unicode := eterm.Width(), eterm.Unicode()
+	t.Run("emulated", func(t *testing.T) {
+		term := TerminalDefault
+		term.Width = 33
+		term.Unicode = !unicode
+		SetupTerminal(t, term)
+		if eterm.Width() != 33 || eterm.Height() != 24 || eterm.Unicode() != !unicode || eterm.Colors() != eterm.NoColor {
+			t.Errorf("emulated %dx%d, unicode %v, colors %v", eterm.Width(), eterm.Height(), eterm.Unicode(), eterm.Colors())
+		}
+	})
+	if eterm.Width() != width || eterm.Unicode() != unicode {
+		t.Errorf("after the test: width %d, unicode %v, want %d, %v", eterm.Width(), eterm.Unicode(), width, unicode)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	got := Capture(t, func() {
+		fmt.Println("captured")
+	})
+	if got != "captured\n" {
+		t.Errorf("Capture = %q, want %q", got, "captured\n")
+	}
+}
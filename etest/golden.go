@@ -0,0 +1,82 @@
+package etest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ravvio/easycli-ui/ediff"
+)
+
+// Environment variable rewriting the golden files instead of comparing
+// them when set to 1.
+const updateEnv = "ETEST_UPDATE"
+
+// Reports whether the golden files should be rewritten, with
+// ETEST_UPDATE=1 or with the -update flag when the test binary defines it.
+func updating() bool {
+	if os.Getenv(updateEnv) == "1" {
+		return true
+	}
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+// Compare got with the golden file of the test t, in testdata and named
+// after the test, failing the test with a diff when they differ. got is
+// compared after NormalizeANSI and Normalize.
+// Run the tests with ETEST_UPDATE=1 to create or update the golden files.
+//
+//	etest.Golden(t, table.Render())
+func Golden(t testing.TB, got string) {
+	t.Helper()
+	GoldenFile(t, goldenName(t.Name()), got)
+}
+
+// Compare got with the golden file name, in testdata, see Golden. Useful
+// to check several outputs in the same test.
+//
+//	etest.GoldenFile(t, "table-narrow.golden", table.Render())
+func GoldenFile(t testing.TB, name string, got string) {
+	t.Helper()
+	got = Normalize(NormalizeANSI(got))
+	path := filepath.Join("testdata", name)
+
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("etest: create testdata: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("etest: write golden file: %v", err)
+		}
+		return
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("etest: read golden file, run with %s=1 to create it: %v", updateEnv, err)
+	}
+	want := Normalize(string(b))
+	if got == want {
+		return
+	}
+	d := ediff.NewDiff(want, got).
+		WithNames(path, "got").
+		WithMode(ediff.DiffUnified)
+	t.Errorf("etest: output differs from %s, run with %s=1 to update it:\n%s", path, updateEnv, StripANSI(d.Render()))
+}
+
+// Returns the name of the golden file of the test name, with the subtests
+// and the characters not allowed in file names replaced by underscores.
+func goldenName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, name)
+	return name + ".golden"
+}
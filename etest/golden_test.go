@@ -0,0 +1,94 @@
+package etest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoldenName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"TestRender", "TestRender.golden"},
+		{"TestRender/narrow", "TestRender_narrow.golden"},
+		{"TestRender/a b:c*d?", "TestRender_a_b_c_d_.golden"},
+		{`TestRender/"x"<y>|z\`, "TestRender__x__y__z_.golden"},
+	}
+	for _, tt := range tests {
+		if got := goldenName(tt.name); got != tt.want {
+			t.Errorf("goldenName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Test recording the failures of Golden instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+	panic(r)
+}
+
+// Run GoldenFile with a recorder, returning it once GoldenFile returns or
+// fails the test.
+func golden(t *testing.T, name string, got string) (r *recorder) {
+	r = &recorder{TB: t}
+	defer func() {
+		if v := recover(); v != nil && v != r {
+			panic(v)
+		}
+	}()
+	GoldenFile(r, name, got)
+	return r
+}
+
+func TestGoldenFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	t.Setenv(updateEnv, "1")
+	if r := golden(t, "out.golden", "\x1b[1mtitle\x1b[0m  \r\nbody\n\n"); len(r.errors) > 0 {
+		t.Fatalf("update: %v", r.errors)
+	}
+	b, err := os.ReadFile(filepath.Join("testdata", "out.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "<1>title<0>\nbody\n" {
+		t.Errorf("written golden file = %q", b)
+	}
+
+	t.Setenv(updateEnv, "")
+	if r := golden(t, "out.golden", "\x1b[1mtitle\x1b[m\nbody"); len(r.errors) > 0 {
+		t.Errorf("same output: %v", r.errors)
+	}
+
+	r := golden(t, "out.golden", "\x1b[1mtitle\x1b[0m\nchanged")
+	if len(r.errors) != 1 || r.fatal {
+		t.Fatalf("different output: errors %v, fatal %v", r.errors, r.fatal)
+	}
+	for _, want := range []string{filepath.Join("testdata", "out.golden"), "-body", "+changed", updateEnv + "=1"} {
+		if !strings.Contains(r.errors[0], want) {
+			t.Errorf("different output: error without %q:\n%s", want, r.errors[0])
+		}
+	}
+
+	r = golden(t, "missing.golden", "text")
+	if !r.fatal || !strings.Contains(r.errors[0], updateEnv+"=1") {
+		t.Errorf("missing golden file: errors %v, fatal %v", r.errors, r.fatal)
+	}
+}
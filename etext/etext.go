@@ -0,0 +1,85 @@
+// Package etext lays out text for the terminal: it wraps, indents,
+// truncates and pads strings by their width in cells, ignoring the escape
+// sequences of styled text and counting wide characters like CJK and emoji
+// as two cells, unlike len which counts bytes.
+//
+//	name := etext.PadRight(etext.Truncate(style.Render(pod.Name), 20), 20)
+package etext
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Appended by Truncate to the truncated text.
+const Ellipsis = "…"
+
+// Returns the width of s in cells, the width of its widest line when it
+// has several.
+//
+//	etext.Width("\x1b[1mbold\x1b[0m") // 4
+//	etext.Width("日本") // 4
+func Width(s string) int {
+	width := 0
+	for _, line := range strings.Split(s, "\n") {
+		width = max(width, ansi.StringWidth(line))
+	}
+	return width
+}
+
+// Wrap the lines of s at width cells, breaking between words when
+// possible and inside the words longer than width. The styles of s are
+// kept.
+//
+//	fmt.Println(etext.Wrap(description, 60))
+func Wrap(s string, width int) string {
+	return ansi.Wrap(s, max(width, 1), "")
+}
+
+// Indent the lines of s by n spaces, except the empty ones.
+//
+//	fmt.Println(etext.Indent(details, 4))
+func Indent(s string, n int) string {
+	prefix := strings.Repeat(" ", max(n, 0))
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Truncate the single line s to width cells, replacing the end with
+// Ellipsis when it is longer. The styles of s are kept.
+//
+//	title = etext.Truncate(title, 30)
+func Truncate(s string, width int) string {
+	return ansi.Truncate(s, max(width, 0), Ellipsis)
+}
+
+// Pad the single line s with spaces on the right to width cells. s is
+// returned unchanged when it is wider.
+//
+//	cell := etext.PadRight(style.Render(name), 20)
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-ansi.StringWidth(s), 0))
+}
+
+// Pad the single line s with spaces on the left to width cells, to align
+// it on the right. s is returned unchanged when it is wider.
+//
+//	cell := etext.PadLeft(strconv.Itoa(count), 6)
+func PadLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-ansi.StringWidth(s), 0)) + s
+}
+
+// Pad the single line s with spaces on both sides to width cells, to center
+// it. The extra space goes on the right when the padding is odd.
+//
+//	title := etext.Center("Summary", 40)
+func Center(s string, width int) string {
+	gap := max(width-ansi.StringWidth(s), 0)
+	return strings.Repeat(" ", gap/2) + s + strings.Repeat(" ", gap-gap/2)
+}
@@ -0,0 +1,106 @@
+package etext
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"\x1b[1mbold\x1b[0m", 4},
+		{"日本", 4},
+		{"short\nlonger line\nmid", 11},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.s); got != tt.want {
+			t.Errorf("Width(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"the quick brown fox", 10, "the quick\nbrown fox"},
+		{"abcdefghij", 4, "abcd\nefgh\nij"},
+		{"one two\nthree four", 5, "one\ntwo\nthree\nfour"},
+		{"abc", 0, "a\nb\nc"},
+		{"\x1b[1mbold text\x1b[0m", 4, "\x1b[1mbold\ntext\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := Wrap(tt.s, tt.width); got != tt.want {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestIndent(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"a", 2, "  a"},
+		{"a\n\nb", 2, "  a\n\n  b"},
+		{"a", 0, "a"},
+		{"a", -1, "a"},
+	}
+	for _, tt := range tests {
+		if got := Indent(tt.s, tt.n); got != tt.want {
+			t.Errorf("Indent(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exact", 5, "exact"},
+		{"truncated", 5, "trun…"},
+		{"日本語", 4, "日…"},
+		{"\x1b[1mbold text\x1b[0m", 5, "\x1b[1mbold…\x1b[0m"},
+		{"text", 0, ""},
+		{"text", -1, ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		s      string
+		width  int
+		right  string
+		left   string
+		center string
+	}{
+		{"ab", 5, "ab   ", "   ab", " ab  "},
+		{"abcdef", 3, "abcdef", "abcdef", "abcdef"},
+		{"日本", 6, "日本  ", "  日本", " 日本 "},
+		{"\x1b[1mab\x1b[0m", 4, "\x1b[1mab\x1b[0m  ", "  \x1b[1mab\x1b[0m", " \x1b[1mab\x1b[0m "},
+	}
+	for _, tt := range tests {
+		if got := PadRight(tt.s, tt.width); got != tt.right {
+			t.Errorf("PadRight(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.right)
+		}
+		if got := PadLeft(tt.s, tt.width); got != tt.left {
+			t.Errorf("PadLeft(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.left)
+		}
+		if got := Center(tt.s, tt.width); got != tt.center {
+			t.Errorf("Center(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.center)
+		}
+	}
+}
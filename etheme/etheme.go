@@ -0,0 +1,164 @@
+// Package etheme defines the colors shared by the components. The default
+// styles of the other packages are built from the current Theme, so a
+// single SetTheme restyles the whole CLI.
+package etheme
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a set of named color roles, each with a variant for terminals
+// with a light background and one for terminals with a dark background.
+type Theme struct {
+	Name string
+	// Titles, headings and selections
+	Primary lipgloss.AdaptiveColor
+	// Secondary highlights, like numbers and links
+	Accent  lipgloss.AdaptiveColor
+	Success lipgloss.AdaptiveColor
+	Warning lipgloss.AdaptiveColor
+	Error   lipgloss.AdaptiveColor
+	// Secondary text, like hints and timestamps, usually rendered faint
+	Muted lipgloss.AdaptiveColor
+	// Text drawn on the other colors used as backgrounds, like in badges,
+	// bars and search matches
+	Contrast lipgloss.AdaptiveColor
+	// Background of the highlighted areas, like the highlighted lines of
+	// code and the footer bars
+	Surface lipgloss.AdaptiveColor
+	// Ends of the gradients, like the ones of progress bars, banners and
+	// heatmaps. They are hex colors, as the ANSI colors cannot be blended.
+	GradientFrom lipgloss.Color
+	GradientTo   lipgloss.Color
+}
+
+// Default Theme, using the ANSI colors of the terminal so it follows the
+// palette of the user.
+var ThemeDefault = Theme{
+	Name:    "default",
+	Primary: lipgloss.AdaptiveColor{Light: "4", Dark: "4"},
+	Accent:  lipgloss.AdaptiveColor{Light: "6", Dark: "6"},
+	Success: lipgloss.AdaptiveColor{Light: "2", Dark: "2"},
+	Warning: lipgloss.AdaptiveColor{Light: "3", Dark: "3"},
+	Error:   lipgloss.AdaptiveColor{Light: "1", Dark: "1"},
+	Muted:   lipgloss.AdaptiveColor{Light: "8", Dark: "15"},
+
+	Contrast:     lipgloss.AdaptiveColor{Light: "0", Dark: "0"},
+	Surface:      lipgloss.AdaptiveColor{Light: "7", Dark: "8"},
+	GradientFrom: lipgloss.Color("#3B82F6"),
+	GradientTo:   lipgloss.Color("#22C55E"),
+}
+
+// Theme using the colors of the Nord palette.
+var ThemeNord = Theme{
+	Name:    "nord",
+	Primary: lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+	Accent:  lipgloss.AdaptiveColor{Light: "#B48EAD", Dark: "#B48EAD"},
+	Success: lipgloss.AdaptiveColor{Light: "#4C7A3F", Dark: "#A3BE8C"},
+	Warning: lipgloss.AdaptiveColor{Light: "#B0822B", Dark: "#EBCB8B"},
+	Error:   lipgloss.AdaptiveColor{Light: "#BF616A", Dark: "#BF616A"},
+	Muted:   lipgloss.AdaptiveColor{Light: "#4C566A", Dark: "#D8DEE9"},
+
+	Contrast:     lipgloss.AdaptiveColor{Light: "#ECEFF4", Dark: "#2E3440"},
+	Surface:      lipgloss.AdaptiveColor{Light: "#E5E9F0", Dark: "#3B4252"},
+	GradientFrom: lipgloss.Color("#5E81AC"),
+	GradientTo:   lipgloss.Color("#A3BE8C"),
+}
+
+// Theme without colors, the components keep their bold, faint and reverse
+// attributes.
+var ThemeMonochrome = Theme{
+	Name: "monochrome",
+}
+
+var (
+	mu      sync.RWMutex
+	current = ThemeDefault
+	themes  = map[string]Theme{
+		ThemeDefault.Name:    ThemeDefault,
+		ThemeNord.Name:       ThemeNord,
+		ThemeMonochrome.Name: ThemeMonochrome,
+	}
+	hooks []func(Theme)
+)
+
+// Returns the current Theme.
+func Current() Theme {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Replace the current Theme and rebuild the default styles of the
+// components from it. The components already created keep their style, so
+// the Theme should be set at startup.
+//
+//	etheme.SetTheme(etheme.ThemeNord)
+func SetTheme(t Theme) {
+	mu.Lock()
+	current = t
+	fns := append([]func(Theme){}, hooks...)
+	mu.Unlock()
+	for _, fn := range fns {
+		fn(t)
+	}
+}
+
+// Call fn with the new Theme each time the Theme is set, to rebuild styles
+// depending on it.
+//
+//	etheme.OnChange(func(t etheme.Theme) {
+//		headerStyle = lipgloss.NewStyle().Foreground(t.Primary)
+//	})
+func OnChange(fn func(Theme)) {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = append(hooks, fn)
+}
+
+// Register a Theme under its name, so it can be selected with
+// SetThemeByName, for example from a flag or a configuration file.
+//
+//	etheme.Register(etheme.Theme{Name: "brand", Primary: brandBlue, ...})
+func Register(t Theme) {
+	mu.Lock()
+	defer mu.Unlock()
+	themes[t.Name] = t
+}
+
+// Returns the registered Theme with the given name.
+func Lookup(name string) (Theme, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	t, ok := themes[name]
+	return t, ok
+}
+
+// Set the registered Theme with the given name, see SetTheme. Reports
+// whether a Theme with the name is registered.
+//
+//	if !etheme.SetThemeByName(cfg.Theme) {
+//		elog.Warn("unknown theme", "name", cfg.Theme)
+//	}
+func SetThemeByName(name string) bool {
+	t, ok := Lookup(name)
+	if ok {
+		SetTheme(t)
+	}
+	return ok
+}
+
+// Returns the names of the registered Themes, sorted.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,314 @@
+// Package etimer renders live countdowns, for the waits of a CLI like the
+// delay before a retry or the reset of a rate limit.
+package etimer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/eterm"
+	"github.com/ravvio/easycli-ui/etheme"
+	"github.com/ravvio/easycli-ui/internal/live"
+)
+
+// ErrInterrupted is returned when the countdown is interrupted by the user
+// with Ctrl+C, SIGINT or SIGTERM.
+var ErrInterrupted = eterm.ErrInterrupted
+
+// Identifiers of the TimerModels, to route their messages.
+var lastTimerID atomic.Int64
+
+// Timer style definition.
+type TimerStyle struct {
+	LabelStyle     lipgloss.Style
+	TimeStyle      lipgloss.Style
+	CancelledStyle lipgloss.Style
+}
+
+// Default TimerStyle used by Countdown. Uses the primary color of the
+// etheme.Theme for the time left.
+var TimerStyleDefault = timerStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		TimerStyleDefault = timerStyle(t)
+	})
+}
+
+// Returns TimerStyleDefault for the theme t.
+func timerStyle(t etheme.Theme) TimerStyle {
+	return TimerStyle{
+		LabelStyle:     lipgloss.NewStyle(),
+		TimeStyle:      lipgloss.NewStyle().Foreground(t.Primary).Bold(true),
+		CancelledStyle: lipgloss.NewStyle().Foreground(t.Error),
+	}
+}
+
+// TickMsg updates the time left of the TimerModel with the same ID.
+type TickMsg struct {
+	ID  int64
+	tag int
+}
+
+// DoneMsg is sent by the TimerModel with the same ID when it reaches zero.
+type DoneMsg struct {
+	ID int64
+}
+
+// TimerModel counts down a duration, rendered as mm:ss after its label.
+// Run it until it completes, or embed it in an existing bubbletea program:
+// forward it the messages of the program, render its View and wait for its
+// DoneMsg.
+//
+//	type model struct {
+//		timer etimer.TimerModel
+//	}
+//
+//	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+//		if msg, ok := msg.(etimer.DoneMsg); ok && msg.ID == m.timer.ID() {
+//			return m, retry
+//		}
+//		var cmd tea.Cmd
+//		m.timer, cmd = m.timer.Update(msg)
+//		return m, cmd
+//	}
+type TimerModel struct {
+	id        int64
+	label     string
+	duration  time.Duration
+	deadline  time.Time
+	left      time.Duration
+	style     TimerStyle
+	onDone    func()
+	done      bool
+	cancelled bool
+	tag       int
+}
+
+// Create a countdown of d, started when the TimerModel is run or
+// initialized.
+//
+//	err := etimer.Countdown(30*time.Second, "Rate limited, retrying in").Run()
+func Countdown(d time.Duration, label string) TimerModel {
+	return TimerModel{
+		id:       lastTimerID.Add(1),
+		label:    label,
+		duration: max(d, 0),
+		left:     max(d, 0),
+		style:    TimerStyleDefault,
+	}
+}
+
+// Specify the style of the TimerModel.
+//
+//	t := etimer.Countdown(d, label).WithStyle(style)
+func (m TimerModel) WithStyle(s TimerStyle) TimerModel {
+	m.style = s
+	return m
+}
+
+// Specify a function called once when the countdown reaches zero, from the
+// Update of the TimerModel. It is not called when the countdown is
+// cancelled.
+//
+//	t := etimer.Countdown(d, label).WithOnDone(func() { elog.Info("retrying") })
+func (m TimerModel) WithOnDone(fn func()) TimerModel {
+	m.onDone = fn
+	return m
+}
+
+// Identifier of the TimerModel, set in its messages.
+func (m TimerModel) ID() int64 {
+	return m.id
+}
+
+// Returns the time left, rounded to the second shown.
+func (m TimerModel) Left() time.Duration {
+	return m.left
+}
+
+// Reports whether the countdown reached zero.
+func (m TimerModel) Done() bool {
+	return m.done
+}
+
+// Stop the countdown without completing it: the TimerModel ignores its next
+// ticks and its View shows it was cancelled.
+//
+//	m.timer = m.timer.Cancel()
+func (m TimerModel) Cancel() TimerModel {
+	if !m.done {
+		m.cancelled = true
+		m.tag++
+	}
+	return m
+}
+
+// Start the countdown.
+func (m TimerModel) Init() tea.Cmd {
+	id, tag := m.id, m.tag
+	return func() tea.Msg {
+		return TickMsg{ID: id, tag: tag}
+	}
+}
+
+// Returns the command updating the time left when its second changes.
+func (m TimerModel) nextTick(left time.Duration) tea.Cmd {
+	id, tag := m.id, m.tag
+	wait := left - left.Truncate(time.Second)
+	if wait <= 0 {
+		wait = time.Second
+	}
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return TickMsg{ID: id, tag: tag}
+	})
+}
+
+func (m TimerModel) Update(msg tea.Msg) (TimerModel, tea.Cmd) {
+	msgTick, ok := msg.(TickMsg)
+	if !ok || msgTick.ID != m.id || msgTick.tag != m.tag || m.done || m.cancelled {
+		return m, nil
+	}
+	if m.deadline.IsZero() {
+		m.deadline = time.Now().Add(m.duration)
+	}
+	left := time.Until(m.deadline)
+	if left > 0 {
+		// Seconds are rounded up, so the countdown ends on 00:00
+		m.left = left.Truncate(time.Second)
+		if m.left < left {
+			m.left += time.Second
+		}
+		return m, m.nextTick(left)
+	}
+
+	m.left, m.done = 0, true
+	if m.onDone != nil {
+		m.onDone()
+	}
+	id := m.id
+	return m, func() tea.Msg {
+		return DoneMsg{ID: id}
+	}
+}
+
+// Format d as mm:ss, or h:mm:ss from one hour.
+func format(d time.Duration) string {
+	s := int(d.Round(time.Second) / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%02d:%02d", s/60, s%60)
+}
+
+func (m TimerModel) View() string {
+	if m.cancelled {
+		return m.style.CancelledStyle.Render(m.label + " " + format(m.left) + " cancelled")
+	}
+	return m.style.LabelStyle.Render(m.label) + " " + m.style.TimeStyle.Render(format(m.left))
+}
+
+// Run the countdown until it reaches zero, then remove its line. On SIGINT,
+// SIGTERM or Ctrl+C the countdown is cancelled and ErrInterrupted is
+// returned.
+// When stdout is not a terminal the countdown is printed once and waited
+// without animation.
+//
+//	if err := etimer.Countdown(wait, "Retrying in").Run(); err != nil {
+//		return err
+//	}
+func (m TimerModel) Run() error {
+	return m.RunContext(context.Background())
+}
+
+// Run the countdown like Run, stopping when ctx is done with its error.
+//
+//	err := etimer.Countdown(wait, "Retrying in").RunContext(ctx)
+func (m TimerModel) RunContext(ctx context.Context) error {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	if !eterm.IsTerminal(os.Stdout) {
+		fmt.Println(m.View())
+		t := time.NewTimer(m.duration)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			if m.onDone != nil {
+				m.onDone()
+			}
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sig:
+			return ErrInterrupted
+		}
+	}
+
+	tp := tea.NewProgram(runModel{timer: m}, tea.WithoutSignalHandler())
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-sig:
+			tp.Send(runMsgStop{err: ErrInterrupted})
+		case <-ctx.Done():
+			tp.Send(runMsgStop{err: ctx.Err()})
+		case <-finished:
+		}
+	}()
+
+	final, err := live.Run(tp)
+	if err != nil {
+		return err
+	}
+	return final.(runModel).err
+}
+
+// The bubbletea.Msg cancelling a running countdown
+type runMsgStop struct {
+	err error
+}
+
+// Bubbletea model of a running countdown.
+type runModel struct {
+	timer TimerModel
+	err   error
+}
+
+func (m runModel) Init() tea.Cmd {
+	return m.timer.Init()
+}
+
+func (m runModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.timer, m.err = m.timer.Cancel(), ErrInterrupted
+			return m, tea.Quit
+		}
+	case runMsgStop:
+		m.timer, m.err = m.timer.Cancel(), msg.err
+		return m, tea.Quit
+	case DoneMsg:
+		return m, tea.Quit
+	}
+	var cmd tea.Cmd
+	m.timer, cmd = m.timer.Update(msg)
+	return m, cmd
+}
+
+func (m runModel) View() string {
+	if m.timer.Done() {
+		return ""
+	}
+	return m.timer.View() + "\n"
+}
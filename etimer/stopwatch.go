@@ -0,0 +1,198 @@
+package etimer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/easycli-ui/etheme"
+)
+
+// Report style definition. The phase taking the most time is rendered with
+// SlowestStyle, the total row with TotalStyle.
+type ReportStyle struct {
+	TableStyle   etable.TableStyle
+	SlowestStyle lipgloss.Style
+	TotalStyle   lipgloss.Style
+}
+
+// Default ReportStyle used by Stopwatch.RenderReport. Uses the default
+// TableStyle of etable and the warning color of the etheme.Theme for the
+// slowest phase.
+var ReportStyleDefault = reportStyle(etheme.Current())
+
+func init() {
+	// The hook of etable runs first, as it is registered when etable is
+	// initialized.
+	etheme.OnChange(func(t etheme.Theme) {
+		ReportStyleDefault = reportStyle(t)
+	})
+}
+
+// Returns ReportStyleDefault for the theme t.
+func reportStyle(t etheme.Theme) ReportStyle {
+	return ReportStyle{
+		TableStyle:   etable.TableStyleDefault,
+		SlowestStyle: lipgloss.NewStyle().Foreground(t.Warning),
+		TotalStyle:   lipgloss.NewStyle().Bold(true),
+	}
+}
+
+// A phase timed by a Stopwatch.
+type Lap struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Stopwatch times the phases of a command, each ending with a named lap.
+// It is safe for concurrent use.
+type Stopwatch struct {
+	mu    sync.Mutex
+	start time.Time
+	last  time.Time
+	laps  []Lap
+	style ReportStyle
+}
+
+// Create a Stopwatch, started now.
+//
+//	sw := etimer.NewStopwatch()
+//	resolve()
+//	sw.Lap("resolve")
+//	download()
+//	sw.Lap("download")
+//	fmt.Println(sw.RenderReport())
+func NewStopwatch() *Stopwatch {
+	now := time.Now()
+	return &Stopwatch{
+		start: now,
+		last:  now,
+		style: ReportStyleDefault,
+	}
+}
+
+// Specify the style of the report.
+//
+//	sw := etimer.NewStopwatch().WithStyle(style)
+func (s *Stopwatch) WithStyle(st ReportStyle) *Stopwatch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.style = st
+	return s
+}
+
+// End the current phase as name, returning its duration. The next phase
+// starts now. Laps with the same name are added up in the report.
+//
+//	sw.Lap("build")
+func (s *Stopwatch) Lap(name string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	d := now.Sub(s.last)
+	s.last = now
+	s.laps = append(s.laps, Lap{Name: name, Duration: d})
+	return d
+}
+
+// Returns the laps, in order.
+func (s *Stopwatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Lap(nil), s.laps...)
+}
+
+// Returns the time elapsed since the Stopwatch started.
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Since(s.start)
+}
+
+// Returns the phases of the laps in order of first appearance, with the
+// durations of the laps with the same name added up.
+func (s *Stopwatch) phases() []Lap {
+	phases := []Lap{}
+	index := map[string]int{}
+	for _, lap := range s.laps {
+		if i, ok := index[lap.Name]; ok {
+			phases[i].Duration += lap.Duration
+			continue
+		}
+		index[lap.Name] = len(phases)
+		phases = append(phases, lap)
+	}
+	return phases
+}
+
+// Format a duration with a precision fitting its magnitude.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(10 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// Render a table of the phases with their duration and their share of the
+// total, followed by the total of the laps.
+//
+//	fmt.Println(sw.RenderReport())
+//
+//	Phase     Duration  Share
+//	resolve      120ms   8.1%
+//	download     1.31s  88.5%
+//	install       50ms   3.4%
+//	total        1.48s   100%
+func (s *Stopwatch) RenderReport() string {
+	s.mu.Lock()
+	phases := s.phases()
+	style := s.style
+	s.mu.Unlock()
+
+	var total time.Duration
+	slowest := -1
+	for i, p := range phases {
+		total += p.Duration
+		if slowest < 0 || p.Duration > phases[slowest].Duration {
+			slowest = i
+		}
+	}
+
+	// The cells of the slowest phase and of the total are styled in the rows
+	styled := func(cell lipgloss.Style, values ...string) []string {
+		for i, v := range values {
+			values[i] = cell.Render(v)
+		}
+		return values
+	}
+	rows := make([]etable.TableRow, 0, len(phases)+1)
+	for i, p := range phases {
+		share := 0.0
+		if total > 0 {
+			share = float64(p.Duration) / float64(total) * 100
+		}
+		cell := lipgloss.NewStyle()
+		if i == slowest {
+			cell = style.SlowestStyle
+		}
+		values := styled(cell, p.Name, formatDuration(p.Duration), fmt.Sprintf("%.1f%%", share))
+		rows = append(rows, etable.TableRow{"phase": values[0], "duration": values[1], "share": values[2]})
+	}
+	values := styled(style.TotalStyle, "total", formatDuration(total), "100%")
+	rows = append(rows, etable.TableRow{"phase": values[0], "duration": values[1], "share": values[2]})
+
+	columns := []etable.TableColumn{
+		etable.NewTableColumn("phase", "Phase"),
+		etable.NewTableColumn("duration", "Duration").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn("share", "Share").WithAlignment(etable.TableAlignmentRight),
+	}
+	t := etable.NewTable(columns).WithStyle(style.TableStyle).WithRows(rows)
+	return t.Render()
+}
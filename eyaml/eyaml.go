@@ -0,0 +1,315 @@
+package eyaml
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ravvio/easycli-ui/etheme"
+	"gopkg.in/yaml.v3"
+)
+
+// YAML style definition. Guide is rendered at each level of indentation,
+// when empty the levels are indented with spaces only.
+type YAMLStyle struct {
+	KeyStyle         lipgloss.Style
+	StringStyle      lipgloss.Style
+	NumberStyle      lipgloss.Style
+	BoolStyle        lipgloss.Style
+	NullStyle        lipgloss.Style
+	AnchorStyle      lipgloss.Style
+	TagStyle         lipgloss.Style
+	CommentStyle     lipgloss.Style
+	PunctuationStyle lipgloss.Style
+	GuideStyle       lipgloss.Style
+	Guide            string
+}
+
+// Default YAMLStyle used by Render. Uses the primary color of the etheme.Theme
+// for the keys, like the other components.
+var YAMLStyleDefault = yamlStyle(etheme.Current())
+
+func init() {
+	etheme.OnChange(func(t etheme.Theme) {
+		YAMLStyleDefault = yamlStyle(t)
+	})
+}
+
+// Returns YAMLStyleDefault for the theme t.
+func yamlStyle(t etheme.Theme) YAMLStyle {
+	return YAMLStyle{
+		KeyStyle:         lipgloss.NewStyle().Foreground(t.Primary),
+		StringStyle:      lipgloss.NewStyle().Foreground(t.Success),
+		NumberStyle:      lipgloss.NewStyle().Foreground(t.Accent),
+		BoolStyle:        lipgloss.NewStyle().Foreground(t.Warning),
+		NullStyle:        lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		AnchorStyle:      lipgloss.NewStyle().Foreground(t.Accent),
+		TagStyle:         lipgloss.NewStyle().Foreground(t.Accent).Italic(true),
+		CommentStyle:     lipgloss.NewStyle().Foreground(t.Muted).Faint(true).Italic(true),
+		PunctuationStyle: lipgloss.NewStyle(),
+		GuideStyle:       lipgloss.NewStyle().Foreground(t.Muted).Faint(true),
+		Guide:            "│",
+	}
+}
+
+// Render the YAML documents in data highlighting the keys, the values by
+// type, the anchors, the aliases, the tags and the comments, with
+// YAMLStyleDefault. The documents are reindented by two spaces per level,
+// marked by indentation guides; the order of the keys, the comments and the
+// style of the scalars are kept.
+//
+//	out, err := eyaml.Render(config)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(out)
+func Render(data []byte) (string, error) {
+	return RenderWithStyle(data, YAMLStyleDefault)
+}
+
+// Render the YAML documents in data like Render, styled with s.
+//
+//	out, err := eyaml.RenderWithStyle(config, style)
+func RenderWithStyle(data []byte, s YAMLStyle) (string, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	documents := []string{}
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		r := renderer{style: s}
+		r.document(&doc)
+		documents = append(documents, strings.Join(r.lines, "\n"))
+	}
+	return strings.Join(documents, "\n"+s.PunctuationStyle.Render("---")+"\n"), nil
+}
+
+// Renders the lines of a YAML document.
+type renderer struct {
+	style YAMLStyle
+	lines []string
+}
+
+// Append a line, without trailing spaces.
+func (r *renderer) line(s string) {
+	r.lines = append(r.lines, strings.TrimRight(s, " "))
+}
+
+// Append the lines of a comment, with prefix.
+func (r *renderer) comment(prefix string, comment string) {
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		r.line(prefix + r.style.CommentStyle.Render(line))
+	}
+}
+
+// Returns a comment following a value on the same line.
+func (r *renderer) lineComment(nodes ...*yaml.Node) string {
+	for _, n := range nodes {
+		if n.LineComment != "" {
+			return " " + r.style.CommentStyle.Render(n.LineComment)
+		}
+	}
+	return ""
+}
+
+// Returns the indentation of the children of a value indented by prefix.
+func (r *renderer) indent(prefix string) string {
+	if r.style.Guide == "" {
+		return prefix + "  "
+	}
+	return prefix + r.style.GuideStyle.Render(r.style.Guide) + " "
+}
+
+func (r *renderer) document(doc *yaml.Node) {
+	r.comment("", doc.HeadComment)
+	if len(doc.Content) > 0 {
+		r.block(doc.Content[0], "", "")
+	}
+	r.comment("", doc.FootComment)
+}
+
+// Reports whether the node is rendered on the lines following its key or
+// its dash.
+func isBlock(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(n.Content) > 0 && n.Style&yaml.FlowStyle == 0
+	case yaml.ScalarNode:
+		return n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0
+	}
+	return false
+}
+
+// Append the lines of a value, the first of them prefixed by first and the
+// others by rest.
+func (r *renderer) block(n *yaml.Node, first string, rest string) {
+	switch {
+	case n.Kind == yaml.MappingNode && isBlock(n):
+		r.comment(rest, n.HeadComment)
+		r.mapping(n, first, rest)
+		r.comment(rest, n.FootComment)
+	case n.Kind == yaml.SequenceNode && isBlock(n):
+		r.comment(rest, n.HeadComment)
+		r.sequence(n, first, rest)
+		r.comment(rest, n.FootComment)
+	case isBlock(n):
+		r.line(first + r.properties(n) + r.blockIndicator(n))
+		r.blockScalar(n, rest)
+	default:
+		r.comment(rest, n.HeadComment)
+		r.line(first + r.inline(n) + r.lineComment(n))
+		r.comment(rest, n.FootComment)
+	}
+}
+
+func (r *renderer) mapping(n *yaml.Node, first string, rest string) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		r.comment(rest, key.HeadComment)
+
+		head := prefix + r.key(key) + r.style.PunctuationStyle.Render(":")
+		switch {
+		case value.Kind == yaml.ScalarNode && isBlock(value):
+			r.line(head + " " + r.properties(value) + r.blockIndicator(value) + r.lineComment(key, value))
+			r.blockScalar(value, r.indent(rest))
+		case isBlock(value):
+			properties := strings.TrimSpace(r.properties(value))
+			if properties != "" {
+				properties = " " + properties
+			}
+			r.line(head + properties + r.lineComment(key, value))
+			r.comment(r.indent(rest), value.HeadComment)
+			if value.Kind == yaml.MappingNode {
+				r.mapping(value, r.indent(rest), r.indent(rest))
+			} else {
+				r.sequence(value, r.indent(rest), r.indent(rest))
+			}
+			r.comment(r.indent(rest), value.FootComment)
+		default:
+			r.line(head + " " + r.inline(value) + r.lineComment(key, value))
+		}
+		r.comment(rest, key.FootComment)
+	}
+}
+
+func (r *renderer) sequence(n *yaml.Node, first string, rest string) {
+	dash := r.style.PunctuationStyle.Render("-") + " "
+	for i, item := range n.Content {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if isBlock(item) && item.Kind != yaml.ScalarNode {
+			if properties := r.properties(item); properties != "" {
+				// Anchors and tags of collections precede their first line
+				r.line(prefix + dash + strings.TrimSpace(properties))
+				r.block(item, rest+"  ", rest+"  ")
+				continue
+			}
+		}
+		r.block(item, prefix+dash, rest+"  ")
+	}
+}
+
+// Render a key of a mapping.
+func (r *renderer) key(n *yaml.Node) string {
+	return r.properties(n) + r.style.KeyStyle.Render(quote(n))
+}
+
+// Render the anchor and the explicit tag of a node, followed by a space.
+func (r *renderer) properties(n *yaml.Node) string {
+	s := ""
+	if n.Style&yaml.TaggedStyle != 0 {
+		s += r.style.TagStyle.Render(n.Tag) + " "
+	}
+	if n.Anchor != "" {
+		s += r.style.AnchorStyle.Render("&"+n.Anchor) + " "
+	}
+	return s
+}
+
+// Render a value on a single line.
+func (r *renderer) inline(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return r.style.AnchorStyle.Render("*" + n.Value)
+	case yaml.MappingNode:
+		parts := []string{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			parts = append(parts, r.key(n.Content[i])+r.style.PunctuationStyle.Render(":")+" "+r.inline(n.Content[i+1]))
+		}
+		return r.properties(n) + r.style.PunctuationStyle.Render("{") + strings.Join(parts, r.style.PunctuationStyle.Render(", ")) + r.style.PunctuationStyle.Render("}")
+	case yaml.SequenceNode:
+		parts := []string{}
+		for _, item := range n.Content {
+			parts = append(parts, r.inline(item))
+		}
+		return r.properties(n) + r.style.PunctuationStyle.Render("[") + strings.Join(parts, r.style.PunctuationStyle.Render(", ")) + r.style.PunctuationStyle.Render("]")
+	}
+	return r.properties(n) + r.scalarStyle(n).Render(quote(n))
+}
+
+// Returns the style of a scalar, by type.
+func (r *renderer) scalarStyle(n *yaml.Node) lipgloss.Style {
+	switch n.ShortTag() {
+	case "!!int", "!!float":
+		return r.style.NumberStyle
+	case "!!bool":
+		return r.style.BoolStyle
+	case "!!null":
+		return r.style.NullStyle
+	}
+	return r.style.StringStyle
+}
+
+// Returns a scalar as written in the document, with its quotes.
+func quote(n *yaml.Node) string {
+	switch {
+	case n.Style&yaml.DoubleQuotedStyle != 0:
+		return strconv.Quote(n.Value)
+	case n.Style&yaml.SingleQuotedStyle != 0:
+		return "'" + strings.ReplaceAll(n.Value, "'", "''") + "'"
+	case strings.Contains(n.Value, "\n"):
+		// Block scalars written inline
+		return strconv.Quote(n.Value)
+	}
+	return n.Value
+}
+
+// Returns the indicator of a literal or folded scalar, with its chomping.
+func (r *renderer) blockIndicator(n *yaml.Node) string {
+	indicator := "|"
+	if n.Style&yaml.FoldedStyle != 0 {
+		indicator = ">"
+	}
+	switch {
+	case !strings.HasSuffix(n.Value, "\n"):
+		indicator += "-"
+	case strings.HasSuffix(n.Value, "\n\n"):
+		indicator += "+"
+	}
+	return r.style.PunctuationStyle.Render(indicator)
+}
+
+// Append the lines of a literal or folded scalar, with prefix.
+func (r *renderer) blockScalar(n *yaml.Node, prefix string) {
+	value := strings.TrimSuffix(n.Value, "\n")
+	for _, line := range strings.Split(value, "\n") {
+		r.line(prefix + r.style.StringStyle.Render(line))
+	}
+}
@@ -0,0 +1,99 @@
+// Package fuzzy implements the fuzzy matching shared by the interactive
+// components.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Match reports whether all the runes of pattern appear in s in the same
+// order, ignoring case, and returns a score for the match. Higher scores are
+// better matches: consecutive runes, runes at the start of words and exact
+// substrings are rewarded.
+func Match(pattern string, s string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+
+	p := []rune(strings.ToLower(pattern))
+	r := []rune(s)
+	score := 0
+	pi := 0
+	prev := -2
+	for i, c := range r {
+		if pi == len(p) {
+			break
+		}
+		if unicode.ToLower(c) != p[pi] {
+			continue
+		}
+
+		score++
+		if prev == i-1 {
+			score += 5
+		}
+		if i == 0 || isSeparator(r[i-1]) {
+			score += 10
+		}
+		prev = i
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+
+	if strings.Contains(strings.ToLower(s), string(p)) {
+		score += 20
+	}
+	return score, true
+}
+
+// Indexes returns the indexes of the runes of s matched by pattern, as
+// Match matches them, to highlight them. Returns nil when s does not match.
+func Indexes(pattern string, s string) []int {
+	p := []rune(strings.ToLower(pattern))
+	indexes := make([]int, 0, len(p))
+	for i, c := range []rune(s) {
+		if len(indexes) == len(p) {
+			break
+		}
+		if unicode.ToLower(c) == p[len(indexes)] {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) < len(p) {
+		return nil
+	}
+	return indexes
+}
+
+// Filter returns the indexes of the items matching pattern, best matches
+// first. Items with the same score keep their order.
+func Filter(pattern string, items []string) []int {
+	type match struct {
+		index int
+		score int
+	}
+
+	matches := make([]match, 0)
+	for i, item := range items {
+		if score, ok := Match(pattern, item); ok {
+			matches = append(matches, match{index: i, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+	return indexes
+}
+
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r)
+}
@@ -0,0 +1,87 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		{"", "anything", true},
+		{"abc", "abc", true},
+		{"abc", "a-b-c", true},
+		{"ABC", "abc", true},
+		{"abc", "ABC", true},
+		{"acb", "abc", false},
+		{"abcd", "abc", false},
+		{"é", "café", true},
+		{"x", "", false},
+	}
+	for _, tt := range tests {
+		if _, got := Match(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestMatchScore(t *testing.T) {
+	// Each pair is ordered from the better match to the worse one
+	tests := []struct {
+		pattern string
+		better  string
+		worse   string
+	}{
+		{"con", "config", "cxoxn"},
+		{"db", "data base", "ladder bar"},
+		{"log", "login", "blog-xlxoxg"},
+		{"set", "settings", "s-e-t"},
+	}
+	for _, tt := range tests {
+		better, _ := Match(tt.pattern, tt.better)
+		worse, _ := Match(tt.pattern, tt.worse)
+		if better <= worse {
+			t.Errorf("Match(%q): %q scores %d, %q scores %d", tt.pattern, tt.better, better, tt.worse, worse)
+		}
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		want    []int
+	}{
+		{"", "abc", []int{}},
+		{"ac", "abc", []int{0, 2}},
+		{"Fb", "foo bar", []int{0, 4}},
+		{"éf", "café fort", []int{3, 5}},
+		{"z", "abc", nil},
+	}
+	for _, tt := range tests {
+		if got := Indexes(tt.pattern, tt.s); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Indexes(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	items := []string{"delete", "deploy", "describe", "get", "apply"}
+	tests := []struct {
+		pattern string
+		want    []int
+	}{
+		{"", []int{0, 1, 2, 3, 4}},
+		{"de", []int{0, 1, 2}},
+		{"ply", []int{4, 1}},
+		{"xyz", []int{}},
+	}
+	for _, tt := range tests {
+		if got := Filter(tt.pattern, items); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Filter(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
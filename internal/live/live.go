@@ -0,0 +1,105 @@
+// Package live tracks the components drawing on the terminal, so that log
+// lines can be printed above them instead of corrupting their output.
+package live
+
+import (
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// A live component, printing lines above its region.
+type region struct {
+	println func(line string)
+	// Sends a message to the program of the component, nil when it is not
+	// a bubbletea program
+	send func(msg tea.Msg)
+}
+
+var (
+	mu      sync.Mutex
+	regions []*region
+	// Size sent to the programs when set, as bubbletea cannot read it from
+	// an output which is not a terminal
+	width  int
+	height int
+)
+
+// Register a live component which prints lines above its region with
+// println, until the returned function is called.
+func Push(println func(line string)) func() {
+	return push(&region{println: println})
+}
+
+func push(r *region) func() {
+	mu.Lock()
+	defer mu.Unlock()
+	regions = append(regions, r)
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := range regions {
+			if regions[i] == r {
+				regions = append(regions[:i], regions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Run p, registered as a live component until it returns.
+func Run(p *tea.Program) (tea.Model, error) {
+	pop := push(&region{
+		println: func(line string) {
+			// Unlike Program.Println, Send does not block once p has stopped
+			p.Send(tea.Println(line)())
+		},
+		send: p.Send,
+	})
+	defer pop()
+
+	mu.Lock()
+	w, h := width, height
+	mu.Unlock()
+	if w > 0 && h > 0 {
+		go p.Send(tea.WindowSizeMsg{Width: w, Height: h})
+	}
+	return p.Run()
+}
+
+// Print line above the live component registered last, if any. Reports
+// whether a live component printed it.
+func Println(line string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(regions) == 0 {
+		return false
+	}
+	regions[len(regions)-1].println(line)
+	return true
+}
+
+// Send msg to the program run last by Run which is still running, if any.
+// Reports whether a program received it.
+func Send(msg tea.Msg) bool {
+	mu.Lock()
+	var send func(msg tea.Msg)
+	for i := len(regions) - 1; i >= 0 && send == nil; i-- {
+		send = regions[i].send
+	}
+	mu.Unlock()
+	if send == nil {
+		return false
+	}
+	send(msg)
+	return true
+}
+
+// Set the size of the terminal sent to the programs started by Run, for
+// outputs which are not a terminal. A size of 0 stops sending it.
+func SetSize(w int, h int) {
+	mu.Lock()
+	defer mu.Unlock()
+	width, height = w, h
+}
@@ -0,0 +1,180 @@
+// Package search implements the / search shared by the pager and the
+// viewers: typing the query, finding the next match and highlighting the
+// matches in already styled text.
+package search
+
+import (
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Search is the state of a search: the query being typed and the last
+// confirmed one. The query matches case insensitively, literally.
+type Search struct {
+	typing bool
+	input  string
+	query  string
+	re     *regexp.Regexp
+}
+
+// Start typing a new query.
+func (s *Search) Start() {
+	s.typing = true
+	s.input = ""
+}
+
+// Reports whether the query is being typed.
+func (s Search) Typing() bool {
+	return s.typing
+}
+
+// Reports whether there is a confirmed query.
+func (s Search) Active() bool {
+	return s.re != nil
+}
+
+// The confirmed query.
+func (s Search) Query() string {
+	return s.query
+}
+
+// Forget the confirmed query.
+func (s *Search) Clear() {
+	s.query = ""
+	s.re = nil
+}
+
+// The query being typed, as rendered in a status line.
+func (s Search) Prompt() string {
+	return "/" + s.input
+}
+
+// Update the query being typed with a key. Enter confirms it, Esc cancels
+// it. Reports whether a non empty query was confirmed, the caller should
+// then move to its first match.
+func (s *Search) Update(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyEsc:
+		s.typing = false
+	case tea.KeyEnter:
+		s.typing = false
+		if s.input == "" {
+			return false
+		}
+		s.query = s.input
+		s.re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(s.input))
+		return true
+	case tea.KeyBackspace:
+		if r := []rune(s.input); len(r) > 0 {
+			s.input = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		s.input += string(msg.Runes)
+	}
+	return false
+}
+
+// Reports whether text, which may be styled, contains the query.
+func (s Search) Match(text string) bool {
+	return s.re != nil && s.re.MatchString(ansi.Strip(text))
+}
+
+// Returns the index of the next element matching after from, or before it
+// when backward is set, wrapping around the n elements. Reports false when
+// none matches.
+func Next(n int, from int, backward bool, match func(i int) bool) (int, bool) {
+	for step := 1; step <= n; step++ {
+		i := (from + step) % n
+		if backward {
+			i = ((from-step)%n + n) % n
+		}
+		if match(i) {
+			return i, true
+		}
+	}
+	return from, false
+}
+
+// Highlight the matches of the query in text with style. The text may be
+// already styled: its style is restored after each match.
+func (s Search) Highlight(text string, style lipgloss.Style) string {
+	if s.re == nil {
+		return text
+	}
+	plain := ansi.Strip(text)
+	matches := s.re.FindAllStringIndex(plain, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	// Escape sequences setting the current style of text
+	active := ""
+	match := ""
+	p := 0
+	for i := 0; i < len(text); {
+		if text[i] == '\x1b' {
+			seq := escapeSequence(text[i:])
+			if strings.HasSuffix(seq, "m") && strings.HasPrefix(seq, "\x1b[") {
+				if seq == "\x1b[0m" || seq == "\x1b[m" {
+					active = ""
+				} else {
+					active += seq
+				}
+			}
+			if len(matches) == 0 || p < matches[0][0] {
+				b.WriteString(seq)
+			}
+			i += len(seq)
+			continue
+		}
+
+		inMatch := len(matches) > 0 && p >= matches[0][0]
+		if inMatch {
+			match += text[i : i+1]
+		} else {
+			b.WriteByte(text[i])
+		}
+		i++
+		p++
+		if inMatch && p == matches[0][1] {
+			b.WriteString(style.Render(match) + active)
+			match = ""
+			matches = matches[1:]
+		}
+	}
+	return b.String()
+}
+
+// Returns the escape sequence at the start of s.
+func escapeSequence(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	switch s[1] {
+	case '[':
+		// Control sequence, ending with a byte in @ to ~
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return s[:i+1]
+			}
+		}
+	case ']':
+		// Operating system command, ending with BEL or ST
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return s[:i+1]
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return s[:i+2]
+			}
+		}
+	default:
+		return s[:2]
+	}
+	return s
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Returns s with the query typed and confirmed.
+func confirmed(query string) Search {
+	var s Search
+	s.Start()
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(query)})
+	s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return s
+}
+
+func TestUpdate(t *testing.T) {
+	var s Search
+	s.Start()
+	if !s.Typing() {
+		t.Fatal("not typing after Start")
+	}
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("pods")})
+	s.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	s.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if got := s.Prompt(); got != "/pod " {
+		t.Errorf("Prompt() = %q, want %q", got, "/pod ")
+	}
+	if !s.Update(tea.KeyMsg{Type: tea.KeyEnter}) {
+		t.Error("Enter did not confirm the query")
+	}
+	if s.Typing() || !s.Active() || s.Query() != "pod " {
+		t.Errorf("after Enter: typing %v, active %v, query %q", s.Typing(), s.Active(), s.Query())
+	}
+
+	s.Start()
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if s.Typing() || s.Query() != "pod " {
+		t.Errorf("after Esc: typing %v, query %q", s.Typing(), s.Query())
+	}
+
+	s.Start()
+	if s.Update(tea.KeyMsg{Type: tea.KeyEnter}) {
+		t.Error("Enter confirmed an empty query")
+	}
+	s.Clear()
+	if s.Active() {
+		t.Error("active after Clear")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		text  string
+		want  bool
+	}{
+		{"pod", "Pods running", true},
+		{"a.b", "a.b", true},
+		{"a.b", "axb", false},
+		{"bold", "\x1b[1mbo\x1b[0mld", true},
+		{"missing", "text", false},
+	}
+	for _, tt := range tests {
+		if got := confirmed(tt.query).Match(tt.text); got != tt.want {
+			t.Errorf("Match(%q) of %q = %v, want %v", tt.text, tt.query, got, tt.want)
+		}
+	}
+	var s Search
+	if s.Match("text") {
+		t.Error("Match without a query")
+	}
+}
+
+func TestNext(t *testing.T) {
+	even := func(i int) bool { return i%2 == 0 }
+	tests := []struct {
+		name     string
+		n        int
+		from     int
+		backward bool
+		match    func(int) bool
+		want     int
+		found    bool
+	}{
+		{"forward", 6, 0, false, even, 2, true},
+		{"wraps forward", 6, 4, false, even, 0, true},
+		{"backward", 6, 4, true, even, 2, true},
+		{"wraps backward", 6, 0, true, even, 4, true},
+		{"only from", 6, 3, false, func(i int) bool { return i == 3 }, 3, true},
+		{"none", 6, 1, false, func(int) bool { return false }, 1, false},
+	}
+	for _, tt := range tests {
+		got, found := Next(tt.n, tt.from, tt.backward, tt.match)
+		if got != tt.want || found != tt.found {
+			t.Errorf("%s: Next = %d, %v, want %d, %v", tt.name, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+	style := lipgloss.NewStyle().Reverse(true)
+
+	tests := []struct {
+		query string
+		text  string
+		want  string
+	}{
+		{"b", "abc", "a\x1b[7mb\x1b[0mc"},
+		{"a", "aXa", "\x1b[7ma\x1b[0mX\x1b[7ma\x1b[0m"},
+		{"z", "abc", "abc"},
+		// The style of the text is restored after the match
+		{"b", "\x1b[1mabc\x1b[0m", "\x1b[1ma\x1b[7mb\x1b[0m\x1b[1mc\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := confirmed(tt.query).Highlight(tt.text, style); got != tt.want {
+			t.Errorf("Highlight(%q) of %q = %q, want %q", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
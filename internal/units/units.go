@@ -0,0 +1,18 @@
+// Package units formats the quantities rendered by the components.
+package units
+
+import "fmt"
+
+// Bytes formats a number of bytes in decimal units, like "12.3 MB".
+func Bytes(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTPE"[exp])
+}